@@ -0,0 +1,135 @@
+// changed.go — Git-aware file selection for --changed. Lists the files
+// that differ from a git revision (tracked changes, staged or not, plus
+// untracked files) so fmt, check, and doc can skip everything else.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultChangedRev is the revision --changed compares against when no
+// value is given.
+const defaultChangedRev = "HEAD"
+
+// addChangedFlag registers --changed[=<git-rev>] on cmd.
+func addChangedFlag(cmd *cobra.Command) {
+	cmd.Flags().String("changed", "", "Only process files that differ from a git revision (default HEAD)")
+	cmd.Flags().Lookup("changed").NoOptDefVal = defaultChangedRev
+}
+
+// gitOutput runs git in dir and returns its stdout, folding stderr into
+// the error on failure.
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %v", args[0], err)
+	}
+	return string(out), nil
+}
+
+// gitRepoRoot returns the top-level directory of the repository holding dir.
+func gitRepoRoot(dir string) (string, error) {
+	out, err := gitOutput(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// changedFiles returns the absolute paths of existing files with one of
+// exts that differ from rev in the working tree, including untracked
+// files that are not ignored. When paths is non-empty only files under
+// those paths (files, directories, or "dir/..." patterns) are kept.
+func changedFiles(rev string, paths []string, exts ...string) ([]string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	root, err := gitRepoRoot(cwd)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := gitOutput(root, "diff", "--name-only", "-z", "--diff-filter=d", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := gitOutput(root, "ls-files", "-z", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	var scopes []string
+	for _, p := range paths {
+		p = strings.TrimSuffix(strings.TrimSuffix(p, "..."), "/")
+		if p == "" {
+			p = "."
+		}
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, abs)
+	}
+
+	seen := map[string]bool{}
+	var files []string
+	for _, name := range strings.Split(diff+untracked, "\x00") {
+		if name == "" || !hasAnySuffix(name, exts) {
+			continue
+		}
+		abs := filepath.Join(root, filepath.FromSlash(name))
+		if seen[abs] || !withinAny(abs, scopes) {
+			continue
+		}
+		if _, err := os.Stat(abs); err != nil {
+			continue
+		}
+		seen[abs] = true
+		files = append(files, abs)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func hasAnySuffix(s string, suffixes []string) bool {
+	for _, suf := range suffixes {
+		if strings.HasSuffix(s, suf) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinAny reports whether path is one of scopes or inside one of them.
+// An empty scope list matches everything.
+func withinAny(path string, scopes []string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, s := range scopes {
+		if path == s || strings.HasPrefix(path, s+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// changed_test.go — Tests for --changed file selection against a scratch
+// git repository: modified, staged, untracked, deleted, and ignored files,
+// extension filtering, and path scoping.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newTestGitRepo creates a git repository with one commit holding files
+// and returns its (symlink-resolved) root.
+func newTestGitRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(dir, name), content)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return dir
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := newTestGitRepo(t, map[string]string{
+		"a.gray":         "a\n",
+		"b.gray":         "b\n",
+		"gone.gray":      "x\n",
+		"src/c.gray":     "c\n",
+		"README.md":      "r\n",
+		".gitignore":     "build/\n",
+		"src/notes.txt":  "n\n",
+		"lib/keep.gray":  "k\n",
+		"lib/other.gray": "o\n",
+	})
+	writeTestFile(t, filepath.Join(dir, "a.gray"), "a2\n")          // modified
+	writeTestFile(t, filepath.Join(dir, "src", "c.gray"), "c2\n")   // modified
+	writeTestFile(t, filepath.Join(dir, "src", "new.gray"), "n\n")  // untracked
+	writeTestFile(t, filepath.Join(dir, "build", "out.gray"), "\n") // ignored
+	writeTestFile(t, filepath.Join(dir, "README.md"), "r2\n")
+	writeTestFile(t, filepath.Join(dir, "src", "notes.txt"), "n2\n")
+	if err := os.Remove(filepath.Join(dir, "gone.gray")); err != nil {
+		t.Fatal(err)
+	}
+
+	runFromTempDir(t, dir, func() {
+		got, err := changedFiles("HEAD", nil, ".gray")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{
+			filepath.Join(dir, "a.gray"),
+			filepath.Join(dir, "src", "c.gray"),
+			filepath.Join(dir, "src", "new.gray"),
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("changedFiles = %v, want %v", got, want)
+		}
+
+		got, err = changedFiles("HEAD", []string{"./src/..."}, ".gray", ".md")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = []string{filepath.Join(dir, "src", "c.gray"), filepath.Join(dir, "src", "new.gray")}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("scoped changedFiles = %v, want %v", got, want)
+		}
+
+		got, err = changedFiles("HEAD", []string{"README.md"}, ".gray", ".md")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{filepath.Join(dir, "README.md")}; !reflect.DeepEqual(got, want) {
+			t.Errorf("file-scoped changedFiles = %v, want %v", got, want)
+		}
+
+		if _, err := changedFiles("no-such-rev", nil, ".gray"); err == nil {
+			t.Error("expected an error for an unknown revision")
+		}
+	})
+}
+
+func TestWithinAny(t *testing.T) {
+	sep := string(filepath.Separator)
+	scopes := []string{sep + "p" + sep + "src"}
+	for path, want := range map[string]bool{
+		sep + "p" + sep + "src":                   true,
+		sep + "p" + sep + "src" + sep + "a.gray":  true,
+		sep + "p" + sep + "src2" + sep + "a.gray": false,
+		sep + "p" + sep + "a.gray":                false,
+	} {
+		if got := withinAny(path, scopes); got != want {
+			t.Errorf("withinAny(%q) = %v, want %v", path, got, want)
+		}
+	}
+	if !withinAny("/anything", nil) {
+		t.Error("empty scope list should match everything")
+	}
+}
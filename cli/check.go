@@ -0,0 +1,173 @@
+// check.go — Multi-path type checking for "gray check". A single path
+// streams grayc's output directly; several paths are checked in parallel
+// and, once all are done, their results are printed in argument order.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+// checkResult is the captured outcome of checking one path.
+type checkResult struct {
+	output []byte
+	code   int
+	err    error
+}
+
+// validateCheckPath reports whether path is something gray check accepts:
+// a directory (project-wide check) or a .gray file.
+func validateCheckPath(path string) error {
+	info, statErr := os.Stat(path)
+	isDir := statErr == nil && info.IsDir()
+	if !isDir && !strings.HasSuffix(path, ".gray") {
+		return fmt.Errorf("error: '%s' is not a valid Grayscale source file — expected a .gray file", path)
+	}
+	return nil
+}
+
+// checkTarget is one grayc check run. A module is checked on its own
+// (grayc check --module), without requiring main().
+type checkTarget struct {
+	path   string
+	module bool
+}
+
+func (t checkTarget) args(extraArgs []string) []string {
+	if t.module {
+		return append([]string{"--module"}, extraArgs...)
+	}
+	return extraArgs
+}
+
+// checkTargets maps the paths given to gray check to what grayc checks.
+// Directories and files with a main() are checked as they are. Any other
+// file is a module: it is checked through the programs of its project
+// that import it, directly or through other modules, so changes that
+// break their callers are caught too, and on its own when nothing
+// imports it. Repeated targets are checked once.
+func checkTargets(paths []string) []checkTarget {
+	var targets []checkTarget
+	seen := map[checkTarget]bool{}
+	add := func(t checkTarget) {
+		if !seen[t] {
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+	programsOf := map[string]map[string][]string{} // project root -> programsByModule
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if info, serr := os.Stat(p); err != nil || (serr == nil && info.IsDir()) || hasMainFunction(p) {
+			add(checkTarget{path: p})
+			continue
+		}
+		root := projectRoot(filepath.Dir(abs))
+		if programsOf[root] == nil {
+			programsOf[root] = programsByModule(root)
+		}
+		programs := programsOf[root][realPath(abs)]
+		if len(programs) == 0 {
+			add(checkTarget{path: p, module: true})
+			continue
+		}
+		for _, prog := range programs {
+			add(checkTarget{path: relPath(prog)})
+		}
+	}
+	return targets
+}
+
+// programsByModule maps every file that a program (a file with a main())
+// under root imports, directly or transitively, to those programs.
+func programsByModule(root string) map[string][]string {
+	byModule := map[string][]string{}
+	for _, prog := range projectSourceFiles(root, ".gray") {
+		if !hasMainFunction(prog) {
+			continue
+		}
+		seen := map[string]bool{prog: true}
+		queue := []string{prog}
+		for len(queue) > 0 {
+			file := queue[0]
+			queue = queue[1:]
+			src, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			for _, imp := range scanDecls(string(src)).Imports {
+				if imp.Stdlib {
+					continue
+				}
+				_, files := resolveImport(imp.Path, filepath.Dir(file))
+				for _, f := range files {
+					if !seen[f] {
+						seen[f] = true
+						queue = append(queue, f)
+						byModule[realPath(f)] = append(byModule[realPath(f)], prog)
+					}
+				}
+			}
+		}
+	}
+	return byModule
+}
+
+// realPath resolves symlinks in path when it can, so the same file found
+// through git (which resolves them) and through the arguments compares
+// equal.
+func realPath(path string) string {
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		return real
+	}
+	return path
+}
+
+// runCheck type-checks every path and returns the exit code to propagate:
+// 0 when all pass, otherwise the code of the first failing check. Paths
+// are mapped to checks with checkTargets.
+func runCheck(paths []string, extraArgs []string, jobs int) (int, error) {
+	for _, p := range paths {
+		if err := validateCheckPath(p); err != nil {
+			return 1, err
+		}
+	}
+	targets := checkTargets(paths)
+	if len(targets) == 1 {
+		code, err := grayc.Check(targets[0].path, targets[0].args(extraArgs))
+		if err != nil {
+			return 1, fmt.Errorf("error: %v", err)
+		}
+		return code, nil
+	}
+
+	results := parallelMap(len(targets), jobs, func(i int) checkResult {
+		out, code, err := grayc.CheckOutput(targets[i].path, targets[i].args(extraArgs))
+		return checkResult{out, code, err}
+	})
+
+	exit := 0
+	for i, r := range results {
+		os.Stderr.Write(r.output)
+		if r.err != nil {
+			fmt.Fprintf(os.Stderr, "gray check: %s: %v\n", targets[i].path, r.err)
+			if exit == 0 {
+				exit = 1
+			}
+			continue
+		}
+		if r.code != 0 && exit == 0 {
+			exit = r.code
+		}
+	}
+	return exit, nil
+}
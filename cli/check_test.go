@@ -0,0 +1,49 @@
+// check_test.go — Tests for gray check path validation and for mapping
+// module files to the programs that check them.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateCheckPath(t *testing.T) {
+	dir := t.TempDir()
+	if err := validateCheckPath(dir); err != nil {
+		t.Errorf("directory rejected: %v", err)
+	}
+	if err := validateCheckPath(filepath.Join(dir, "main.gray")); err != nil {
+		t.Errorf(".gray file rejected: %v", err)
+	}
+	if err := validateCheckPath(filepath.Join(dir, "notes.txt")); err == nil {
+		t.Error("non-.gray file accepted")
+	}
+}
+
+func TestCheckTargets(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "gray.toml"), "")
+	writeTestFile(t, filepath.Join(dir, "main.gray"), "import \"./lib/models\"\ndo main() {}\n")
+	writeTestFile(t, filepath.Join(dir, "tools", "main.gray"), "import \"../lib/models\"\ndo main() {}\n")
+	writeTestFile(t, filepath.Join(dir, "lib", "models.gray"), "import \"./types\"\ndo f() {}\n")
+	writeTestFile(t, filepath.Join(dir, "lib", "types.gray"), "do g() {}\n")
+	writeTestFile(t, filepath.Join(dir, "orphan.gray"), "do h() {}\n")
+	runFromTempDir(t, dir, func() {
+		got := checkTargets([]string{"main.gray", filepath.Join("lib", "types.gray"), "orphan.gray", "lib"})
+		want := []checkTarget{
+			{path: "main.gray"},
+			{path: filepath.Join("tools", "main.gray")},
+			{path: "orphan.gray", module: true},
+			{path: "lib"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("checkTargets = %+v, want %+v", got, want)
+		}
+	})
+}
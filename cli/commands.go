@@ -1,5 +1,6 @@
 // commands.go — Defines all Cobra subcommands (build, check, update, doc,
-// fmt, new, watch, man, report, verify, version) and the root command wiring.
+// fmt, new, watch, man, report, symbols, verify, version) and the root
+// command wiring.
 //
 // Author:  Marshall A Burns (@SchoolyB)
 // Copyright (c) 2025-Present Marshall A Burns
@@ -27,15 +28,38 @@ type ExitError struct{ Code int }
 func (e *ExitError) Error() string { return fmt.Sprintf("exit status %d", e.Code) }
 
 var checkCmd = &cobra.Command{
-	Use:   "check [file.gray | directory]",
+	Use:   "check [file.gray | directory]...",
 	Short: "Type-check a file or project without compiling",
-	Args:  cobra.ExactArgs(1),
+	Long: `Type-check one or more .gray files or project directories without compiling.
+
+With several paths, checks run in parallel (-j sets the worker count) and
+results are printed in argument order.
+
+A file without a main() is a module: it is checked through the programs
+in its project (the nearest gray.toml directory, else the git repository)
+that import it, so callers broken by a change are reported too. A module
+that no program imports is checked on its own, which needs every name it
+uses to be imported (a file of a directory module leans on its siblings
+and cannot be).
+
+--changed[=<git-rev>] checks only the .gray files that differ from a git
+revision (HEAD by default), limited to the given paths if any.`,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Allow directories for project-wide check
-		info, statErr := os.Stat(args[0])
-		isDir := statErr == nil && info.IsDir()
-		if !isDir && !strings.HasSuffix(args[0], ".gray") {
-			return fmt.Errorf("error: '%s' is not a valid Grayscale source file — expected a .gray file", args[0])
+		if changed, _ := cmd.Flags().GetString("changed"); changed != "" {
+			files, err := changedFiles(changed, args, ".gray")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "gray check: %v\n", err)
+				return &ExitError{1}
+			}
+			if len(files) == 0 {
+				fmt.Println("gray check: no changed .gray files")
+				return nil
+			}
+			args = files
+		} else if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "gray check: requires at least one path (or --changed)")
+			return &ExitError{1}
 		}
 		var extraArgs []string
 		quiet, _ := cmd.Flags().GetString("quiet")
@@ -44,9 +68,10 @@ var checkCmd = &cobra.Command{
 		} else if quiet != "" {
 			extraArgs = append(extraArgs, "--quiet", quiet)
 		}
-		code, err := grayc.Check(args[0], extraArgs)
+		jobs, _ := cmd.Flags().GetInt("jobs")
+		code, err := runCheck(args, extraArgs, jobs)
 		if err != nil {
-			return fmt.Errorf("error: %v", err)
+			return err
 		}
 		if code != 0 {
 			return &ExitError{code}
@@ -138,6 +163,11 @@ var docCmd = &cobra.Command{
 	Use:   "doc <path>",
 	Short: "Generate documentation from #doc attributes",
 	Long: `Generate markdown documentation from #doc attributes in Grayscale source files.
+Functions, structs (fields, defaults, and struct functions), enums, and
+constants are read from the compiler's parser, so signatures are exact
+however the declaration is laid out. #doc text may be a multi-line raw
+string with @param, @return, @example, @see, and @deprecated tags; tags
+that do not match the declaration are reported as warnings.
 
 Examples:
   gray doc .              Generate docs for current directory (no recursion)
@@ -148,32 +178,166 @@ Examples:
   gray doc a.gray b.gray      Generate docs for multiple files
 
 Output is written to DOCS.md by default. Use -o/--output to write
-to a different path (parent directories are created as needed).`,
-	Args: cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+to a different path (parent directories are created as needed).
+--format json writes the entries as JSON (to stdout unless -o is given):
+name, kind, signature, description, file, line, module, private,
+attributes (#json, #flags), struct function members, and parsed tags.
+--html (or --format html) writes a static site instead (to site/ unless -o names another
+directory): one page per source file, a sidebar, type names linked to
+their definitions and to stdlib entries, and a client-side search.
+"gray doc serve" serves that site locally and rebuilds it on changes.
+--test runs the @example blocks as tests instead of writing docs: each is
+type-checked against the file it documents, and one ending in an
+"// Output:" comment is run and its output compared with the comment.
+--coverage lists the public functions, struct functions, structs, and
+enums without #doc (main is not counted) and reports coverage per file,
+per module directory, and in total. --fail-under <percent> implies
+--coverage and exits non-zero when the total is lower.
+--changed[=<git-rev>] documents only the .gray files that differ from a
+git revision (HEAD by default), limited to the given paths if any.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		output, _ := cmd.Flags().GetString("output")
-		generateDocs(args, output)
+		if changed, _ := cmd.Flags().GetString("changed"); changed != "" {
+			files, err := changedFiles(changed, args, ".gray")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "gray doc: %v\n", err)
+				return &ExitError{1}
+			}
+			if len(files) == 0 {
+				fmt.Println("gray doc: no changed .gray files")
+				return nil
+			}
+			args = files
+		} else if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "gray doc: requires at least one path (or --changed)")
+			return &ExitError{1}
+		}
+		coverage, _ := cmd.Flags().GetBool("coverage")
+		failUnder, _ := cmd.Flags().GetFloat64("fail-under")
+		if coverage || cmd.Flags().Changed("fail-under") {
+			if failUnder < 0 || failUnder > 100 {
+				fmt.Fprintln(os.Stderr, "gray doc: --fail-under must be between 0 and 100")
+				return &ExitError{1}
+			}
+			if code := runDocCoverage(args, failUnder); code != 0 {
+				return &ExitError{code}
+			}
+			return nil
+		}
+		if test, _ := cmd.Flags().GetBool("test"); test {
+			jobs, _ := cmd.Flags().GetInt("jobs")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			if code := runDocTests(args, jobs, verbose); code != 0 {
+				return &ExitError{code}
+			}
+			return nil
+		}
+		format, _ := cmd.Flags().GetString("format")
+		if html, _ := cmd.Flags().GetBool("html"); html {
+			format = "html"
+		}
+		var err error
+		switch format {
+		case "markdown", "md":
+			if split, _ := cmd.Flags().GetBool("split"); split {
+				if !cmd.Flags().Changed("output") {
+					output = defaultDocSplitDir
+				}
+				err = generateDocsSplit(args, output)
+			} else {
+				err = generateDocs(args, output)
+			}
+		case "html":
+			if !cmd.Flags().Changed("output") {
+				output = defaultDocSiteDir
+			}
+			err = generateDocSite(args, output)
+		case "json":
+			if !cmd.Flags().Changed("output") {
+				output = ""
+			}
+			err = generateDocsJSON(args, output)
+		default:
+			fmt.Fprintf(os.Stderr, "gray doc: unknown --format %q (expected markdown, html, or json)\n", format)
+			return &ExitError{1}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gray doc: %v\n", err)
+			return &ExitError{1}
+		}
+		return nil
 	},
 }
 
 var fmtCmd = &cobra.Command{
-	Use:   "fmt <path>",
+	Use:   "fmt <path> | -",
 	Short: "Format .gray source files",
 	Long: `Normalize formatting of .gray source files (indentation, trailing
-whitespace, end-of-file newline, blank-line runs).
+whitespace, end-of-file newline, blank-line runs) and organise import
+blocks: one import per line, duplicates merged, stdlib (@module) imports
+sorted before local ("./path") imports, aliases preserved.
+
+Markdown (.md) files are processed too: every ` + "```gray" + ` fenced block is
+formatted in place and parse failures are reported with Markdown line
+numbers. Annotate a block ` + "```gray,nofmt" + ` to leave it alone; blocks marked
+` + "```gray,fail=..." + ` are skipped when they do not parse.
 
 Examples:
   gray fmt .              Format .gray files in current directory (no recursion)
   gray fmt ./...          Format recursively from current directory
   gray fmt file.gray        Format a single file
   gray fmt a.gray b.gray      Format multiple files
+  gray fmt --check STANDARD.md   Check the ` + "```gray" + ` blocks of a Markdown file
   gray fmt --check ./...  Exit non-zero if any file would change (CI gate)
-
-By default files are rewritten in place. Use --check for a non-mutating CI gate.`,
-	Args: cobra.MinimumNArgs(1),
+  gray fmt --check --diff ./...  Same, and show the offending hunks
+  gray fmt --list ./...   List files that would change
+  gray fmt --json ./...   Per-file status (unchanged/changed/error) as JSON
+  gray fmt -j 4 ./...     Format with 4 parallel workers (default: one per CPU)
+  gray fmt --drop-unused-imports ./...   Also remove imports reported unused
+  gray fmt - < file.gray  Read source from stdin, write formatted source to stdout
+  gray fmt --stdin --stdin-filename app.gray
+  gray fmt --range 10:20 file.gray   Only re-indent lines 10 through 20
+  gray fmt --check --changed         Check only files changed since HEAD
+  gray fmt --changed=main ./src      Format files under src changed since main
+
+By default files are rewritten in place. --check, --diff, --list, and --json
+never modify files; they exit non-zero if a file fails to parse, and --check
+also exits non-zero if any file would change.
+With stdin input the formatted source is written to stdout and nothing on
+disk is modified.
+
+Style is configured per file from the nearest .editorconfig files
+(indent_style, indent_size, max_line_length, gray_max_blank_lines,
+gray_brace_style) and then the [fmt] table of the nearest gray.toml:
+
+  [fmt]
+  indent_width = 4          # spaces per level
+  use_tabs = false          # indent with tabs
+  max_blank_lines = 2       # longest run of blank lines kept (0 = none)
+  max_line_length = 100     # wrap long call arguments, one per line (0 = off)
+  brace_style = "same_line" # or "next_line"; "preserve" leaves braces alone
+
+Only braces add an indentation level, unless max_line_length is set: the
+arguments it wraps are indented inside their ( or [, and so are those of
+calls already split across lines.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		check, _ := cmd.Flags().GetBool("check")
-		exit := runFmt(args, check)
+		var opts fmtOptions
+		opts.Check, _ = cmd.Flags().GetBool("check")
+		opts.Diff, _ = cmd.Flags().GetBool("diff")
+		opts.List, _ = cmd.Flags().GetBool("list")
+		opts.JSON, _ = cmd.Flags().GetBool("json")
+		opts.Stdin, _ = cmd.Flags().GetBool("stdin")
+		opts.StdinFilename, _ = cmd.Flags().GetString("stdin-filename")
+		opts.Range, _ = cmd.Flags().GetString("range")
+		opts.Jobs, _ = cmd.Flags().GetInt("jobs")
+		opts.DropUnusedImports, _ = cmd.Flags().GetBool("drop-unused-imports")
+		opts.Changed, _ = cmd.Flags().GetString("changed")
+		if len(args) == 0 && !opts.Stdin && opts.Changed == "" {
+			fmt.Fprintln(os.Stderr, "gray fmt: requires a path, or - / --stdin to read from stdin")
+			return &ExitError{1}
+		}
+		exit := runFmt(args, opts)
 		if exit != 0 {
 			return &ExitError{exit}
 		}
@@ -316,8 +480,18 @@ func printManUsage() {
 	fmt.Println("  gray man <name>            docs for a specific function, type, or constant")
 	fmt.Println("                           (e.g. gray man println, gray man sqrt, gray man PI)")
 	fmt.Println("  gray man <name()>          same — trailing () is ignored")
+	fmt.Println("  gray man -k <words>        search all docs (e.g. gray man -k split string)")
+	fmt.Println("  gray man --tui [name]      browse everything in a full-screen terminal browser")
+	fmt.Println("                           (tree on the left, / to search, Tab to follow links)")
+	fmt.Println("  gray man --export roff     write man pages for gray(1) and every builtin and")
+	fmt.Println("           [-o share/man]    stdlib entry (section 3gray); or --export markdown")
 	fmt.Println("  gray man <module>.<name>   qualified lookup to avoid ambiguity")
 	fmt.Println("                           (e.g. gray man strings.contains, gray man math.PI)")
+	fmt.Println("  gray man <code>            explain an error, warning, or panic code")
+	fmt.Println("                           (e.g. gray man E2013, same as gray explain E2013)")
+	fmt.Println()
+	fmt.Println("  Names that are not builtins, stdlib, or language entries are looked up in")
+	fmt.Println("  the #doc'd declarations of the gray.toml project you are in (e.g. gray man shapes.area)")
 	fmt.Println()
 	fmt.Println("Currently Supported Stdlib Modules:")
 	mods := make([]string, 0, len(stdlibModules))
@@ -340,24 +514,26 @@ func printManUsage() {
 	fmt.Println("See the full language standard: https://github.com/grayscale-lang/grayscale/blob/main/STANDARD.md")
 }
 
+// builtinGroups is the order gray man builtins lists the builtins in.
+var builtinGroups = []struct {
+	label string
+	names []string
+}{
+	{"I/O        ", []string{"println", "print", "eprintln", "eprint", "input"}},
+	{"Control    ", []string{"exit", "panic", "assert"}},
+	{"Sleep      ", []string{"sleep_s", "sleep_ms", "sleep_ns"}},
+	{"Type casts ", []string{"int", "uint", "float", "string", "char", "byte", "bool", "cast"}},
+	{"Width casts", []string{"i128", "u128", "i256", "u256"}},
+	{"Memory     ", []string{"new", "ref", "addr", "copy"}},
+	{"Introspect ", []string{"len", "type_of", "size_of"}},
+	{"Misc       ", []string{"error", "range", "c_string", "to_char", "char_count", "here"}},
+	{"Types      ", []string{"SourceLocation", "Error"}},
+}
+
 func printBuiltinsIndex() {
 	fmt.Println("Builtins  (gray man <name> for details)")
 	fmt.Println(strings.Repeat("─", 50))
-	groups := []struct {
-		label string
-		names []string
-	}{
-		{"I/O        ", []string{"println", "print", "eprintln", "eprint", "input"}},
-		{"Control    ", []string{"exit", "panic", "assert"}},
-		{"Sleep      ", []string{"sleep_s", "sleep_ms", "sleep_ns"}},
-		{"Type casts ", []string{"int", "uint", "float", "string", "char", "byte", "bool", "cast"}},
-		{"Width casts", []string{"i128", "u128", "i256", "u256"}},
-		{"Memory     ", []string{"new", "ref", "addr", "copy"}},
-		{"Introspect ", []string{"len", "type_of", "size_of"}},
-		{"Misc       ", []string{"error", "range", "c_string", "to_char", "char_count", "here"}},
-		{"Types      ", []string{"SourceLocation", "Error"}},
-	}
-	for _, g := range groups {
+	for _, g := range builtinGroups {
 		var labels []string
 		for _, n := range g.names {
 			if e, ok := builtinManDocs[n]; ok && e.Kind == "func" {
@@ -380,6 +556,12 @@ func printManEntry(module, name, kind, sig, fields, desc, example string) {
 	fmt.Printf("Module:  %s\n", module)
 	if kind == "type" {
 		fmt.Printf("Kind:    type\n")
+		if sig != "" {
+			fmt.Println("\nDefinition:")
+			for _, line := range strings.Split(sig, "\n") {
+				fmt.Printf("  %s\n", line)
+			}
+		}
 		if fields != "" {
 			fmt.Println("\nFields:")
 			for _, f := range strings.Split(fields, "\n") {
@@ -487,92 +669,126 @@ func printLangEntry(displayName string, entry LangManEntry) {
 
 var manCmd = &cobra.Command{
 	Use:   "man [name]",
-	Short: "Show documentation for a builtin or stdlib function",
+	Short: "Show documentation for a builtin, stdlib, or project function",
 	Args:  cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) == 0 {
-			printManUsage()
-			return nil
+		if format, _ := cmd.Flags().GetString("export"); format != "" {
+			outDir, _ := cmd.Flags().GetString("output")
+			return exportManPages(cmd.Root(), format, outDir)
+		}
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		if tui, _ := cmd.Flags().GetBool("tui"); tui {
+			return runManBrowser(args, noColor)
 		}
+		return showManOutput(noColor, func() error { return runMan(cmd, args) })
+	},
+}
 
-		name := strings.TrimSuffix(args[0], "()")
+// runMan prints the page, index, or search results args ask for.
+func runMan(cmd *cobra.Command, args []string) error {
+	if search, _ := cmd.Flags().GetBool("apropos"); search {
+		return runManSearch(args)
+	}
+	if len(args) == 0 {
+		printManUsage()
+		return nil
+	}
 
-		if name == "builtins" || name == "builtin" {
-			printBuiltinsIndex()
-			return nil
-		}
+	name := strings.TrimSuffix(args[0], "()")
 
-		// Language reference index and categories
-		if name == "lang" || name == "language" {
-			printLangIndex()
-			return nil
-		}
-		if name == "keywords" || name == "types" || name == "symbols" || name == "attributes" {
-			return printLangCategoryIndex(name)
-		}
+	// Diagnostic codes (e.g. gray man E2013) show the gray explain page
+	if isErrorCode(name) {
+		return runExplain(name)
+	}
 
-		// Module-level index (e.g. gray man math)
-		if _, isMod := stdlibModules[name]; isMod {
-			return printStdlibModuleIndex(name)
-		}
+	if name == "builtins" || name == "builtin" {
+		printBuiltinsIndex()
+		return nil
+	}
 
-		// Builtin lookup
-		if entry, ok := builtinManDocs[name]; ok {
-			printBuiltinEntry(name, entry)
-			return nil
-		}
+	// Language reference index and categories
+	if name == "lang" || name == "language" {
+		printLangIndex()
+		return nil
+	}
+	if name == "keywords" || name == "types" || name == "symbols" || name == "attributes" {
+		return printLangCategoryIndex(name)
+	}
 
-		// Stdlib function/type lookup — supports "module.func" and plain "func"
-		if entry, ok := stdlibManDocs[name]; ok {
-			displayName := name
-			if idx := strings.LastIndex(name, "."); idx >= 0 {
-				displayName = name[idx+1:]
-			}
-			printStdlibEntry(displayName, entry)
-			return nil
-		}
-		// Plain name: scan all module.func keys for a match
-		var matchEntries []StdlibManEntry
-		var matchKeys []string
-		for k, e := range stdlibManDocs {
-			if strings.HasSuffix(k, "."+name) {
-				matchEntries = append(matchEntries, e)
-				matchKeys = append(matchKeys, k)
-			}
+	// Module-level index (e.g. gray man math)
+	if _, isMod := stdlibModules[name]; isMod {
+		return printStdlibModuleIndex(name)
+	}
+
+	// Builtin lookup
+	if entry, ok := builtinManDocs[name]; ok {
+		printBuiltinEntry(name, entry)
+		return nil
+	}
+
+	// Stdlib function/type lookup — supports "module.func" and plain "func"
+	if entry, ok := stdlibManDocs[name]; ok {
+		displayName := name
+		if idx := strings.LastIndex(name, "."); idx >= 0 {
+			displayName = name[idx+1:]
 		}
-		if len(matchEntries) == 1 {
-			printStdlibEntry(name, matchEntries[0])
-			return nil
+		printStdlibEntry(displayName, entry)
+		return nil
+	}
+	// Plain name: scan all module.func keys for a match
+	var matchEntries []StdlibManEntry
+	var matchKeys []string
+	for k, e := range stdlibManDocs {
+		if strings.HasSuffix(k, "."+name) {
+			matchEntries = append(matchEntries, e)
+			matchKeys = append(matchKeys, k)
 		}
-		if len(matchEntries) > 1 {
-			var sb strings.Builder
-			fmt.Fprintf(&sb, "gray: '%s' exists in multiple modules. Use a qualified name:", name)
-			for _, k := range matchKeys {
-				fmt.Fprintf(&sb, "\n    gray man %s", k)
-			}
-			return fmt.Errorf("%s", sb.String())
+	}
+	if len(matchEntries) == 1 {
+		printStdlibEntry(name, matchEntries[0])
+		return nil
+	}
+	if len(matchEntries) > 1 {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "gray: '%s' exists in multiple modules. Use a qualified name:", name)
+		for _, k := range matchKeys {
+			fmt.Fprintf(&sb, "\n    gray man %s", k)
 		}
+		return fmt.Errorf("%s", sb.String())
+	}
 
-		// Language reference lookup — direct key
-		if entry, ok := langManDocs[name]; ok {
-			printLangEntry(langDisplayName(name), entry)
-			return nil
-		}
-		// Language reference lookup — type suffix fallback (e.g. "i8" -> "i8_type")
-		if entry, ok := langManDocs[name+"_type"]; ok {
-			printLangEntry(name, entry)
+	// Language reference lookup — direct key
+	if entry, ok := langManDocs[name]; ok {
+		printLangEntry(langDisplayName(name), entry)
+		return nil
+	}
+	// Language reference lookup — type suffix fallback (e.g. "i8" -> "i8_type")
+	if entry, ok := langManDocs[name+"_type"]; ok {
+		printLangEntry(name, entry)
+		return nil
+	}
+	// Language reference lookup — symbol alias (e.g. "pointer" -> "^")
+	if target, ok := langSymbolAliases[name]; ok {
+		if entry, ok := langManDocs[target]; ok {
+			printLangEntry(target, entry)
 			return nil
 		}
-		// Language reference lookup — symbol alias (e.g. "pointer" -> "^")
-		if target, ok := langSymbolAliases[name]; ok {
-			if entry, ok := langManDocs[target]; ok {
-				printLangEntry(target, entry)
-				return nil
-			}
+	}
+
+	// The current project's own #doc'd declarations. A near miss of a
+	// name gray man knows is a typo, suggested without walking the project.
+	suggestions := suggestManNames(name, manNames())
+	if len(suggestions) == 0 || !isManTypo(name, suggestions[0]) {
+		if found, err := lookupProjectMan(name); found {
+			return err
 		}
+	}
 
-		return fmt.Errorf("gray: no documentation for '%s'\n    try: gray man builtins  or  gray man lang\n    See the full language standard: https://github.com/grayscale-lang/grayscale/blob/main/STANDARD.md", name)
-	},
+	var hint string
+	if len(suggestions) > 0 {
+		hint = "\n    did you mean: " + strings.Join(suggestions, ", ")
+	}
+	return fmt.Errorf("gray: no documentation for '%s'%s\n    try: gray man builtins  or  gray man lang\n    See the full language standard: https://github.com/grayscale-lang/grayscale/blob/main/STANDARD.md", name, hint)
 }
 
 var rootCmd = &cobra.Command{
@@ -625,7 +841,7 @@ func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.SilenceUsage = true
 	rootCmd.SilenceErrors = true
-	rootCmd.AddCommand(updateCmd, installCmd, checkCmd, buildCmd, reportCmd, versionCmd, docCmd, fmtCmd, newCmd, watchCmd, manCmd, verifyCmd)
+	rootCmd.AddCommand(updateCmd, installCmd, checkCmd, buildCmd, reportCmd, versionCmd, docCmd, fmtCmd, newCmd, watchCmd, manCmd, verifyCmd, symbolsCmd, mdtestCmd, hooksCmd, explainCmd)
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
 		CheckForUpdateAsync()
 	}
@@ -674,9 +890,47 @@ See the full language standard: https://github.com/grayscale-lang/grayscale/blob
 	checkCmd.Flags().StringP("quiet", "q", "", "Suppress warnings (use 'all' or comma-separated codes like W1001,W1002)")
 	watchCmd.Flags().StringP("quiet", "q", "", "Suppress warnings (use 'all' or comma-separated codes like W1001,W1002)")
 
-	docCmd.Flags().StringP("output", "o", defaultDocOutputPath, "Path to write generated markdown")
+	manCmd.Flags().BoolP("apropos", "k", false, "Search names, signatures, descriptions and examples for the given words")
+	manCmd.Flags().String("export", "", "Write every page as roff (man1/, man3/) or markdown instead of showing one")
+	manCmd.Flags().StringP("output", "o", defaultManExportDir, "Directory for --export")
+	manCmd.Flags().Bool("no-color", false, "Disable colored output")
+	manCmd.Flags().Bool("tui", false, "Browse the builtins, language reference, and stdlib in a full-screen terminal browser")
+	explainCmd.Flags().Bool("no-color", false, "Disable colored output")
+	docCmd.Flags().StringP("output", "o", defaultDocOutputPath, "Where to write the docs (markdown: DOCS.md, --split: docs/, html: site/, json: stdout)")
+	docCmd.Flags().Bool("split", false, "Write one Markdown file per module plus index.md to the --output directory (default docs/)")
+	docCmd.Flags().String("format", "markdown", "Output format: markdown, html, or json")
+	docCmd.Flags().Bool("html", false, "Generate a static HTML site in the --output directory (default site/)")
+	docCmd.Flags().Bool("coverage", false, "Report public declarations missing #doc instead of generating docs")
+	docCmd.Flags().Float64("fail-under", 0, "With --coverage, exit non-zero if total coverage is below this percentage")
+	docCmd.Flags().Bool("test", false, "Run the @example blocks in #doc as tests instead of generating docs")
+	docCmd.Flags().IntP("jobs", "j", defaultJobs(), "Number of examples to test in parallel (with --test)")
+	docCmd.Flags().BoolP("verbose", "v", false, "Also list passing examples (with --test)")
 
 	fmtCmd.Flags().Bool("check", false, "Exit non-zero if any file would change; don't modify files")
+	fmtCmd.Flags().Bool("diff", false, "Print a unified diff of what would change; don't modify files")
+	fmtCmd.Flags().BoolP("list", "l", false, "List files that would change; don't modify files")
+	fmtCmd.Flags().Bool("json", false, "Report per-file status (unchanged/changed/error) as JSON; don't modify files")
+	fmtCmd.Flags().Bool("stdin", false, "Read source from stdin and write formatted source to stdout (same as '-')")
+	fmtCmd.Flags().String("stdin-filename", "", "Name to report for stdin input in messages")
+	fmtCmd.Flags().String("range", "", "Only re-indent lines start:end (1-based, inclusive)")
+	fmtCmd.Flags().IntP("jobs", "j", defaultJobs(), "Number of files to format in parallel")
+	fmtCmd.Flags().Bool("drop-unused-imports", false, "Remove imports that gray check reports as unused (W1002)")
+	checkCmd.Flags().IntP("jobs", "j", defaultJobs(), "Number of paths to check in parallel")
+	addChangedFlag(fmtCmd)
+	addChangedFlag(checkCmd)
+	addChangedFlag(docCmd)
+	docCmd.AddCommand(docServeCmd)
+	docServeCmd.Flags().String("addr", defaultDocServeAddr, "Address to listen on (host:port)")
+
+	hooksCmd.AddCommand(hooksInstallCmd, hooksUninstallCmd)
+	hooksInstallCmd.Flags().BoolP("force", "f", false, "Replace an existing pre-commit hook not written by gray")
+
+	mdtestCmd.Flags().IntP("jobs", "j", defaultJobs(), "Number of code blocks to check in parallel")
+	mdtestCmd.Flags().BoolP("verbose", "v", false, "Also list passing and skipped code blocks")
+
+	symbolsCmd.Flags().Bool("json", false, "Emit the index as JSON")
+	symbolsCmd.Flags().String("definition", "", "Print the declaration of the symbol at file:line:column")
+	symbolsCmd.Flags().String("references", "", "Print every reference to the symbol at file:line:column")
 
 	newCmd.Flags().StringP("template", "t", "basic", "Template: basic, cli, lib, multi, server, client")
 	newCmd.Flags().BoolP("comments", "c", false, "Include helpful syntax comments")
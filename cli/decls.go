@@ -0,0 +1,336 @@
+// decls.go — Top-level declaration scanner for .gray sources. Walks the
+// token stream from lexer.go and records imports, using declarations,
+// functions, structs (with their namespaced functions), enums, and
+// constants along with their source positions.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// grayDecl is a single declaration found in a source file.
+type grayDecl struct {
+	Name    string
+	Kind    string // "function", "struct", "enum", "const", "struct_function"
+	Parent  string // owning struct for struct_function, else ""
+	Private bool
+	Line    int
+	Col     int
+	// NameOffset is the byte offset of the name token, used to tell a
+	// declaration site apart from a reference.
+	NameOffset int
+}
+
+// grayImport is one item of an import statement. The JSON names match
+// the imports of grayc --dump-decls.
+type grayImport struct {
+	Alias   string `json:"alias"` // explicit alias or the derived module name
+	Path    string `json:"path"`  // "./utils" for local imports, module name for stdlib
+	Stdlib  bool   `json:"stdlib"`
+	AutoUse bool   `json:"auto_use"` // import and use
+	Line    int    `json:"line"`
+}
+
+// grayFileDecls is everything scanDecls extracts from one file.
+type grayFileDecls struct {
+	Tokens  []grayToken
+	Decls   []grayDecl
+	Imports []grayImport
+	Usings  []string
+}
+
+// scanDecls lexes src and collects its top-level declarations.
+func scanDecls(src string) *grayFileDecls {
+	fd := &grayFileDecls{Tokens: lexGraySource(src)}
+	p := &declParser{toks: fd.Tokens, out: fd}
+	p.parseTopLevel()
+	return fd
+}
+
+// declParser walks a token slice. It only understands enough of the
+// grammar to find declaration boundaries; statement bodies are skipped
+// as balanced brace groups.
+type declParser struct {
+	toks []grayToken
+	pos  int
+	out  *grayFileDecls
+}
+
+func (p *declParser) at(i int) grayToken {
+	if p.pos+i < len(p.toks) {
+		return p.toks[p.pos+i]
+	}
+	return grayToken{Kind: tokEOF}
+}
+
+func (p *declParser) done() bool { return p.pos >= len(p.toks) }
+
+// skipLine advances past the current logical line, treating bracketed
+// groups as a single unit so multi-line literals are skipped whole.
+func (p *declParser) skipLine() {
+	depth := 0
+	for !p.done() {
+		t := p.at(0)
+		switch {
+		case t.Kind == tokNewline && depth == 0:
+			p.pos++
+			return
+		case t.isPunct("(") || t.isPunct("[") || t.isPunct("{"):
+			depth++
+		case t.isPunct(")") || t.isPunct("]") || t.isPunct("}"):
+			if depth > 0 {
+				depth--
+			}
+		}
+		p.pos++
+	}
+}
+
+// skipBlock expects the current token to be '{' and advances past its
+// matching '}'.
+func (p *declParser) skipBlock() {
+	depth := 0
+	for !p.done() {
+		t := p.at(0)
+		p.pos++
+		if t.isPunct("{") {
+			depth++
+		} else if t.isPunct("}") {
+			depth--
+			if depth <= 0 {
+				return
+			}
+		}
+	}
+}
+
+// skipAttribute advances past an attribute token and its optional
+// parenthesised arguments.
+func (p *declParser) skipAttribute() {
+	p.pos++
+	if p.at(0).isPunct("(") {
+		depth := 0
+		for !p.done() {
+			t := p.at(0)
+			p.pos++
+			if t.isPunct("(") {
+				depth++
+			} else if t.isPunct(")") {
+				depth--
+				if depth == 0 {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *declParser) parseTopLevel() {
+	private := false
+	for !p.done() {
+		t := p.at(0)
+		switch {
+		case t.Kind == tokNewline:
+			p.pos++
+		case t.Kind == tokAttr:
+			p.skipAttribute()
+		case t.isKeyword("private"):
+			private = true
+			p.pos++
+			continue
+		case t.isKeyword("import"):
+			p.parseImport()
+		case t.isKeyword("using"):
+			p.parseUsing()
+		case t.isKeyword("do"):
+			p.parseFunc("", private)
+		case t.isKeyword("const"):
+			p.parseConst(private)
+		default:
+			p.skipLine()
+		}
+		private = false
+	}
+}
+
+// parseImport handles `import [and use] [alias] @mod | "path", ...`.
+func (p *declParser) parseImport() {
+	line := p.at(0).Line
+	p.pos++
+	autoUse := false
+	if p.at(0).Kind == tokIdent && p.at(0).Text == "and" && p.at(1).isKeyword("use") {
+		autoUse = true
+		p.pos += 2
+	}
+	for !p.done() && p.at(0).Kind != tokNewline {
+		t := p.at(0)
+		switch {
+		case t.isPunct(","):
+			p.pos++
+		case t.Kind == tokIdent && t.Text == "c" && p.at(1).Kind == tokString:
+			// C header import; not a Grayscale module.
+			p.pos += 2
+		case t.Kind == tokIdent && p.at(1).isPunct("@") && p.at(2).Kind == tokIdent:
+			p.out.Imports = append(p.out.Imports, grayImport{Alias: t.Text, Path: p.at(2).Text, Stdlib: true, AutoUse: autoUse, Line: line})
+			p.pos += 3
+		case t.isPunct("@") && p.at(1).Kind == tokIdent:
+			mod := p.at(1).Text
+			p.out.Imports = append(p.out.Imports, grayImport{Alias: mod, Path: mod, Stdlib: true, AutoUse: autoUse, Line: line})
+			p.pos += 2
+		case t.Kind == tokIdent && p.at(1).Kind == tokString:
+			p.out.Imports = append(p.out.Imports, grayImport{Alias: t.Text, Path: p.at(1).stringValue(), AutoUse: autoUse, Line: line})
+			p.pos += 2
+		case t.Kind == tokString:
+			path := t.stringValue()
+			p.out.Imports = append(p.out.Imports, grayImport{Alias: importModuleName(path), Path: path, AutoUse: autoUse, Line: line})
+			p.pos++
+		default:
+			p.pos++
+		}
+	}
+}
+
+// importModuleName derives the default module name for a local import
+// path the same way the grayc parser does: the last path component with
+// any .gray extension removed.
+func importModuleName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), ".gray")
+}
+
+func (p *declParser) parseUsing() {
+	p.pos++
+	for !p.done() && p.at(0).Kind != tokNewline {
+		if p.at(0).Kind == tokIdent {
+			p.out.Usings = append(p.out.Usings, p.at(0).Text)
+		}
+		p.pos++
+	}
+}
+
+// parseFunc handles `do name(params) -> ret { body }`. parent is the
+// enclosing struct name for struct-namespaced functions.
+func (p *declParser) parseFunc(parent string, private bool) {
+	p.pos++ // do
+	name := p.at(0)
+	if name.Kind != tokIdent {
+		p.skipLine()
+		return
+	}
+	kind := "function"
+	if parent != "" {
+		kind = "struct_function"
+	}
+	p.out.Decls = append(p.out.Decls, grayDecl{
+		Name: name.Text, Kind: kind, Parent: parent, Private: private,
+		Line: name.Line, Col: name.Col, NameOffset: name.Offset,
+	})
+	p.pos++
+
+	// The body is the first '{' outside the parameter and return-type
+	// parentheses, so defaults like `p Point = Point{}` are not mistaken
+	// for the body.
+	depth := 0
+	for !p.done() {
+		t := p.at(0)
+		switch {
+		case t.isPunct("(") || t.isPunct("["):
+			depth++
+		case t.isPunct(")") || t.isPunct("]"):
+			depth--
+		case t.isPunct("{") && depth == 0:
+			p.skipBlock()
+			return
+		}
+		p.pos++
+	}
+}
+
+// parseConst handles `const Name struct {...}`, `const Name enum {...}`,
+// and `const NAME [type] = value`.
+func (p *declParser) parseConst(private bool) {
+	p.pos++ // const
+	name := p.at(0)
+	if name.Kind != tokIdent {
+		p.skipLine()
+		return
+	}
+	decl := grayDecl{
+		Name: name.Text, Private: private,
+		Line: name.Line, Col: name.Col, NameOffset: name.Offset,
+	}
+	switch {
+	case p.at(1).isKeyword("struct"):
+		decl.Kind = "struct"
+		p.out.Decls = append(p.out.Decls, decl)
+		p.pos += 2
+		p.parseStructBody(name.Text)
+	case p.at(1).isKeyword("enum"):
+		decl.Kind = "enum"
+		p.out.Decls = append(p.out.Decls, decl)
+		p.pos += 2
+		if p.at(0).isPunct("{") {
+			p.skipBlock()
+		}
+	default:
+		decl.Kind = "const"
+		p.out.Decls = append(p.out.Decls, decl)
+		p.skipLine()
+	}
+}
+
+// parseStructBody walks a struct body collecting namespaced functions.
+func (p *declParser) parseStructBody(structName string) {
+	if !p.at(0).isPunct("{") {
+		return
+	}
+	p.pos++
+	private := false
+	for !p.done() {
+		t := p.at(0)
+		switch {
+		case t.isPunct("}"):
+			p.pos++
+			return
+		case t.Kind == tokNewline:
+			p.pos++
+		case t.Kind == tokAttr:
+			p.skipAttribute()
+		case t.isKeyword("private"):
+			private = true
+			p.pos++
+			continue
+		case t.isKeyword("do"):
+			p.parseFunc(structName, private)
+		default:
+			p.skipFieldLine()
+		}
+		private = false
+	}
+}
+
+// skipFieldLine skips one struct field line, stopping before a '}' that
+// closes the struct when the field shares its line.
+func (p *declParser) skipFieldLine() {
+	depth := 0
+	for !p.done() {
+		t := p.at(0)
+		switch {
+		case t.Kind == tokNewline && depth == 0:
+			return
+		case t.isPunct("(") || t.isPunct("[") || t.isPunct("{"):
+			depth++
+		case t.isPunct(")") || t.isPunct("]") || t.isPunct("}"):
+			if depth == 0 {
+				return
+			}
+			depth--
+		}
+		p.pos++
+	}
+}
@@ -0,0 +1,146 @@
+// diff_test.go — Tests for the unified diff renderer used by gray fmt
+// --diff, covering identical inputs, hunk headers, context trimming,
+// hunk splitting, missing trailing newlines, and shortest edit scripts
+// for random and very large inputs.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiffIdentical(t *testing.T) {
+	if got := unifiedDiff("a", "b", "x\ny\n", "x\ny\n"); got != "" {
+		t.Errorf("identical inputs produced a diff:\n%s", got)
+	}
+}
+
+func TestUnifiedDiffSingleHunk(t *testing.T) {
+	before := "do main() {\nprintln(\"a\")\n}\n"
+	after := "do main() {\n    println(\"a\")\n}\n"
+	want := `--- f.gray.orig
++++ f.gray
+@@ -1,3 +1,3 @@
+ do main() {
+-println("a")
++    println("a")
+ }
+`
+	if got := unifiedDiff("f.gray.orig", "f.gray", before, after); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnifiedDiffSplitsDistantHunks(t *testing.T) {
+	var a, b []string
+	for i := 1; i <= 20; i++ {
+		line := strings.Repeat("x", i)
+		a = append(a, line)
+		if i == 2 || i == 18 {
+			line = "changed"
+		}
+		b = append(b, line)
+	}
+	got := unifiedDiff("a", "b", strings.Join(a, "\n")+"\n", strings.Join(b, "\n")+"\n")
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Fatalf("got %d hunks, want 2:\n%s", n, got)
+	}
+	if !strings.Contains(got, "@@ -1,5 +1,5 @@") || !strings.Contains(got, "@@ -15,6 +15,6 @@") {
+		t.Errorf("unexpected hunk headers:\n%s", got)
+	}
+}
+
+func TestUnifiedDiffMissingNewline(t *testing.T) {
+	got := unifiedDiff("a", "b", "x\ny", "x\ny\n")
+	if !strings.Contains(got, "-y\n\\ No newline at end of file\n+y\n") {
+		t.Errorf("missing-newline marker not rendered:\n%s", got)
+	}
+}
+
+func TestDiffLinesAddsAndRemoves(t *testing.T) {
+	ops := diffLines([]string{"a", "b", "c"}, []string{"a", "c", "d"})
+	var kinds strings.Builder
+	for _, op := range ops {
+		kinds.WriteByte(op.Kind)
+	}
+	if got := kinds.String(); got != " - +" {
+		t.Errorf("edit script = %q, want %q", got, " - +")
+	}
+}
+
+// applyDiff rebuilds both sides from an edit script and counts its edits.
+func applyDiff(ops []diffOp) (a, b []string, edits int) {
+	for _, op := range ops {
+		if op.Kind != '+' {
+			a = append(a, op.Text)
+		}
+		if op.Kind != '-' {
+			b = append(b, op.Text)
+		}
+		if op.Kind != ' ' {
+			edits++
+		}
+	}
+	return a, b, edits
+}
+
+func TestDiffLinesShortestScript(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randLines := func() []string {
+		lines := make([]string, r.Intn(12))
+		for i := range lines {
+			lines[i] = string(rune('a' + r.Intn(3)))
+		}
+		return lines
+	}
+	for i := 0; i < 500; i++ {
+		a, b := randLines(), randLines()
+		gotA, gotB, edits := applyDiff(diffLines(a, b))
+		if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+			t.Fatalf("diffLines(%q, %q) does not rebuild its inputs", a, b)
+		}
+		// The shortest script keeps a longest common subsequence.
+		lcs := make([][]int, len(a)+1)
+		for x := range lcs {
+			lcs[x] = make([]int, len(b)+1)
+		}
+		for x := len(a) - 1; x >= 0; x-- {
+			for y := len(b) - 1; y >= 0; y-- {
+				if a[x] == b[y] {
+					lcs[x][y] = lcs[x+1][y+1] + 1
+				} else {
+					lcs[x][y] = max(lcs[x+1][y], lcs[x][y+1])
+				}
+			}
+		}
+		if want := len(a) + len(b) - 2*lcs[0][0]; edits != want {
+			t.Fatalf("diffLines(%q, %q) made %d edits, want %d", a, b, edits, want)
+		}
+	}
+}
+
+func TestDiffLinesLargeReindent(t *testing.T) {
+	var before, after strings.Builder
+	for i := 0; i < 8000; i++ {
+		fmt.Fprintf(&before, "x := %d\n", i)
+		fmt.Fprintf(&after, "    x := %d\n", i)
+	}
+	var start, end runtime.MemStats
+	runtime.ReadMemStats(&start)
+	got := unifiedDiff("a", "b", before.String(), after.String())
+	runtime.ReadMemStats(&end)
+	if n := strings.Count(got, "\n-x := "); n != 8000 {
+		t.Errorf("%d lines removed, want 8000", n)
+	}
+	if alloc := end.TotalAlloc - start.TotalAlloc; alloc > 64<<20 {
+		t.Errorf("diffing 8000 changed lines allocated %d MB", alloc>>20)
+	}
+}
@@ -1,6 +1,8 @@
 // doc.go — Documentation generator for Grayscale source files ("gray doc").
-// Extracts #doc attributes from functions, structs, and enums, then emits
-// a structured Markdown reference file.
+// Reads each file's declarations from the compiler (grayc --dump-decls),
+// keeps the ones carrying #doc attributes — functions, structs and their
+// functions, enums, and constants — and emits a Markdown reference (see
+// docmarkdown.go).
 //
 // Author:  Marshall A Burns (@SchoolyB)
 // Copyright (c) 2025-Present Marshall A Burns
@@ -9,59 +11,324 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
 )
 
-// DocEntry represents a documented item
+// DocEntry represents a documented item. Description is the #doc text
+// less its tags, which are parsed into the fields below it (see
+// doctags.go). The JSON form is what gray doc --format json writes.
 type DocEntry struct {
-	Name        string
-	Kind        string // "function", "struct", "enum"
-	Signature   string
-	Description string
-	File        string
+	Name        string     `json:"name"`
+	Kind        string     `json:"kind"` // "function", "struct", "enum", "const", "struct_function"
+	Signature   string     `json:"signature"`
+	Description string     `json:"description"`
+	File        string     `json:"file"`
+	Line        int        `json:"line"`
+	Module      string     `json:"module"`      // the name the module is imported under
+	ModulePath  string     `json:"module_path"` // import path of the module: a file without .gray, or a directory
+	Private     bool       `json:"private"`
+	Attributes  []string   `json:"attributes,omitempty"` // "json" for #json structs, "flags" for #flags enums
+	Members     []DocEntry `json:"members,omitempty"`    // documented struct functions
+
+	Params         []DocParam `json:"params,omitempty"`   // @param, in the order written
+	Returns        []string   `json:"returns,omitempty"`  // @return
+	Examples       []string   `json:"examples,omitempty"` // @example code blocks
+	See            []string   `json:"see,omitempty"`      // @see targets: item names, stdlib entries, or URLs
+	Deprecated     bool       `json:"deprecated,omitempty"`
+	DeprecatedNote string     `json:"deprecated_note,omitempty"` // text after @deprecated, if any
 }
 
 // defaultDocOutputPath is used when the caller does not pass --output.
 const defaultDocOutputPath = "DOCS.md"
 
+// dumpDecls runs grayc --dump-decls; tests swap it for a canned dump.
+var dumpDecls = grayc.DumpDecls
+
+// declDump mirrors the JSON written by grayc --dump-decls.
+type declDump struct {
+	File    string       `json:"file"`
+	Imports []grayImport `json:"imports"` // C header imports are left out
+	Decls   []dumpedDecl `json:"decls"`
+}
+
+// srcSpan locates an expression by the positions of its first and last
+// tokens (1-based line and byte column, as grayc reports them) and the
+// byte offsets of its text, or -1 when grayc could not find them.
+type srcSpan struct {
+	Line      int `json:"line"`
+	Column    int `json:"column"`
+	EndLine   int `json:"end_line"`
+	EndColumn int `json:"end_column"`
+	Start     int `json:"start"`
+	End       int `json:"end"`
+}
+
+type dumpedDecl struct {
+	Kind    string `json:"kind"` // "function", "struct", "enum", "const", "var"
+	Name    string `json:"name"`
+	Line    int    `json:"line"`
+	Private bool   `json:"private"`
+	Doc     string `json:"doc"`
+
+	// Functions
+	Params  []dumpedParam  `json:"params"`
+	Returns []dumpedReturn `json:"returns"`
+
+	// Structs
+	JSON      bool          `json:"json"`
+	Fields    []dumpedField `json:"fields"`
+	Functions []dumpedDecl  `json:"functions"`
+
+	// Enums
+	Flags    bool            `json:"flags"`
+	Variants []dumpedVariant `json:"variants"`
+
+	// Constants
+	Type     string   `json:"type"`
+	TypeSpan *srcSpan `json:"type_span"`
+	Value    *srcSpan `json:"value"`
+}
+
+type dumpedParam struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	TypeSpan  *srcSpan `json:"type_span"`
+	Mutable   bool     `json:"mutable"`
+	TypeParam bool     `json:"type_param"`
+	Default   *srcSpan `json:"default"`
+}
+
+type dumpedReturn struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	TypeSpan *srcSpan `json:"type_span"`
+}
+
+type dumpedField struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	TypeSpan *srcSpan `json:"type_span"`
+	Default  *srcSpan `json:"default"`
+}
+
+type dumpedVariant struct {
+	Name    string   `json:"name"`
+	Value   *srcSpan `json:"value"`
+	Payload []string `json:"payload"`
+}
+
 // generateDocs is the entry point for the gray doc command. outputPath is
 // the destination markdown file; an empty string falls back to
 // defaultDocOutputPath so the `DOCS.md`-in-cwd behavior is
 // unchanged for callers that do not pass --output.
-func generateDocs(args []string, outputPath string) {
+func generateDocs(args []string, outputPath string) error {
 	if outputPath == "" {
 		outputPath = defaultDocOutputPath
 	}
 
+	entries, _, err := collectDocs(args)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No documented items found.")
+		return nil
+	}
+
+	output := generateMarkdown(entries, filepath.Dir(outputPath))
+	if err := writeDocOutput(outputPath, []byte(output)); err != nil {
+		return err
+	}
+	fmt.Printf("Generated %s with %d documented item(s)\n", outputPath, len(entries))
+	return nil
+}
+
+// generateDocsJSON is the entry point for gray doc --format json. It
+// writes {"entries": [...]} to outputPath, or to stdout when outputPath is
+// empty. Paths in the output are relative to the working directory when
+// possible.
+func generateDocsJSON(args []string, outputPath string) error {
+	entries, _, err := collectDocs(args)
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []DocEntry{}
+	}
+	for i := range entries {
+		entries[i].File = relPath(entries[i].File)
+		for j := range entries[i].Members {
+			entries[i].Members[j].File = entries[i].File
+		}
+	}
+	// Signatures are full of "->" and "&"; keep them readable.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(struct {
+		Entries []DocEntry `json:"entries"`
+	}{entries}); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+
+	if outputPath == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := writeDocOutput(outputPath, data); err != nil {
+		return err
+	}
+	fmt.Printf("Generated %s with %d documented item(s)\n", outputPath, len(entries))
+	return nil
+}
+
+// writeDocOutput writes generated documentation to path, creating its
+// parent directories when --output points at a nested path like
+// docs/API.md.
+func writeDocOutput(path string, data []byte) error {
+	warnOutsideCwd(path)
+	if dir := filepath.Dir(path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("creating output directory %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing %s: %v", path, err)
+	}
+	return nil
+}
+
+// docImports maps an absolute source path to its imports, as grayc
+// --dump-decls reports them.
+type docImports map[string][]grayImport
+
+// collectDocs gathers the documented items for gray doc's path arguments
+// (files, directories, and "dir/..." for recursion) and the imports of
+// the files they come from.
+func collectDocs(args []string) ([]DocEntry, docImports, error) {
+	files, err := docSourceFiles(args)
+	if err != nil {
+		return nil, nil, err
+	}
 	var entries []DocEntry
+	imports := docImports{}
+	for _, file := range files {
+		found, imps, err := collectDocsFromFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, found...)
+		if abs, err := filepath.Abs(file); err == nil {
+			imports[abs] = imps
+		}
+	}
+	assignDirModules(imports, entries)
+	return entries, imports, nil
+}
+
+// assignDirModules moves entries from files that one of the importing
+// files imports as a directory into that directory's module. A file is
+// its own module otherwise, as docEntriesFromDump assumes. Imports
+// resolve with resolveImport, as in gray symbols and the compiler.
+func assignDirModules(imports docImports, entries []DocEntry) {
+	dirOf := map[string]string{} // absolute file -> absolute directory module
+	for file, imps := range imports {
+		for _, imp := range imps {
+			if imp.Stdlib {
+				continue
+			}
+			if key, members := resolveImport(imp.Path, filepath.Dir(file)); key != "" && key != members[0] {
+				for _, m := range members {
+					dirOf[m] = key
+				}
+			}
+		}
+	}
+	for i := range entries {
+		abs, err := filepath.Abs(entries[i].File)
+		if err != nil {
+			continue
+		}
+		if dir, ok := dirOf[abs]; ok {
+			setModule(&entries[i], moduleDisplayName(dir), filepath.ToSlash(relPath(dir)))
+		}
+	}
+}
+
+// fileModulePath is the import path of the module a file is on its own:
+// the file without .gray, relative to the working directory.
+func fileModulePath(filename string) string {
+	return filepath.ToSlash(strings.TrimSuffix(relPath(filename), ".gray"))
+}
+
+func setModule(e *DocEntry, name, path string) {
+	e.Module, e.ModulePath = name, path
+	for j := range e.Members {
+		setModule(&e.Members[j], name, path)
+	}
+}
 
+// docSourceFiles lists the .gray files named by gray doc's path
+// arguments. Files found by scanning a directory are absolute.
+func docSourceFiles(args []string) ([]string, error) {
+	var files []string
 	for _, arg := range args {
 		if strings.HasSuffix(arg, "/...") {
 			baseDir := strings.TrimSuffix(arg, "/...")
-			if baseDir == "." || baseDir == "" {
+			if baseDir == "" {
 				baseDir = "."
 			}
-			entries = append(entries, collectDocsRecursive(baseDir)...)
+			absDir, err := filepath.Abs(baseDir)
+			if err != nil {
+				return nil, err
+			}
+			err = filepath.Walk(absDir, func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return nil
+				}
+				if !info.IsDir() && strings.HasSuffix(path, ".gray") {
+					files = append(files, path)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
 		} else if strings.HasSuffix(arg, ".gray") {
-			entries = append(entries, collectDocsFromFile(arg)...)
+			files = append(files, arg)
 		} else {
-			entries = append(entries, collectDocsFromDir(arg)...)
+			absDir, err := filepath.Abs(arg)
+			if err != nil {
+				return nil, err
+			}
+			dirEntries, err := os.ReadDir(absDir)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range dirEntries {
+				if !file.IsDir() && strings.HasSuffix(file.Name(), ".gray") {
+					files = append(files, filepath.Join(absDir, file.Name()))
+				}
+			}
 		}
 	}
+	return files, nil
+}
 
-	if len(entries) == 0 {
-		fmt.Println("No documented items found.")
-		return
-	}
-
-	output := generateMarkdown(entries)
-
-	// Warn when --output resolves to a path outside the working directory.
+// warnOutsideCwd warns when an output path resolves outside the working
+// directory.
+func warnOutsideCwd(outputPath string) {
 	if cwd, err := os.Getwd(); err == nil {
 		if abs, err := filepath.Abs(outputPath); err == nil {
 			rel, err := filepath.Rel(cwd, abs)
@@ -70,275 +337,274 @@ func generateDocs(args []string, outputPath string) {
 			}
 		}
 	}
+}
 
-	// Make sure the parent directory exists when --output points at a
-	// nested path like docs/API.md.
-	if dir := filepath.Dir(outputPath); dir != "" && dir != "." {
-		if err := os.MkdirAll(dir, 0o755); err != nil {
-			fmt.Printf("Error creating output directory %s: %v\n", dir, err)
-			return
-		}
+// collectDocsFromFile extracts documented items and imports from a single
+// .gray file. The declarations come from the compiler's parser (grayc
+// --dump-decls), so signatures, defaults, and bodies are exact whatever
+// the layout; default values are quoted from the source as written.
+func collectDocsFromFile(filename string) ([]DocEntry, []grayImport, error) {
+	dump, src, err := readDeclDump(filename)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
-		fmt.Printf("Error writing %s: %v\n", outputPath, err)
-		return
+	entries, warnings := docEntriesFromDump(dump, filename, src)
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
 	}
-
-	fmt.Printf("Generated %s with %d documented item(s)\n", outputPath, len(entries))
+	return entries, dump.Imports, nil
 }
 
-// collectDocsFromFile extracts documented items from a single .gray file using text scanning.
-// Looks for #doc("description") followed by do/const struct/const enum declarations.
-func collectDocsFromFile(filename string) []DocEntry {
-	var entries []DocEntry
-
-	absPath, err := filepath.Abs(filename)
+// readDeclDump returns filename's declarations and source.
+func readDeclDump(filename string) (declDump, string, error) {
+	var dump declDump
+	src, err := os.ReadFile(filename)
 	if err != nil {
-		fmt.Printf("Error resolving path %s: %v\n", filename, err)
-		return entries
+		return dump, "", err
 	}
-
-	f, err := os.Open(absPath)
+	out, err := dumpDecls(filename)
 	if err != nil {
-		fmt.Printf("Error reading file %s: %v\n", filename, err)
-		return entries
-	}
-	defer f.Close()
-
-	scanner := bufio.NewScanner(f)
-	var pendingDoc string
-	var inBlock bool
-	var blockLines []string
-	var blockKind string
-	var blockName string
-	braceDepth := 0
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		trimmed := strings.TrimSpace(line)
-
-		// Collecting a block (struct/enum body)
-		if inBlock {
-			blockLines = append(blockLines, line)
-			braceDepth += strings.Count(line, "{") - strings.Count(line, "}")
-			if braceDepth <= 0 {
-				inBlock = false
-				entries = append(entries, DocEntry{
-					Name:        blockName,
-					Kind:        blockKind,
-					Signature:   strings.Join(blockLines, "\n"),
-					Description: pendingDoc,
-					File:        filename,
-				})
-				pendingDoc = ""
-				blockLines = nil
-			}
-			continue
-		}
+		return dump, "", err
+	}
+	if err := json.Unmarshal(out, &dump); err != nil {
+		return dump, "", fmt.Errorf("%s: reading declarations: %v", filename, err)
+	}
+	return dump, string(src), nil
+}
 
-		// Check for #doc attribute
-		if strings.HasPrefix(trimmed, "#doc(") {
-			// Extract description from #doc("...")
-			desc := extractDocString(trimmed)
-			pendingDoc = desc
-			continue
+// docEntriesFromDump turns a declaration dump into DocEntries for the
+// documented declarations. A struct is included when it or one of its
+// functions has a #doc. The returned warnings ("file:line: name: problem")
+// report doc tags that do not match their declarations.
+func docEntriesFromDump(dump declDump, filename, src string) ([]DocEntry, []string) {
+	spans := sourceSpans(src)
+	var entries []DocEntry
+	var warnings []string
+	tags := func(e *DocEntry, d dumpedDecl) {
+		for _, w := range applyDocComment(e, d) {
+			warnings = append(warnings, fmt.Sprintf("%s:%d: %s: %s", filename, e.Line, e.Name, w))
 		}
-
-		// If we have a pending doc, the next non-empty line should be a declaration
-		if pendingDoc != "" && trimmed != "" {
-			if strings.HasPrefix(trimmed, "do ") || strings.HasPrefix(trimmed, "private do ") {
-				// Function declaration — capture the signature line
-				sig := trimmed
-				// Strip the body (everything after {)
-				if idx := strings.Index(sig, "{"); idx >= 0 {
-					sig = strings.TrimSpace(sig[:idx])
+	}
+	module := moduleDisplayName(filename)
+	modulePath := fileModulePath(filename)
+	for _, d := range dump.Decls {
+		entry := DocEntry{
+			Name:        d.Name,
+			Kind:        d.Kind,
+			Description: d.Doc,
+			File:        filename,
+			Line:        d.Line,
+			Module:      module,
+			ModulePath:  modulePath,
+			Private:     d.Private,
+		}
+		if d.JSON {
+			entry.Attributes = append(entry.Attributes, "json")
+		}
+		if d.Flags {
+			entry.Attributes = append(entry.Attributes, "flags")
+		}
+		switch d.Kind {
+		case "function":
+			entry.Signature = funcSignature(d, spans)
+		case "struct":
+			entry.Signature = structSignature(d, spans)
+			for _, fn := range d.Functions {
+				if fn.Doc == "" {
+					continue
 				}
-				name := extractFuncName(trimmed)
-				entries = append(entries, DocEntry{
-					Name:        name,
-					Kind:        "function",
-					Signature:   sig,
-					Description: pendingDoc,
+				member := DocEntry{
+					Name:        d.Name + "." + fn.Name,
+					Kind:        "struct_function",
+					Signature:   funcSignature(fn, spans),
+					Description: fn.Doc,
 					File:        filename,
-				})
-				pendingDoc = ""
-			} else if strings.Contains(trimmed, " struct ") && strings.HasPrefix(trimmed, "const ") {
-				// Struct declaration — collect until closing brace
-				blockName = extractStructEnumName(trimmed)
-				blockKind = "struct"
-				blockLines = []string{line}
-				braceDepth = strings.Count(line, "{") - strings.Count(line, "}")
-				if braceDepth > 0 {
-					inBlock = true
-				} else {
-					entries = append(entries, DocEntry{
-						Name:        blockName,
-						Kind:        "struct",
-						Signature:   trimmed,
-						Description: pendingDoc,
-						File:        filename,
-					})
-					pendingDoc = ""
-				}
-			} else if strings.Contains(trimmed, " enum ") && strings.HasPrefix(trimmed, "const ") {
-				blockName = extractStructEnumName(trimmed)
-				blockKind = "enum"
-				blockLines = []string{line}
-				braceDepth = strings.Count(line, "{") - strings.Count(line, "}")
-				if braceDepth > 0 {
-					inBlock = true
-				} else {
-					entries = append(entries, DocEntry{
-						Name:        blockName,
-						Kind:        "enum",
-						Signature:   trimmed,
-						Description: pendingDoc,
-						File:        filename,
-					})
-					pendingDoc = ""
+					Line:        fn.Line,
+					Module:      module,
+					ModulePath:  modulePath,
+					Private:     fn.Private,
 				}
-			} else {
-				// Not a documentable declaration — discard pending doc
-				pendingDoc = ""
+				tags(&member, fn)
+				entry.Members = append(entry.Members, member)
 			}
+			if d.Doc == "" && len(entry.Members) == 0 {
+				continue
+			}
+			tags(&entry, d)
+			entries = append(entries, entry)
+			continue
+		case "enum":
+			entry.Signature = enumSignature(d, spans)
+		case "const":
+			entry.Signature = constSignature(d, spans)
+		default:
+			continue
+		}
+		if d.Doc != "" {
+			tags(&entry, d)
+			entries = append(entries, entry)
 		}
 	}
-
-	return entries
+	return entries, warnings
 }
 
-// extractDocString extracts the description from #doc("some text")
-func extractDocString(line string) string {
-	// Find the opening quote after #doc(
-	start := strings.Index(line, `"`)
-	if start < 0 {
-		start = strings.Index(line, `'`)
-	}
-	if start < 0 {
-		return ""
-	}
-	quote := line[start]
-	end := strings.LastIndex(line, string(quote))
-	if end <= start {
+// sourceSpans quotes expressions out of a file by the byte offsets grayc
+// reports.
+type sourceSpans string
+
+// text returns the source of span, or "" when grayc gave no offsets or
+// they do not fit the file.
+func (s sourceSpans) text(span *srcSpan) string {
+	if span == nil || span.Start < 0 || span.End <= span.Start || span.End > len(s) {
 		return ""
 	}
-	return line[start+1 : end]
+	return string(s[span.Start:span.End])
 }
 
-// extractFuncName extracts function name from "do funcname(" or "private do funcname("
-func extractFuncName(line string) string {
-	line = strings.TrimPrefix(line, "private ")
-	line = strings.TrimPrefix(line, "do ")
-	if idx := strings.Index(line, "("); idx >= 0 {
-		return strings.TrimSpace(line[:idx])
+// typeText quotes a type as written, falling back to grayc's canonical
+// spelling (which writes mod.Type as mod_Type).
+func (s sourceSpans) typeText(canonical string, span *srcSpan) string {
+	if text := s.text(span); text != "" {
+		return text
 	}
-	return strings.Fields(line)[0]
+	return canonical
 }
 
-// extractStructEnumName extracts name from "const Name struct {" or "const Name enum {"
-func extractStructEnumName(line string) string {
-	line = strings.TrimPrefix(line, "const ")
-	fields := strings.Fields(line)
-	if len(fields) > 0 {
-		return fields[0]
+// withDefault appends " = <expr>" when span quotes to something.
+func withDefault(s string, spans sourceSpans, span *srcSpan) string {
+	if text := spans.text(span); text != "" {
+		return s + " = " + text
 	}
-	return ""
+	return s
 }
 
-func collectDocsFromDir(dir string) []DocEntry {
-	var entries []DocEntry
-	absDir, err := filepath.Abs(dir)
-	if err != nil {
-		fmt.Printf("Error resolving path %s: %v\n", dir, err)
-		return entries
+// funcSignature renders a function header: `do name(params) -> returns`.
+// Grouped parameters (`a, b int`) are written out one by one.
+func funcSignature(d dumpedDecl, spans sourceSpans) string {
+	var b strings.Builder
+	if d.Private {
+		b.WriteString("private ")
 	}
-	files, err := os.ReadDir(absDir)
-	if err != nil {
-		fmt.Printf("Error reading directory %s: %v\n", dir, err)
-		return entries
+	b.WriteString("do " + d.Name + "(")
+	for i, p := range d.Params {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		param := p.Name + " " + spans.typeText(p.Type, p.TypeSpan)
+		if p.Mutable {
+			param = "&" + param
+		}
+		if p.TypeParam {
+			param = p.Name + " <?>"
+		}
+		b.WriteString(withDefault(param, spans, p.Default))
 	}
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".gray") {
-			entries = append(entries, collectDocsFromFile(filepath.Join(absDir, file.Name()))...)
+	b.WriteString(")")
+
+	switch {
+	case len(d.Returns) == 0:
+	case len(d.Returns) == 1 && d.Returns[0].Name == "":
+		b.WriteString(" -> " + spans.typeText(d.Returns[0].Type, d.Returns[0].TypeSpan))
+	default:
+		parts := make([]string, len(d.Returns))
+		for i, r := range d.Returns {
+			parts[i] = spans.typeText(r.Type, r.TypeSpan)
+			if r.Name != "" {
+				parts[i] = r.Name + " " + parts[i]
+			}
 		}
+		b.WriteString(" -> (" + strings.Join(parts, ", ") + ")")
 	}
-	return entries
+	return b.String()
 }
 
-func collectDocsRecursive(dir string) []DocEntry {
-	var entries []DocEntry
-	absDir, err := filepath.Abs(dir)
-	if err != nil {
-		fmt.Printf("Error resolving path %s: %v\n", dir, err)
-		return entries
+// structSignature renders a struct with its fields, defaults, and the
+// headers of its struct functions.
+func structSignature(d dumpedDecl, spans sourceSpans) string {
+	var b strings.Builder
+	if d.JSON {
+		b.WriteString("#json\n")
 	}
-	filepath.Walk(absDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-		if !info.IsDir() && strings.HasSuffix(path, ".gray") {
-			entries = append(entries, collectDocsFromFile(path)...)
+	b.WriteString("const " + d.Name + " struct {\n")
+	for _, f := range d.Fields {
+		b.WriteString("    " + withDefault(f.Name+" "+spans.typeText(f.Type, f.TypeSpan), spans, f.Default) + "\n")
+	}
+	if len(d.Fields) > 0 && len(d.Functions) > 0 {
+		b.WriteString("\n")
+	}
+	for _, fn := range d.Functions {
+		b.WriteString("    " + funcSignature(fn, spans) + "\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// enumSignature renders an enum with its variants, explicit values, and
+// payload types.
+func enumSignature(d dumpedDecl, spans sourceSpans) string {
+	var b strings.Builder
+	if d.Flags {
+		b.WriteString("#flags\n")
+	}
+	b.WriteString("const " + d.Name + " enum {\n")
+	for _, v := range d.Variants {
+		variant := v.Name
+		if len(v.Payload) > 0 {
+			variant += "(" + strings.Join(v.Payload, ", ") + ")"
 		}
-		return nil
-	})
-	return entries
+		b.WriteString("    " + withDefault(variant, spans, v.Value) + "\n")
+	}
+	b.WriteString("}")
+	return b.String()
 }
 
-func generateMarkdown(entries []DocEntry) string {
-	var buf strings.Builder
+// constSignature renders `const NAME type = value`.
+func constSignature(d dumpedDecl, spans sourceSpans) string {
+	sig := "const " + d.Name
+	if d.Private {
+		sig = "private " + sig
+	}
+	if d.Type != "" {
+		sig += " " + spans.typeText(d.Type, d.TypeSpan)
+	}
+	return withDefault(sig, spans, d.Value)
+}
 
-	buf.WriteString("# Documentation\n\n")
-	buf.WriteString("*Generated by `gray doc`*\n\n")
+// docSection is one kind of item in the generated reference, in the order
+// sections are emitted.
+type docSection struct {
+	Title   string
+	Entries []DocEntry
+}
 
-	var functions, structs, enums []DocEntry
+// docSections groups entries into Functions, Structs, Enums, and
+// Constants, each sorted by name. Empty sections are dropped.
+func docSections(entries []DocEntry) []docSection {
+	sections := []docSection{{Title: "Functions"}, {Title: "Structs"}, {Title: "Enums"}, {Title: "Constants"}}
 	for _, e := range entries {
 		switch e.Kind {
 		case "function":
-			functions = append(functions, e)
+			sections[0].Entries = append(sections[0].Entries, e)
 		case "struct":
-			structs = append(structs, e)
+			sections[1].Entries = append(sections[1].Entries, e)
 		case "enum":
-			enums = append(enums, e)
-		}
-	}
-
-	sort.Slice(functions, func(i, j int) bool { return functions[i].Name < functions[j].Name })
-	sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
-	sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
-
-	if len(functions) > 0 {
-		buf.WriteString("## Functions\n\n")
-		for _, f := range functions {
-			buf.WriteString(fmt.Sprintf("### %s\n\n", f.Name))
-			buf.WriteString(fmt.Sprintf("```gray\n%s\n```\n\n", f.Signature))
-			if f.Description != "" {
-				buf.WriteString(f.Description + "\n\n")
-			}
-		}
-	}
-
-	if len(structs) > 0 {
-		buf.WriteString("## Structs\n\n")
-		for _, s := range structs {
-			buf.WriteString(fmt.Sprintf("### %s\n\n", s.Name))
-			buf.WriteString(fmt.Sprintf("```gray\n%s\n```\n\n", s.Signature))
-			if s.Description != "" {
-				buf.WriteString(s.Description + "\n\n")
-			}
+			sections[2].Entries = append(sections[2].Entries, e)
+		case "const":
+			sections[3].Entries = append(sections[3].Entries, e)
 		}
 	}
-
-	if len(enums) > 0 {
-		buf.WriteString("## Enums\n\n")
-		for _, e := range enums {
-			buf.WriteString(fmt.Sprintf("### %s\n\n", e.Name))
-			buf.WriteString(fmt.Sprintf("```gray\n%s\n```\n\n", e.Signature))
-			if e.Description != "" {
-				buf.WriteString(e.Description + "\n\n")
-			}
+	var out []docSection
+	for _, sec := range sections {
+		if len(sec.Entries) == 0 {
+			continue
 		}
+		sort.Slice(sec.Entries, func(i, j int) bool { return sec.Entries[i].Name < sec.Entries[j].Name })
+		out = append(out, sec)
 	}
+	return out
+}
 
-	return buf.String()
+// isDocURL reports whether a @see target is a web link.
+func isDocURL(target string) bool {
+	return strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://")
 }
@@ -16,12 +16,11 @@ import (
 )
 
 // writeGraySource writes a tiny .gray source file with one documented
-// function so generateDocs has at least one entry to emit.
+// function so generateDocs has at least one entry to emit, and stands in
+// for grayc's declaration dump of it.
 func writeGraySource(t *testing.T, dir string) string {
 	t.Helper()
-	src := `module main
-
-#doc("greet returns a friendly hello.")
+	src := `#doc("greet returns a friendly hello.")
 do greet() -> string {
     return "hello"
 }
@@ -30,9 +29,20 @@ do greet() -> string {
 	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
 		t.Fatalf("write source: %v", err)
 	}
+	useDeclDump(t, `{"file":"main.gray","decls":[{"kind":"function","name":"greet","line":2,"column":1,`+
+		`"private":false,"doc":"greet returns a friendly hello.","params":[],"returns":[{"name":null,"type":"string"}]}]}`)
 	return path
 }
 
+// useDeclDump makes dumpDecls return dump for every file until the test
+// ends, so doc tests do not need a grayc binary.
+func useDeclDump(t *testing.T, dump string) {
+	t.Helper()
+	prev := dumpDecls
+	dumpDecls = func(string) ([]byte, error) { return []byte(dump), nil }
+	t.Cleanup(func() { dumpDecls = prev })
+}
+
 // runFromTempDir cd's into dir for the duration of fn, then restores
 // the original cwd. generateDocs writes relative paths against cwd, so
 // tests that exercise the default output path need to run inside a
@@ -0,0 +1,250 @@
+// doc_test.go — Tests for building documentation from grayc's declaration
+// dump: exact signatures, struct fields with defaults, struct functions,
+// enum variants, constants, imports, and quoting defaults from the source.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+// docTestSource exercises what the old line scanner got wrong: braces in
+// strings, multi-line signatures, and #doc stacked with #json.
+const docTestSource = `#doc("Max retries.")
+const MAX_RETRIES int = 3
+
+#json
+#doc("A point with \"defaults\" { and braces }.")
+#doc(` + "`second line`" + `)
+const Point struct {
+    x, y int = 0
+    label string = "{origin}"
+
+    #doc("Builds a point.")
+    do make(x int,
+            y int = 1 + 2) -> Point {
+        return Point{x: x, y: y}
+    }
+    private do hidden() {}
+}
+
+#doc("Colours.")
+const Color enum {
+    Red = 1
+    Green
+}
+
+#doc("Adds.")
+do add(a, b int = 0, &c string) -> (sum int, err Error) {
+    return a + b, nil
+}
+
+do main() {}
+`
+
+// docTestDump is grayc --dump-decls output for docTestSource.
+const docTestDump = `{"file":"lib.gray","imports":[],"decls":[` +
+	`{"kind":"const","name":"MAX_RETRIES","line":2,"column":1,"private":false,"doc":"Max retries.","type":"int",` +
+	`"value":{"line":2,"column":25,"end_line":2,"end_column":25,"start":45,"end":46}},` +
+	`{"kind":"struct","name":"Point","line":7,"column":7,"private":false,` +
+	`"doc":"A point with \"defaults\" { and braces }.\nsecond line","json":true,"fields":[` +
+	`{"name":"x","type":"int","default":{"line":8,"column":16,"end_line":8,"end_column":16,"start":160,"end":161}},` +
+	`{"name":"y","type":"int","default":{"line":8,"column":16,"end_line":8,"end_column":16,"start":160,"end":161}},` +
+	`{"name":"label","type":"string","default":{"line":9,"column":20,"end_line":9,"end_column":20,"start":181,"end":191}}],"functions":[` +
+	`{"kind":"function","name":"make","line":12,"column":5,"private":false,"doc":"Builds a point.","params":[` +
+	`{"name":"x","type":"int","mutable":false,"type_param":false,"default":null},` +
+	`{"name":"y","type":"int","mutable":false,"type_param":false,"default":{"line":13,"column":21,"end_line":13,"end_column":25,"start":260,"end":265}}],` +
+	`"returns":[{"name":null,"type":"Point"}]},` +
+	`{"kind":"function","name":"hidden","line":16,"column":13,"private":true,"doc":null,"params":[],"returns":[]}]},` +
+	`{"kind":"enum","name":"Color","line":20,"column":7,"private":false,"doc":"Colours.","flags":false,"variants":[` +
+	`{"name":"Red","value":{"line":21,"column":11,"end_line":21,"end_column":11,"start":393,"end":394},"payload":[]},` +
+	`{"name":"Green","value":null,"payload":[]}]},` +
+	`{"kind":"function","name":"add","line":26,"column":1,"private":false,"doc":"Adds.","params":[` +
+	`{"name":"a","type":"int","mutable":false,"type_param":false,"default":{"line":26,"column":19,"end_line":26,"end_column":19,"start":440,"end":441}},` +
+	`{"name":"b","type":"int","mutable":false,"type_param":false,"default":{"line":26,"column":19,"end_line":26,"end_column":19,"start":440,"end":441}},` +
+	`{"name":"c","type":"string","mutable":true,"type_param":false,"default":null}],` +
+	`"returns":[{"name":"sum","type":"int"},{"name":"err","type":"Error"}]},` +
+	`{"kind":"function","name":"main","line":30,"column":1,"private":false,"doc":null,"params":[],"returns":[]}]}`
+
+func docTestEntries(t *testing.T) map[string]DocEntry {
+	t.Helper()
+	var dump declDump
+	if err := json.Unmarshal([]byte(docTestDump), &dump); err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]DocEntry{}
+	entries, _ := docEntriesFromDump(dump, "lib.gray", docTestSource)
+	for _, e := range entries {
+		byName[e.Name] = e
+	}
+	return byName
+}
+
+func TestDocEntriesFromDump(t *testing.T) {
+	entries := docTestEntries(t)
+	if _, ok := entries["main"]; ok {
+		t.Error("undocumented function main should not be listed")
+	}
+	if len(entries) != 4 {
+		t.Errorf("got %d entries, want 4: %v", len(entries), entries)
+	}
+
+	tests := map[string]string{
+		"MAX_RETRIES": "const MAX_RETRIES int = 3",
+		"add":         "do add(a int = 0, b int = 0, &c string) -> (sum int, err Error)",
+		"Color":       "const Color enum {\n    Red = 1\n    Green\n}",
+		"Point": "#json\nconst Point struct {\n    x int = 0\n    y int = 0\n    label string = \"{origin}\"\n\n" +
+			"    do make(x int, y int = 1 + 2) -> Point\n    private do hidden()\n}",
+	}
+	for name, want := range tests {
+		if got := entries[name].Signature; got != want {
+			t.Errorf("%s signature:\n%s\nwant:\n%s", name, got, want)
+		}
+	}
+
+	point := entries["Point"]
+	if point.Description != "A point with \"defaults\" { and braces }.\nsecond line" {
+		t.Errorf("Point description = %q", point.Description)
+	}
+	if point.Line != 7 || point.Kind != "struct" {
+		t.Errorf("Point = %+v", point)
+	}
+	if len(point.Members) != 1 || point.Members[0].Name != "Point.make" ||
+		point.Members[0].Signature != "do make(x int, y int = 1 + 2) -> Point" {
+		t.Errorf("Point members = %+v", point.Members)
+	}
+}
+
+func TestGenerateMarkdownSections(t *testing.T) {
+	var entries []DocEntry
+	for _, e := range docTestEntries(t) {
+		entries = append(entries, e)
+	}
+	md := generateMarkdown(entries, ".")
+	order := []string{"## Contents", "- [lib](#lib)", "## <a id=\"lib\"></a>lib",
+		"### Functions", "#### <a id=\"lib-add\"></a>add", "### Structs", "#### <a id=\"lib-point\"></a>Point",
+		"##### <a id=\"lib-point-make\"></a>Point.make", "Builds a point.",
+		"### Enums", "#### <a id=\"lib-color\"></a>Color", "### Constants", "#### <a id=\"lib-max_retries\"></a>MAX_RETRIES"}
+	pos := 0
+	for _, want := range order {
+		i := strings.Index(md[pos:], want)
+		if i < 0 {
+			t.Fatalf("markdown missing %q after offset %d:\n%s", want, pos, md)
+		}
+		pos += i + len(want)
+	}
+}
+
+func TestSourceSpansMismatch(t *testing.T) {
+	spans := sourceSpans("const X int = 3\n")
+	if got := spans.text(&srcSpan{Start: 14, End: 15}); got != "3" {
+		t.Errorf("text = %q, want %q", got, "3")
+	}
+	// Offsets grayc could not find, or that do not fit the file, are
+	// ignored rather than producing a garbled default.
+	for _, span := range []*srcSpan{nil, {Start: -1, End: -1}, {Start: 14, End: 40}, {Start: 15, End: 14}} {
+		if got := spans.text(span); got != "" {
+			t.Errorf("text(%+v) quoted %q", span, got)
+		}
+	}
+}
+
+// TestCollectDocsFromFileGrayc checks the fixture above against the real
+// compiler when one is available.
+func TestCollectDocsFromFileGrayc(t *testing.T) {
+	if _, err := grayc.Find(); err != nil {
+		t.Skip("grayc not available")
+	}
+	path := filepath.Join(t.TempDir(), "lib.gray")
+	if err := os.WriteFile(path, []byte(docTestSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, _, err := collectDocsFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := docTestEntries(t)
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+	for _, e := range got {
+		if w := want[e.Name]; e.Signature != w.Signature || e.Description != w.Description || e.Line != w.Line {
+			t.Errorf("%s:\n%+v\nwant:\n%+v", e.Name, e, w)
+		}
+	}
+}
+
+func TestGenerateDocsJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "src", "shapes.gray"), "")
+	useDeclDump(t, `{"decls":[`+
+		`{"kind":"enum","name":"Perm","line":2,"private":false,"doc":"Permissions.","flags":true,"variants":[{"name":"Read","value":null,"payload":[]}]},`+
+		`{"kind":"struct","name":"Point","line":6,"private":false,"doc":"A point.","json":true,"fields":[],"functions":[`+
+		`{"kind":"function","name":"norm","line":7,"private":true,"doc":"Length.","params":[],"returns":[{"name":null,"type":"float"}]}]}]}`)
+
+	runFromTempDir(t, dir, func() {
+		if err := generateDocsJSON([]string{"./src"}, "api/docs.json"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	data, err := os.ReadFile(filepath.Join(dir, "api", "docs.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"signature": "private do norm() -> float"`) {
+		t.Errorf("signature escaped or missing:\n%s", data)
+	}
+	var out struct {
+		Entries []DocEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Entries) != 2 {
+		t.Fatalf("got %d entries:\n%s", len(out.Entries), data)
+	}
+	perm, point := out.Entries[0], out.Entries[1]
+	if perm.Module != "shapes" || perm.File != "src/shapes.gray" || perm.Line != 2 ||
+		strings.Join(perm.Attributes, ",") != "flags" {
+		t.Errorf("Perm = %+v", perm)
+	}
+	if strings.Join(point.Attributes, ",") != "json" || len(point.Members) != 1 {
+		t.Fatalf("Point = %+v", point)
+	}
+	if m := point.Members[0]; m.Name != "Point.norm" || !m.Private || m.Module != "shapes" || m.File != "src/shapes.gray" {
+		t.Errorf("Point.norm = %+v", m)
+	}
+}
+
+// TestCollectDocsImportsGrayc checks that the imports come from grayc,
+// leaving out C headers.
+func TestCollectDocsImportsGrayc(t *testing.T) {
+	if _, err := grayc.Find(); err != nil {
+		t.Skip("grayc not available")
+	}
+	path := filepath.Join(t.TempDir(), "lib.gray")
+	writeTestFile(t, path, "import and use @strings\nimport io @io, \"./util\"\nimport c\"x.h\"\n\n#doc(\"F.\")\ndo f() {}\n")
+	_, got, err := collectDocsFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []grayImport{
+		{Alias: "strings", Path: "strings", Stdlib: true, AutoUse: true, Line: 1},
+		{Alias: "io", Path: "io", Stdlib: true, Line: 2},
+		{Alias: "util", Path: "./util", Line: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("imports = %+v, want %+v", got, want)
+	}
+}
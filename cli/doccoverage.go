@@ -0,0 +1,191 @@
+// doccoverage.go — Documentation coverage for "gray doc --coverage".
+// Counts the public functions (struct functions included), structs, and
+// enums that carry #doc, lists the ones that do not, and summarises
+// coverage per file, per module, and overall, optionally
+// failing below a threshold (--fail-under).
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// docCoverageItem is one public declaration that should be documented.
+type docCoverageItem struct {
+	Name       string
+	Kind       string // "function", "struct", "enum"
+	Line       int
+	Documented bool
+}
+
+// docCoverageFile holds the declarations counted in one file.
+type docCoverageFile struct {
+	Path   string // relative to the working directory when possible
+	Module string // import path of the module the file is part of, as DocEntry.ModulePath
+	Items  []docCoverageItem
+}
+
+// docCoverageItems picks the declarations coverage counts out of a dump:
+// public functions other than main, public struct functions, structs, and
+// enums. Constants are not counted.
+func docCoverageItems(dump declDump) []docCoverageItem {
+	var items []docCoverageItem
+	for _, d := range dump.Decls {
+		switch d.Kind {
+		case "function":
+			if d.Private || d.Name == "main" {
+				continue
+			}
+		case "struct":
+			for _, fn := range d.Functions {
+				if !fn.Private {
+					items = append(items, docCoverageItem{d.Name + "." + fn.Name, "function", fn.Line, fn.Doc != ""})
+				}
+			}
+			if d.Private {
+				continue
+			}
+		case "enum":
+			if d.Private {
+				continue
+			}
+		default:
+			continue
+		}
+		items = append(items, docCoverageItem{d.Name, d.Kind, d.Line, d.Doc != ""})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Line < items[j].Line })
+	return items
+}
+
+// collectDocCoverage reads the declarations of every file named by args
+// and the module each file is part of, as gray doc assigns them.
+func collectDocCoverage(args []string) ([]docCoverageFile, error) {
+	files, err := docSourceFiles(args)
+	if err != nil {
+		return nil, err
+	}
+	var out []docCoverageFile
+	modules := make([]DocEntry, len(files))
+	imports := docImports{}
+	for i, file := range files {
+		dump, _, err := readDeclDump(file)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, docCoverageFile{Path: relPath(file), Items: docCoverageItems(dump)})
+		modules[i] = DocEntry{File: file, ModulePath: fileModulePath(file)}
+		if abs, err := filepath.Abs(file); err == nil {
+			imports[abs] = dump.Imports
+		}
+	}
+	assignDirModules(imports, modules)
+	for i := range out {
+		out[i].Module = modules[i].ModulePath
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+// docCoverageCount is a documented/total pair.
+type docCoverageCount struct{ Documented, Total int }
+
+func (c *docCoverageCount) add(items []docCoverageItem) {
+	for _, it := range items {
+		c.Total++
+		if it.Documented {
+			c.Documented++
+		}
+	}
+}
+
+// Percent is the share documented; nothing to document counts as 100%.
+func (c docCoverageCount) Percent() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+	return 100 * float64(c.Documented) / float64(c.Total)
+}
+
+func (c docCoverageCount) String() string {
+	return fmt.Sprintf("%d/%d  %5.1f%%", c.Documented, c.Total, c.Percent())
+}
+
+// writeDocCoverage prints the undocumented declarations followed by
+// coverage per file, per module, and in total, and returns the total. Files with nothing to document are left out of the tables.
+func writeDocCoverage(w io.Writer, files []docCoverageFile) docCoverageCount {
+	var total docCoverageCount
+	var missing []string
+	modules := map[string]*docCoverageCount{}
+	width := 0
+	for _, f := range files {
+		total.add(f.Items)
+		for _, it := range f.Items {
+			if !it.Documented {
+				missing = append(missing, fmt.Sprintf("  %s:%d: %s %s", f.Path, it.Line, it.Kind, it.Name))
+			}
+		}
+		if len(f.Items) == 0 {
+			continue
+		}
+		if modules[f.Module] == nil {
+			modules[f.Module] = &docCoverageCount{}
+		}
+		modules[f.Module].add(f.Items)
+		width = max(width, len(f.Path), len(f.Module))
+	}
+
+	if len(missing) > 0 {
+		fmt.Fprintln(w, "Undocumented public declarations:")
+		fmt.Fprintln(w, strings.Join(missing, "\n"))
+		fmt.Fprintln(w)
+	}
+	if total.Total > 0 {
+		fmt.Fprintln(w, "Coverage by file:")
+		for _, f := range files {
+			if len(f.Items) > 0 {
+				var c docCoverageCount
+				c.add(f.Items)
+				fmt.Fprintf(w, "  %-*s  %s\n", width, f.Path, c)
+			}
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Coverage by module:")
+		names := make([]string, 0, len(modules))
+		for name := range modules {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(w, "  %-*s  %s\n", width, name, *modules[name])
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "Total: %d of %d public declarations documented (%.1f%%)\n", total.Documented, total.Total, total.Percent())
+	return total
+}
+
+// runDocCoverage is the entry point for gray doc --coverage. failUnder is
+// the minimum total percentage; 0 disables the check.
+func runDocCoverage(args []string, failUnder float64) int {
+	files, err := collectDocCoverage(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gray doc: %v\n", err)
+		return 1
+	}
+	total := writeDocCoverage(os.Stdout, files)
+	if failUnder > 0 && total.Percent() < failUnder {
+		fmt.Fprintf(os.Stderr, "gray doc: coverage %.1f%% is below --fail-under %g%%\n", total.Percent(), failUnder)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+// doccoverage_test.go — Tests for gray doc --coverage: which declarations
+// count, the per-file and per-module report, and --fail-under.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDocCoverageItems(t *testing.T) {
+	var dump declDump
+	if err := json.Unmarshal([]byte(docTestDump), &dump); err != nil {
+		t.Fatal(err)
+	}
+	// MAX_RETRIES is a constant, Point.hidden is private, and main is
+	// not counted.
+	want := []docCoverageItem{
+		{"Point", "struct", 7, true},
+		{"Point.make", "function", 12, true},
+		{"Color", "enum", 20, true},
+		{"add", "function", 26, true},
+	}
+	if got := docCoverageItems(dump); !reflect.DeepEqual(got, want) {
+		t.Errorf("docCoverageItems =\n%v\nwant\n%v", got, want)
+	}
+
+	dump = declDump{Decls: []dumpedDecl{
+		{Kind: "function", Name: "helper", Line: 3},
+		{Kind: "function", Name: "secret", Line: 1, Private: true},
+		{Kind: "struct", Name: "Box", Line: 5, Doc: "A box.", Functions: []dumpedDecl{{Kind: "function", Name: "open", Line: 6}}},
+	}}
+	want = []docCoverageItem{{"helper", "function", 3, false}, {"Box", "struct", 5, true}, {"Box.open", "function", 6, false}}
+	if got := docCoverageItems(dump); !reflect.DeepEqual(got, want) {
+		t.Errorf("docCoverageItems =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestWriteDocCoverage(t *testing.T) {
+	files := []docCoverageFile{
+		{Path: "lib/a.gray", Module: "lib", Items: []docCoverageItem{{"f", "function", 2, true}, {"g", "function", 9, false}}},
+		{Path: "lib/b.gray", Module: "lib", Items: []docCoverageItem{{"S", "struct", 4, true}}},
+		{Path: "main.gray", Module: "main"},
+	}
+	var b strings.Builder
+	total := writeDocCoverage(&b, files)
+	if total != (docCoverageCount{2, 3}) {
+		t.Errorf("total = %+v", total)
+	}
+	want := `Undocumented public declarations:
+  lib/a.gray:9: function g
+
+Coverage by file:
+  lib/a.gray  1/2   50.0%
+  lib/b.gray  1/1  100.0%
+
+Coverage by module:
+  lib         2/3   66.7%
+
+Total: 2 of 3 public declarations documented (66.7%)
+`
+	if b.String() != want {
+		t.Errorf("report:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	if total := writeDocCoverage(&b, nil); total.Percent() != 100 || b.String() != "Total: 0 of 0 public declarations documented (100.0%)\n" {
+		t.Errorf("empty report: %q", b.String())
+	}
+}
+
+func TestCollectDocCoverageModules(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.gray", "util.gray", "models/user.gray", "models/post.gray", "lib/extra.gray"} {
+		writeTestFile(t, filepath.Join(dir, name), "")
+	}
+	dumps := map[string]string{
+		"main.gray": `{"imports":[{"alias":"models","path":"./models","line":1}],"decls":[]}`,
+	}
+	prev := dumpDecls
+	dumpDecls = func(file string) ([]byte, error) {
+		if d, ok := dumps[filepath.Base(file)]; ok {
+			return []byte(d), nil
+		}
+		return []byte(`{"decls":[]}`), nil
+	}
+	t.Cleanup(func() { dumpDecls = prev })
+
+	runFromTempDir(t, dir, func() {
+		files, err := collectDocCoverage([]string{"./..."})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := map[string]string{}
+		for _, f := range files {
+			got[filepath.ToSlash(f.Path)] = f.Module
+		}
+		// models/ is imported as a directory; lib/ is not, so its file is
+		// a module of its own.
+		want := map[string]string{
+			"main.gray": "main", "util.gray": "util", "lib/extra.gray": "lib/extra",
+			"models/user.gray": "models", "models/post.gray": "models",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("modules = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestRunDocCoverageFailUnder(t *testing.T) {
+	dir := t.TempDir()
+	path := writeGraySource(t, dir) // greet, documented
+	useDeclDump(t, `{"decls":[{"kind":"function","name":"greet","line":2,"doc":"Hi."},`+
+		`{"kind":"function","name":"bye","line":6,"doc":null}]}`)
+	args := []string{path}
+	if code := runDocCoverage(args, 50); code != 0 {
+		t.Errorf("50%% coverage failed --fail-under 50")
+	}
+	if code := runDocCoverage(args, 80); code != 1 {
+		t.Errorf("50%% coverage passed --fail-under 80")
+	}
+}
@@ -0,0 +1,342 @@
+// docmarkdown.go — Markdown output for "gray doc". Items are grouped by
+// module (a file, or a directory imported as one namespace), each with
+// its own Functions/Structs/Enums/Constants sections, behind a linked
+// table of contents. Headings carry explicit anchors built from the
+// module path, so two modules' init functions get distinct, stable
+// links. With --split each module is written to its own file next to an
+// index.md holding the table of contents.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// defaultDocSplitDir is where gray doc --split writes without --output.
+const defaultDocSplitDir = "docs"
+
+// docModule is the documented items of one module.
+type docModule struct {
+	Name    string // name the module is imported under
+	Path    string // import path, e.g. "src/shapes"
+	Entries []DocEntry
+}
+
+// anchor is the fragment of the module's heading.
+func (m docModule) anchor() string {
+	return docAnchor(m.Path)
+}
+
+// fileName is the module's page under gray doc --split. A module named
+// index would otherwise replace the table of contents.
+func (m docModule) fileName() string {
+	switch name := m.anchor(); name {
+	case "":
+		return "module.md"
+	case "index":
+		return "index-module.md"
+	default:
+		return name + ".md"
+	}
+}
+
+// docModulesOf groups entries by module, ordered by module path.
+func docModulesOf(entries []DocEntry) []docModule {
+	var modules []docModule
+	index := map[string]int{}
+	for _, e := range entries {
+		i, ok := index[e.ModulePath]
+		if !ok {
+			i = len(modules)
+			index[e.ModulePath] = i
+			modules = append(modules, docModule{Name: e.Module, Path: e.ModulePath})
+		}
+		modules[i].Entries = append(modules[i].Entries, e)
+	}
+	sort.SliceStable(modules, func(i, j int) bool { return modules[i].Path < modules[j].Path })
+	return modules
+}
+
+// docAnchor makes an HTML id out of a module path or item name: letters,
+// digits, '_' and '-' are kept (lowercased) and anything else becomes '-'.
+func docAnchor(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == '-' || r == '_' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// itemAnchor is the fragment of an item's heading: the module's anchor
+// followed by the item's name, e.g. "src-shapes-point-make".
+func itemAnchor(m docModule, name string) string {
+	if m.Path == "" {
+		return docAnchor(name)
+	}
+	return m.anchor() + "-" + docAnchor(name)
+}
+
+// markdownDoc renders the Markdown reference for a set of modules.
+type markdownDoc struct {
+	modules []docModule
+	split   bool   // one file per module
+	outDir  string // directory the Markdown is written to, for source links
+	// Link targets for @see: by module path and item name, and by item
+	// name across all modules.
+	items  map[string]map[string]string
+	byName map[string][]string
+}
+
+func newMarkdownDoc(entries []DocEntry, outDir string, split bool) *markdownDoc {
+	d := &markdownDoc{
+		modules: docModulesOf(entries),
+		split:   split,
+		outDir:  outDir,
+		items:   map[string]map[string]string{},
+		byName:  map[string][]string{},
+	}
+	for _, m := range d.modules {
+		d.items[m.Path] = map[string]string{}
+		for _, e := range m.Entries {
+			for _, item := range append([]DocEntry{e}, e.Members...) {
+				href := d.href(m, itemAnchor(m, item.Name))
+				d.items[m.Path][item.Name] = href
+				d.byName[item.Name] = append(d.byName[item.Name], href)
+			}
+		}
+	}
+	return d
+}
+
+// href links to anchor in module m from the index or, for single-file
+// output, from anywhere in the document.
+func (d *markdownDoc) href(m docModule, anchor string) string {
+	if d.split {
+		return m.fileName() + "#" + anchor
+	}
+	return "#" + anchor
+}
+
+// generateMarkdown renders entries as one Markdown file written to outDir.
+func generateMarkdown(entries []DocEntry, outDir string) string {
+	d := newMarkdownDoc(entries, outDir, false)
+	var buf strings.Builder
+	buf.WriteString("# Documentation\n\n")
+	buf.WriteString("*Generated by `gray doc`*\n\n")
+	d.writeContents(&buf, "##")
+	for _, m := range d.modules {
+		d.writeModule(&buf, m, 2)
+	}
+	return buf.String()
+}
+
+// generateMarkdownSplit renders entries as index.md plus one file per
+// module, keyed by file name.
+func generateMarkdownSplit(entries []DocEntry, outDir string) map[string]string {
+	d := newMarkdownDoc(entries, outDir, true)
+	files := map[string]string{}
+	var index strings.Builder
+	index.WriteString("# Documentation\n\n")
+	index.WriteString("*Generated by `gray doc`*\n\n")
+	d.writeContents(&index, "##")
+	files["index.md"] = index.String()
+	for _, m := range d.modules {
+		var buf strings.Builder
+		buf.WriteString("[Index](index.md)\n\n")
+		d.writeModule(&buf, m, 1)
+		files[m.fileName()] = buf.String()
+	}
+	return files
+}
+
+// writeContents writes the table of contents: each module, then its
+// top-level items in section order.
+func (d *markdownDoc) writeContents(buf *strings.Builder, heading string) {
+	buf.WriteString(heading + " Contents\n\n")
+	for _, m := range d.modules {
+		fmt.Fprintf(buf, "- [%s](%s)%s\n", moduleTitle(m), d.href(m, m.anchor()), modulePathNote(m))
+		for _, sec := range docSections(m.Entries) {
+			for _, e := range sec.Entries {
+				fmt.Fprintf(buf, "  - [%s](%s)\n", e.Name, d.href(m, itemAnchor(m, e.Name)))
+			}
+		}
+	}
+	buf.WriteString("\n")
+}
+
+func moduleTitle(m docModule) string {
+	switch {
+	case m.Name != "":
+		return m.Name
+	case m.Path != "":
+		return m.Path
+	}
+	return "Module"
+}
+
+// modulePathNote shows the import path when it says more than the name.
+func modulePathNote(m docModule) string {
+	if m.Path == "" || m.Path == m.Name {
+		return ""
+	}
+	return " — `" + m.Path + "`"
+}
+
+// writeModule writes a module heading at the given level and its items
+// one and two levels below their section headings.
+func (d *markdownDoc) writeModule(buf *strings.Builder, m docModule, level int) {
+	h := func(n int) string { return strings.Repeat("#", n) }
+	fmt.Fprintf(buf, "%s <a id=\"%s\"></a>%s\n\n", h(level), m.anchor(), moduleTitle(m))
+	if m.Path != "" && m.Path != m.Name {
+		fmt.Fprintf(buf, "Module `%s`\n\n", m.Path)
+	}
+	for _, sec := range docSections(m.Entries) {
+		fmt.Fprintf(buf, "%s %s\n\n", h(level+1), sec.Title)
+		for _, e := range sec.Entries {
+			d.writeItem(buf, m, e, h(level+2))
+			for _, mem := range e.Members {
+				d.writeItem(buf, m, mem, h(level+3))
+			}
+		}
+	}
+}
+
+// writeItem writes one item's heading, signature, source location,
+// description, and tag sections.
+func (d *markdownDoc) writeItem(buf *strings.Builder, m docModule, e DocEntry, heading string) {
+	fmt.Fprintf(buf, "%s <a id=\"%s\"></a>%s\n\n", heading, itemAnchor(m, e.Name), e.Name)
+	fmt.Fprintf(buf, "```gray\n%s\n```\n\n", e.Signature)
+	if src := d.sourceLink(e); src != "" {
+		buf.WriteString("Source: " + src + "\n\n")
+	}
+	if e.Deprecated {
+		note := "**Deprecated.**"
+		if e.DeprecatedNote != "" {
+			note = "**Deprecated:** " + e.DeprecatedNote
+		}
+		buf.WriteString("> " + note + "\n\n")
+	}
+	if e.Description != "" {
+		buf.WriteString(e.Description + "\n\n")
+	}
+	if len(e.Params) > 0 {
+		buf.WriteString("**Parameters**\n\n")
+		for _, p := range e.Params {
+			if p.Text == "" {
+				fmt.Fprintf(buf, "- `%s`\n", p.Name)
+			} else {
+				fmt.Fprintf(buf, "- `%s` — %s\n", p.Name, p.Text)
+			}
+		}
+		buf.WriteString("\n")
+	}
+	if len(e.Returns) == 1 {
+		buf.WriteString("**Returns** " + e.Returns[0] + "\n\n")
+	} else if len(e.Returns) > 1 {
+		buf.WriteString("**Returns**\n\n")
+		for _, r := range e.Returns {
+			buf.WriteString("- " + r + "\n")
+		}
+		buf.WriteString("\n")
+	}
+	for _, example := range e.Examples {
+		fmt.Fprintf(buf, "**Example**\n\n```gray\n%s\n```\n\n", example)
+	}
+	if len(e.See) > 0 {
+		links := make([]string, len(e.See))
+		for i, target := range e.See {
+			links[i] = d.seeLink(m, target)
+		}
+		buf.WriteString("**See also:** " + strings.Join(links, ", ") + "\n\n")
+	}
+}
+
+// sourceLink is "[path:line](path#Lline)" with the link relative to the
+// output directory. It is empty for entries without a file.
+func (d *markdownDoc) sourceLink(e DocEntry) string {
+	if e.File == "" {
+		return ""
+	}
+	shown := filepath.ToSlash(relPath(e.File))
+	target := shown
+	absFile, err1 := filepath.Abs(e.File)
+	absDir, err2 := filepath.Abs(d.outDir)
+	if err1 == nil && err2 == nil {
+		if rel, err := filepath.Rel(absDir, absFile); err == nil {
+			target = filepath.ToSlash(rel)
+		}
+	}
+	return fmt.Sprintf("[%s:%d](%s#L%d)", shown, e.Line, target, e.Line)
+}
+
+// seeLink resolves a @see target written in module m: an item of m
+// itself, "module.item" naming another module, or an item name that only
+// one module documents. Web links are kept as links; anything else is
+// shown as code.
+func (d *markdownDoc) seeLink(m docModule, target string) string {
+	if isDocURL(target) {
+		return "<" + target + ">"
+	}
+	href := d.items[m.Path][target]
+	if href == "" {
+		if mod, name, ok := strings.Cut(target, "."); ok {
+			for _, other := range d.modules {
+				if other.Name == mod || other.Path == mod {
+					if h := d.items[other.Path][name]; h != "" {
+						href = h
+						break
+					}
+				}
+			}
+		}
+	}
+	if href == "" && len(d.byName[target]) == 1 {
+		href = d.byName[target][0]
+	}
+	if href == "" {
+		return "`" + target + "`"
+	}
+	if d.split && strings.HasPrefix(href, m.fileName()+"#") {
+		href = strings.TrimPrefix(href, m.fileName())
+	}
+	return fmt.Sprintf("[%s](%s)", target, href)
+}
+
+// generateDocsSplit is gray doc --split: index.md and one Markdown file
+// per module in outDir.
+func generateDocsSplit(args []string, outDir string) error {
+	entries, _, err := collectDocs(args)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No documented items found.")
+		return nil
+	}
+	files := generateMarkdownSplit(entries, outDir)
+	warnOutsideCwd(outDir)
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("creating output directory %s: %v", outDir, err)
+	}
+	for name, text := range files {
+		path := filepath.Join(outDir, name)
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			return fmt.Errorf("writing %s: %v", path, err)
+		}
+	}
+	fmt.Printf("Generated %d file(s) in %s with %d documented item(s)\n", len(files), outDir, len(entries))
+	return nil
+}
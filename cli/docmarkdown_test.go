@@ -0,0 +1,139 @@
+// docmarkdown_test.go — Tests for the Markdown reference: grouping by
+// module, anchors that keep same-named items apart, the table of
+// contents, source links, @see resolution, and --split output.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// docMarkdownEntries documents an init function in two modules, one of
+// them a directory module.
+func docMarkdownEntries() []DocEntry {
+	return []DocEntry{
+		{Name: "init", Kind: "function", Signature: "do init()", Description: "Sets up the db.",
+			File: "src/db.gray", Line: 4, Module: "db", ModulePath: "src/db", See: []string{"connect", "models.init"}},
+		{Name: "connect", Kind: "function", Signature: "do connect()", Description: "Connects.",
+			File: "src/db.gray", Line: 9, Module: "db", ModulePath: "src/db"},
+		{Name: "init", Kind: "function", Signature: "do init()", Description: "Registers the models.",
+			File: "src/models/user.gray", Line: 2, Module: "models", ModulePath: "src/models", See: []string{"connect"}},
+	}
+}
+
+func TestDocAnchor(t *testing.T) {
+	for in, want := range map[string]string{"src/shapes": "src-shapes", "Point.make": "point-make", "MAX_RETRIES": "max_retries"} {
+		if got := docAnchor(in); got != want {
+			t.Errorf("docAnchor(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateMarkdownModules(t *testing.T) {
+	md := generateMarkdown(docMarkdownEntries(), "docs")
+	order := []string{
+		"## Contents",
+		"- [db](#src-db) — `src/db`\n  - [connect](#src-db-connect)\n  - [init](#src-db-init)\n",
+		"- [models](#src-models) — `src/models`\n  - [init](#src-models-init)\n",
+		"## <a id=\"src-db\"></a>db\n\nModule `src/db`",
+		"#### <a id=\"src-db-init\"></a>init",
+		"Source: [src/db.gray:4](../src/db.gray#L4)",
+		"Sets up the db.",
+		"**See also:** [connect](#src-db-connect), [models.init](#src-models-init)",
+		"## <a id=\"src-models\"></a>models",
+		"#### <a id=\"src-models-init\"></a>init",
+		"Source: [src/models/user.gray:2](../src/models/user.gray#L2)",
+		"**See also:** [connect](#src-db-connect)",
+	}
+	pos := 0
+	for _, want := range order {
+		i := strings.Index(md[pos:], want)
+		if i < 0 {
+			t.Fatalf("markdown missing %q after offset %d:\n%s", want, pos, md)
+		}
+		pos += i + len(want)
+	}
+}
+
+func TestGenerateMarkdownSplit(t *testing.T) {
+	files := generateMarkdownSplit(docMarkdownEntries(), "docs")
+	if len(files) != 3 {
+		t.Fatalf("got files %v", files)
+	}
+	if !strings.Contains(files["index.md"], "- [db](src-db.md#src-db) — `src/db`\n  - [connect](src-db.md#src-db-connect)") {
+		t.Errorf("index.md:\n%s", files["index.md"])
+	}
+	db := files["src-db.md"]
+	for _, want := range []string{"[Index](index.md)", "# <a id=\"src-db\"></a>db", "### <a id=\"src-db-init\"></a>init",
+		"**See also:** [connect](#src-db-connect), [models.init](src-models.md#src-models-init)"} {
+		if !strings.Contains(db, want) {
+			t.Errorf("src-db.md missing %q:\n%s", want, db)
+		}
+	}
+	if !strings.Contains(files["src-models.md"], "[connect](src-db.md#src-db-connect)") {
+		t.Errorf("src-models.md:\n%s", files["src-models.md"])
+	}
+}
+
+func TestAssignDirModules(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.gray"), "import \"./models\"\nusing models\ndo main() {}\n")
+	writeTestFile(t, filepath.Join(dir, "models", "user.gray"), "do init() {}\n")
+	writeTestFile(t, filepath.Join(dir, "util.gray"), "do init() {}\n")
+	runFromTempDir(t, dir, func() {
+		files := []string{"main.gray", filepath.Join("models", "user.gray"), "util.gray"}
+		entries := []DocEntry{
+			{Name: "init", File: files[1], Module: "user", ModulePath: "models/user"},
+			{Name: "init", File: files[2], Module: "util", ModulePath: "util"},
+		}
+		assignDirModules(docImports{filepath.Join(dir, "main.gray"): {{Alias: "models", Path: "./models"}}}, entries)
+		if entries[0].Module != "models" || entries[0].ModulePath != "models" {
+			t.Errorf("directory module = %q %q", entries[0].Module, entries[0].ModulePath)
+		}
+		if entries[1].Module != "util" || entries[1].ModulePath != "util" {
+			t.Errorf("file module = %q %q", entries[1].Module, entries[1].ModulePath)
+		}
+	})
+}
+
+func TestAssignDirModulesMatchesSymbols(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "models", "user.gray"), "do init() {}\n")
+	// grayc resolves this against lib/, where there is no models
+	// directory, so models/user.gray stays a module of its own.
+	writeTestFile(t, filepath.Join(dir, "lib", "a.gray"), "import \"./models\"\ndo f() {}\n")
+	runFromTempDir(t, dir, func() {
+		files := []string{filepath.Join("lib", "a.gray"), filepath.Join("models", "user.gray")}
+		entries := []DocEntry{{Name: "init", File: files[1], Module: "user", ModulePath: "models/user"}}
+		assignDirModules(docImports{filepath.Join(dir, "lib", "a.gray"): {{Alias: "models", Path: "./models"}}}, entries)
+
+		abs, _ := filepath.Abs(files[1])
+		idx := buildSymbolIndex([]string{abs, filepath.Join(dir, "lib", "a.gray")})
+		sym := idx.symbolAt(abs, 1, 4)
+		if sym == nil || entries[0].Module != sym.Module {
+			t.Errorf("gray doc module %q, gray symbols %+v", entries[0].Module, sym)
+		}
+	})
+}
+
+func TestGenerateDocsSplit(t *testing.T) {
+	dir := t.TempDir()
+	src := writeGraySource(t, dir)
+	useDeclDump(t, `{"decls":[{"kind":"function","name":"greet","line":2,"doc":"Hi."}]}`)
+	out := filepath.Join(dir, "docs")
+	if err := generateDocsSplit([]string{src}, out); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"index.md", docAnchor(strings.TrimSuffix(filepath.ToSlash(relPath(src)), ".gray")) + ".md"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("missing %s: %v", name, err)
+		}
+	}
+}
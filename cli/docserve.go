@@ -0,0 +1,294 @@
+// docserve.go — Live documentation server ("gray doc serve"). Builds the
+// HTML site from gray doc --html in memory, serves it over HTTP, and
+// rebuilds it when .gray files change, telling connected browsers to
+// reload over server-sent events.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"html"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+// defaultDocServeAddr is where gray doc serve listens without --addr.
+const defaultDocServeAddr = "localhost:6060"
+
+// docReloadPath is the server-sent events endpoint pages subscribe to.
+const docReloadPath = "/_reload"
+
+// docReloadScript is added to every served page. The browser reconnects
+// on its own if the server restarts.
+const docReloadScript = `<script>new EventSource("` + docReloadPath + `").onmessage = function () { location.reload(); };</script>
+`
+
+var docServeCmd = &cobra.Command{
+	Use:   "serve [path...]",
+	Short: "Serve HTML documentation and rebuild it on changes",
+	Long: `Serve the documentation site from gray doc --html over HTTP, rebuilding
+it whenever a .gray file under the given paths changes. Open pages reload
+automatically after each rebuild; if a rebuild fails, the error is shown
+in the browser until the next successful one.
+
+Paths take the same forms as gray doc and default to ./... (the current
+directory, recursively).
+
+Examples:
+  gray doc serve                    Serve docs for the current project
+  gray doc serve ./src/...          Serve docs for src only
+  gray doc serve --addr :8080       Listen on all interfaces, port 8080`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			args = []string{"./..."}
+		}
+		addr, _ := cmd.Flags().GetString("addr")
+		if err := serveDocs(args, addr); err != nil {
+			fmt.Fprintf(os.Stderr, "gray doc serve: %v\n", err)
+			return &ExitError{1}
+		}
+		return nil
+	},
+}
+
+// docServer holds the most recent build of the site and the browsers
+// waiting to hear about the next one.
+type docServer struct {
+	args []string
+
+	mu    sync.Mutex
+	files map[string][]byte
+	err   error
+	// clients are the open event streams; each is sent a value after
+	// every rebuild.
+	clients map[chan struct{}]bool
+}
+
+func newDocServer(args []string) *docServer {
+	return &docServer{args: args, clients: map[chan struct{}]bool{}}
+}
+
+// rebuild regenerates the site, keeping the previous pages if it fails,
+// and notifies connected browsers.
+func (s *docServer) rebuild() error {
+	files, err := buildDocSite(s.args)
+
+	s.mu.Lock()
+	if err == nil {
+		s.files = files
+	}
+	s.err = err
+	for ch := range s.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	s.mu.Unlock()
+	return err
+}
+
+// buildDocSite renders the site for args without writing it anywhere.
+func buildDocSite(args []string) (map[string][]byte, error) {
+	entries, imports, err := collectDocs(args)
+	if err != nil {
+		return nil, err
+	}
+	site, err := newDocSite(entries, imports)
+	if err != nil {
+		return nil, err
+	}
+	return site.render()
+}
+
+func (s *docServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == docReloadPath {
+		s.serveEvents(w, r)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+	if name == "" {
+		name = "index.html"
+	}
+
+	s.mu.Lock()
+	content, ok := s.files[name]
+	buildErr := s.err
+	s.mu.Unlock()
+
+	isHTML := strings.HasSuffix(name, ".html")
+	if isHTML && buildErr != nil {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n"+
+			"<title>gray doc: build failed</title>\n</head>\n<body>\n<h1>gray doc: build failed</h1>\n<pre>%s</pre>\n%s</body>\n</html>\n",
+			html.EscapeString(buildErr.Error()), docReloadScript)
+		return
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", mime.TypeByExtension(path.Ext(name)))
+	w.Header().Set("Cache-Control", "no-cache")
+	if isHTML {
+		content = injectReloadScript(content)
+	}
+	w.Write(content)
+}
+
+// injectReloadScript adds docReloadScript just before </body>.
+func injectReloadScript(page []byte) []byte {
+	s := string(page)
+	i := strings.LastIndex(s, "</body>")
+	if i < 0 {
+		return []byte(s + docReloadScript)
+	}
+	return []byte(s[:i] + docReloadScript + s[i:])
+}
+
+// serveEvents holds an event stream open, sending a "reload" message after
+// each rebuild until the browser goes away.
+func (s *docServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.clients[ch] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, ch)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// docWatchDirs returns the directories to watch for args: each directory
+// argument (and, for "dir/...", everything below it) and the directory of
+// each file argument. Watching directories rather than files picks up new
+// files and editors that save by renaming.
+func docWatchDirs(args []string) []string {
+	seen := map[string]bool{}
+	var dirs []string
+	add := func(dir string) {
+		if abs, err := filepath.Abs(dir); err == nil && !seen[abs] {
+			seen[abs] = true
+			dirs = append(dirs, abs)
+		}
+	}
+	for _, arg := range args {
+		switch {
+		case strings.HasSuffix(arg, "/..."):
+			base := strings.TrimSuffix(arg, "/...")
+			if base == "" {
+				base = "."
+			}
+			filepath.Walk(base, func(p string, info os.FileInfo, err error) error {
+				if err != nil || !info.IsDir() {
+					return nil
+				}
+				if p != base && strings.HasPrefix(info.Name(), ".") {
+					return filepath.SkipDir
+				}
+				add(p)
+				return nil
+			})
+		case strings.HasSuffix(arg, ".gray"):
+			add(filepath.Dir(arg))
+		default:
+			add(arg)
+		}
+	}
+	return dirs
+}
+
+// serveDocs is the entry point for gray doc serve.
+func serveDocs(args []string, addr string) error {
+	srv := newDocServer(args)
+	if err := srv.rebuild(); err != nil {
+		fmt.Fprintf(os.Stderr, "gray doc serve: %v\n", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating watcher: %v", err)
+	}
+	defer watcher.Close()
+	dirs := docWatchDirs(args)
+	for _, dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			fmt.Printf("Error watching %s: %v\n", shortPath(dir), err)
+		}
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Serving docs for %s at http://%s/\n", strings.Join(args, " "), docServeHost(ln.Addr(), addr))
+	fmt.Println("Press Ctrl+C to stop")
+
+	// Deleting or renaming a file removes its entries from the docs.
+	go watchLoop(watcher, watchOps|fsnotify.Remove|fsnotify.Rename,
+		func(e fsnotify.Event) bool { return strings.HasSuffix(e.Name, ".gray") },
+		func() []string { return docWatchDirs(args) },
+		func() {
+			timestamp := time.Now().Format("15:04:05")
+			if err := srv.rebuild(); err != nil {
+				fmt.Fprintf(os.Stderr, "[%s] gray doc serve: %v\n", timestamp, err)
+				return
+			}
+			fmt.Printf("[%s] Rebuilt docs\n", timestamp)
+		})
+
+	return http.Serve(ln, srv)
+}
+
+// docServeHost is the host:port to print for the listener, keeping the
+// name the user asked for (such as localhost) and filling in the port.
+func docServeHost(bound net.Addr, requested string) string {
+	host, _, err := net.SplitHostPort(requested)
+	_, port, perr := net.SplitHostPort(bound.String())
+	if err != nil || perr != nil {
+		return bound.String()
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
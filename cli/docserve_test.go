@@ -0,0 +1,147 @@
+// docserve_test.go — Tests for gray doc serve: serving the in-memory site
+// with the reload script, reporting failed rebuilds in the browser, the
+// reload event stream, and choosing directories to watch.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func httpGet(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestDocServerPages(t *testing.T) {
+	dir := t.TempDir()
+	writeGraySource(t, dir)
+	srv := newDocServer([]string{filepath.Join(dir, "main.gray")})
+	if err := srv.rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	code, body := httpGet(t, ts.URL+"/")
+	if code != http.StatusOK || !strings.Contains(body, docReloadScript+"</body>") {
+		t.Errorf("GET / = %d:\n%s", code, body)
+	}
+	if code, body := httpGet(t, ts.URL+"/search-index.js"); code != http.StatusOK || !strings.Contains(body, `"n":"greet"`) {
+		t.Errorf("GET /search-index.js = %d: %s", code, body)
+	}
+	if code, _ := httpGet(t, ts.URL+"/missing.html"); code != http.StatusNotFound {
+		t.Errorf("GET /missing.html = %d, want 404", code)
+	}
+
+	// A failed rebuild replaces pages with the error until the next
+	// successful one.
+	prev := dumpDecls
+	dumpDecls = func(string) ([]byte, error) { return nil, errors.New("main.gray:1:1: <bad> syntax") }
+	if err := srv.rebuild(); err == nil {
+		t.Fatal("rebuild succeeded with a failing dump")
+	}
+	code, body = httpGet(t, ts.URL+"/index.html")
+	if code != http.StatusInternalServerError || !strings.Contains(body, "&lt;bad&gt; syntax") ||
+		!strings.Contains(body, docReloadScript) {
+		t.Errorf("GET /index.html after failed build = %d:\n%s", code, body)
+	}
+	dumpDecls = prev
+	if err := srv.rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	if code, _ := httpGet(t, ts.URL+"/index.html"); code != http.StatusOK {
+		t.Errorf("GET /index.html after recovery = %d", code)
+	}
+}
+
+func TestDocServerReloadEvents(t *testing.T) {
+	dir := t.TempDir()
+	writeGraySource(t, dir)
+	srv := newDocServer([]string{filepath.Join(dir, "main.gray")})
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + docReloadPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	// The client is registered once the headers have arrived.
+	if err := srv.rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	lines := make(chan string)
+	go func() {
+		line, _ := bufio.NewReader(resp.Body).ReadString('\n')
+		lines <- line
+	}()
+	select {
+	case line := <-lines:
+		if line != "data: reload\n" {
+			t.Errorf("event = %q", line)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload event after rebuild")
+	}
+}
+
+func TestDocWatchDirs(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"src/shapes", ".git/objects", "lib"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := docWatchDirs([]string{
+		filepath.Join(dir, "src") + "/...",
+		filepath.Join(dir, "lib", "util.gray"),
+		filepath.Join(dir, "lib"),
+	})
+	want := []string{
+		filepath.Join(dir, "src"),
+		filepath.Join(dir, "src", "shapes"),
+		filepath.Join(dir, "lib"),
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("docWatchDirs = %v, want %v", got, want)
+	}
+
+	runFromTempDir(t, dir, func() {
+		got := docWatchDirs([]string{"./..."})
+		for _, d := range got {
+			if strings.Contains(d, ".git") {
+				t.Errorf("hidden directory watched: %s", d)
+			}
+		}
+		if len(got) != 4 {
+			t.Errorf("docWatchDirs(./...) = %v", got)
+		}
+	})
+}
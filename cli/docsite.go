@@ -0,0 +1,557 @@
+// docsite.go — Static HTML documentation site for "gray doc --html". Writes
+// one page per source file with a sidebar, signatures whose type names
+// link to their definitions (project types and stdlib entries from the
+// embedded man data), and a client-side search index.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// defaultDocSiteDir is where --html writes when -o is not given.
+const defaultDocSiteDir = "site"
+
+// stdlibPageName is the site page holding referenced stdlib entries.
+const stdlibPageName = "stdlib.html"
+
+//go:embed docsite
+var docSiteAssets embed.FS
+
+// docPage is one generated page: the documented items of a source file.
+type docPage struct {
+	Title   string // source path relative to the working directory, without .gray
+	File    string // absolute source path
+	Name    string // page file name, e.g. "src.shapes.html"
+	Entries []DocEntry
+	Imports []grayImport
+}
+
+// docSite holds everything needed to render the pages of a site.
+type docSite struct {
+	pages  []*docPage
+	byFile map[string]*docPage
+	// types maps project struct and enum names to "page.html#Name";
+	// items does the same for every documented item, for @see.
+	types map[string]string
+	items map[string]string
+	// stdlibModules and stdlibRefs record the stdlib modules imported and
+	// the individual man entries (stdlib "module.Name" keys or builtin
+	// names) linked from signatures; they make up stdlib.html.
+	stdlibModules map[string]bool
+	stdlibRefs    map[string]bool
+}
+
+// docSearchEntry is one item of search-index.js. Keys are kept short
+// since the index is loaded on every page.
+type docSearchEntry struct {
+	Name    string `json:"n"`
+	Kind    string `json:"k"`
+	Page    string `json:"p"`
+	Anchor  string `json:"a"`
+	Summary string `json:"s"`
+}
+
+// generateDocSite is the entry point for gray doc --html.
+func generateDocSite(args []string, outDir string) error {
+	if outDir == "" {
+		outDir = defaultDocSiteDir
+	}
+	entries, imports, err := collectDocs(args)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No documented items found.")
+		return nil
+	}
+	site, err := newDocSite(entries, imports)
+	if err != nil {
+		return err
+	}
+	files, err := site.render()
+	if err != nil {
+		return err
+	}
+
+	warnOutsideCwd(outDir)
+	for name, content := range files {
+		path := filepath.Join(outDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("creating output directory %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %v", path, err)
+		}
+	}
+
+	fmt.Printf("Generated %s with %d page(s) and %d documented item(s)\n", outDir, len(site.pages), len(entries))
+	return nil
+}
+
+// newDocSite splits entries into per-file pages, with the imports of
+// their files, and indexes their types.
+func newDocSite(entries []DocEntry, imports docImports) (*docSite, error) {
+	site := &docSite{
+		byFile:        map[string]*docPage{},
+		types:         map[string]string{},
+		items:         map[string]string{},
+		stdlibModules: map[string]bool{},
+		stdlibRefs:    map[string]bool{},
+	}
+	for _, e := range entries {
+		abs, err := filepath.Abs(e.File)
+		if err != nil {
+			return nil, err
+		}
+		page := site.byFile[abs]
+		if page == nil {
+			page = &docPage{Title: docPageTitle(abs), File: abs, Imports: imports[abs]}
+			site.byFile[abs] = page
+			site.pages = append(site.pages, page)
+		}
+		page.Entries = append(page.Entries, e)
+	}
+	sort.Slice(site.pages, func(i, j int) bool { return site.pages[i].Title < site.pages[j].Title })
+
+	used := map[string]bool{"index.html": true, stdlibPageName: true}
+	for _, page := range site.pages {
+		page.Name = uniquePageName(page.Title, used)
+		for _, imp := range page.Imports {
+			if _, ok := stdlibModuleGroups[imp.Path]; ok && imp.Stdlib {
+				site.stdlibModules[imp.Path] = true
+			}
+		}
+		// The first definition wins when files share a name.
+		for _, e := range page.Entries {
+			if _, ok := site.items[e.Name]; !ok {
+				site.items[e.Name] = page.Name + "#" + e.Name
+			}
+			for _, m := range e.Members {
+				if _, ok := site.items[m.Name]; !ok {
+					site.items[m.Name] = page.Name + "#" + m.Name
+				}
+			}
+			if e.Kind != "struct" && e.Kind != "enum" {
+				continue
+			}
+			if _, ok := site.types[e.Name]; !ok {
+				site.types[e.Name] = page.Name + "#" + e.Name
+			}
+		}
+	}
+	return site, nil
+}
+
+// docPageTitle names a page after its source path relative to the working
+// directory, falling back to the file name for files outside it.
+func docPageTitle(abs string) string {
+	title := filepath.Base(abs)
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			title = rel
+		}
+	}
+	return strings.TrimSuffix(filepath.ToSlash(title), ".gray")
+}
+
+// uniquePageName turns a title like "src/shapes" into "src.shapes.html",
+// avoiding names already in used.
+func uniquePageName(title string, used map[string]bool) string {
+	base := strings.ReplaceAll(title, "/", ".")
+	name := base + ".html"
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s-%d.html", base, i)
+	}
+	used[name] = true
+	return name
+}
+
+// render returns the site's files keyed by path relative to the output
+// directory.
+func (s *docSite) render() (map[string][]byte, error) {
+	// Link every signature and @see once up front so stdlibRefs is
+	// complete before the first sidebar is written.
+	for _, page := range s.pages {
+		for _, e := range page.Entries {
+			for _, item := range append([]DocEntry{e}, e.Members...) {
+				s.linkSignature(item.Signature, page)
+				for _, target := range item.See {
+					s.seeLink(target, page)
+				}
+			}
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, page := range s.pages {
+		files[page.Name] = []byte(s.renderPage(page))
+	}
+	if len(s.stdlibModules) > 0 || len(s.stdlibRefs) > 0 {
+		files[stdlibPageName] = []byte(s.renderStdlibPage())
+	}
+	files["index.html"] = []byte(s.renderIndex())
+
+	index, err := json.Marshal(s.searchIndex())
+	if err != nil {
+		return nil, err
+	}
+	files["search-index.js"] = []byte("window.grayDocIndex = " + string(index) + ";\n")
+
+	for _, asset := range []string{"style.css", "search.js"} {
+		data, err := docSiteAssets.ReadFile("docsite/" + asset)
+		if err != nil {
+			return nil, err
+		}
+		files[asset] = data
+	}
+	return files, nil
+}
+
+func (s *docSite) searchIndex() []docSearchEntry {
+	var out []docSearchEntry
+	for _, page := range s.pages {
+		for _, e := range page.Entries {
+			out = append(out, docSearchEntry{e.Name, e.Kind, page.Name, e.Name, docSummary(e.Description)})
+			for _, m := range e.Members {
+				out = append(out, docSearchEntry{m.Name, "function", page.Name, m.Name, docSummary(m.Description)})
+			}
+		}
+	}
+	return out
+}
+
+// docSummary is the first line of a description.
+func docSummary(desc string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(desc), "\n")
+	return line
+}
+
+// writeHTMLPage wraps body in the shared page layout. current is the page
+// being rendered (nil for the index and stdlib pages).
+func (s *docSite) writeHTMLPage(b *strings.Builder, title string, current *docPage, body string) {
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	fmt.Fprintf(b, "<title>%s — Documentation</title>\n", html.EscapeString(title))
+	b.WriteString("<link rel=\"stylesheet\" href=\"style.css\">\n</head>\n<body>\n")
+
+	b.WriteString("<nav class=\"sidebar\">\n<a class=\"site-title\" href=\"index.html\">Documentation</a>\n")
+	b.WriteString("<input id=\"search\" type=\"search\" placeholder=\"Search\" autocomplete=\"off\">\n")
+	b.WriteString("<ul id=\"search-results\"></ul>\n<h2>Files</h2>\n<ul>\n")
+	for _, page := range s.pages {
+		if page != current {
+			fmt.Fprintf(b, "<li><a href=\"%s\">%s</a></li>\n", page.Name, html.EscapeString(page.Title))
+			continue
+		}
+		fmt.Fprintf(b, "<li class=\"current\"><a href=\"%s\">%s</a>\n<ul class=\"items\">\n", page.Name, html.EscapeString(page.Title))
+		for _, sec := range docSections(page.Entries) {
+			for _, e := range sec.Entries {
+				fmt.Fprintf(b, "<li><a href=\"#%s\">%s</a></li>\n", html.EscapeString(e.Name), html.EscapeString(e.Name))
+			}
+		}
+		b.WriteString("</ul>\n</li>\n")
+	}
+	b.WriteString("</ul>\n")
+	if len(s.stdlibModules) > 0 || len(s.stdlibRefs) > 0 {
+		fmt.Fprintf(b, "<h2>Standard library</h2>\n<ul>\n<li><a href=\"%s\">Referenced entries</a></li>\n</ul>\n", stdlibPageName)
+	}
+	b.WriteString("</nav>\n<main>\n")
+	b.WriteString(body)
+	b.WriteString("</main>\n<script src=\"search-index.js\"></script>\n<script src=\"search.js\"></script>\n</body>\n</html>\n")
+}
+
+func (s *docSite) renderIndex() string {
+	var body strings.Builder
+	body.WriteString("<h1>Documentation</h1>\n<p><em>Generated by <code>gray doc</code></em></p>\n")
+	if len(s.pages) == 0 {
+		body.WriteString("<p>No documented items found.</p>\n")
+	}
+	body.WriteString("<ul>\n")
+	for _, page := range s.pages {
+		count := 0
+		for _, e := range page.Entries {
+			count += 1 + len(e.Members)
+		}
+		fmt.Fprintf(&body, "<li><a href=\"%s\">%s</a> <span class=\"kind\">%d item(s)</span></li>\n",
+			page.Name, html.EscapeString(page.Title), count)
+	}
+	body.WriteString("</ul>\n")
+	var b strings.Builder
+	s.writeHTMLPage(&b, "Index", nil, body.String())
+	return b.String()
+}
+
+func (s *docSite) renderPage(page *docPage) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "<h1>%s</h1>\n", html.EscapeString(page.Title))
+	if imports := s.renderImports(page); imports != "" {
+		body.WriteString(imports)
+	}
+	for _, sec := range docSections(page.Entries) {
+		fmt.Fprintf(&body, "<h2>%s</h2>\n", sec.Title)
+		for _, e := range sec.Entries {
+			s.renderItem(&body, page, e, "h3", "item")
+			for _, m := range e.Members {
+				s.renderItem(&body, page, m, "h4", "item member")
+			}
+		}
+	}
+	var b strings.Builder
+	s.writeHTMLPage(&b, page.Title, page, body.String())
+	return b.String()
+}
+
+// renderImports lists the file's imports, linking stdlib modules to the
+// stdlib page and local files to their pages when they have one.
+func (s *docSite) renderImports(page *docPage) string {
+	var links []string
+	for _, imp := range page.Imports {
+		if imp.Stdlib {
+			if _, ok := stdlibModuleGroups[imp.Path]; ok {
+				links = append(links, fmt.Sprintf("<a href=\"%s#module-%s\">@%s</a>", stdlibPageName,
+					html.EscapeString(imp.Path), html.EscapeString(imp.Path)))
+				continue
+			}
+			links = append(links, "@"+html.EscapeString(imp.Path))
+			continue
+		}
+		target := filepath.Join(filepath.Dir(page.File), filepath.FromSlash(imp.Path))
+		if !strings.HasSuffix(target, ".gray") {
+			target += ".gray"
+		}
+		if other := s.byFile[target]; other != nil {
+			links = append(links, fmt.Sprintf("<a href=\"%s\">%s</a>", other.Name, html.EscapeString(imp.Path)))
+		} else {
+			links = append(links, html.EscapeString(imp.Path))
+		}
+	}
+	if len(links) == 0 {
+		return ""
+	}
+	return "<p class=\"imports\">Imports: " + strings.Join(links, ", ") + "</p>\n"
+}
+
+func (s *docSite) renderItem(b *strings.Builder, page *docPage, e DocEntry, heading, class string) {
+	id := html.EscapeString(e.Name)
+	kind := e.Kind
+	if kind == "struct_function" {
+		kind = "function"
+	}
+	if e.Deprecated {
+		kind += ", deprecated"
+		class += " deprecated"
+	}
+	fmt.Fprintf(b, "<section class=\"%s\" id=\"%s\">\n<%s><a href=\"#%s\">%s</a><span class=\"kind\">%s</span></%s>\n",
+		class, id, heading, id, id, kind, heading)
+	fmt.Fprintf(b, "<pre><code>%s</code></pre>\n", s.linkSignature(e.Signature, page))
+	if e.Deprecated {
+		b.WriteString("<p class=\"deprecation\"><strong>Deprecated.</strong>")
+		if e.DeprecatedNote != "" {
+			b.WriteString(" " + html.EscapeString(e.DeprecatedNote))
+		}
+		b.WriteString("</p>\n")
+	}
+	b.WriteString(renderDocText(e.Description))
+	if len(e.Params) > 0 {
+		b.WriteString("<h5>Parameters</h5>\n<dl class=\"params\">\n")
+		for _, p := range e.Params {
+			fmt.Fprintf(b, "<dt><code>%s</code></dt><dd>%s</dd>\n", html.EscapeString(p.Name), html.EscapeString(p.Text))
+		}
+		b.WriteString("</dl>\n")
+	}
+	if len(e.Returns) > 0 {
+		b.WriteString("<h5>Returns</h5>\n")
+		b.WriteString(renderDocText(strings.Join(e.Returns, "\n")))
+	}
+	for _, example := range e.Examples {
+		fmt.Fprintf(b, "<h5>Example</h5>\n<pre><code>%s</code></pre>\n", html.EscapeString(example))
+	}
+	if len(e.See) > 0 {
+		links := make([]string, len(e.See))
+		for i, target := range e.See {
+			links[i] = s.seeLink(target, page)
+		}
+		fmt.Fprintf(b, "<p class=\"see\"><strong>See also:</strong> %s</p>\n", strings.Join(links, ", "))
+	}
+	b.WriteString("</section>\n")
+}
+
+// seeLink renders a @see target: a link to a documented item, to a stdlib
+// or builtin entry (resolving import aliases), or to a URL, and plain code
+// otherwise.
+func (s *docSite) seeLink(target string, page *docPage) string {
+	text := html.EscapeString(target)
+	if isDocURL(target) {
+		return fmt.Sprintf("<a href=\"%s\">%s</a>", text, text)
+	}
+	if href, ok := s.items[target]; ok {
+		return fmt.Sprintf("<a href=\"%s\"><code>%s</code></a>", html.EscapeString(href), text)
+	}
+	key := target
+	if mod, name, ok := strings.Cut(target, "."); ok {
+		for _, imp := range page.Imports {
+			if imp.Stdlib && imp.Alias == mod {
+				key = imp.Path + "." + name
+			}
+		}
+	}
+	_, stdlib := stdlibManDocs[key]
+	_, builtin := builtinManDocs[key]
+	if stdlib || builtin {
+		s.stdlibRefs[key] = true
+		return fmt.Sprintf("<a href=\"%s#%s\"><code>%s</code></a>", stdlibPageName, html.EscapeString(key), text)
+	}
+	return "<code>" + text + "</code>"
+}
+
+// renderDocText turns a description into paragraphs: blank lines separate
+// paragraphs and single newlines become line breaks.
+func renderDocText(desc string) string {
+	var b strings.Builder
+	for _, para := range strings.Split(strings.TrimSpace(desc), "\n\n") {
+		if para = strings.TrimSpace(para); para == "" {
+			continue
+		}
+		lines := strings.Split(para, "\n")
+		for i := range lines {
+			lines[i] = html.EscapeString(lines[i])
+		}
+		b.WriteString("<p>" + strings.Join(lines, "<br>\n") + "</p>\n")
+	}
+	return b.String()
+}
+
+// linkSignature escapes sig and links the type names in it: project
+// structs and enums to their definitions, stdlib types ("mod.Type", with
+// import aliases resolved) and builtin types to the stdlib page.
+func (s *docSite) linkSignature(sig string, page *docPage) string {
+	aliases := map[string]string{}
+	for _, imp := range page.Imports {
+		if imp.Stdlib {
+			aliases[imp.Alias] = imp.Path
+		}
+	}
+
+	var b strings.Builder
+	toks := lexGraySource(sig)
+	prev := 0
+	for i := 0; i < len(toks); i++ {
+		t := toks[i]
+		if t.Kind != tokIdent {
+			continue
+		}
+		href, end := "", t.End
+		if i+2 < len(toks) && toks[i+1].isPunct(".") && toks[i+2].Kind == tokIdent &&
+			toks[i+1].Offset == t.End && toks[i+2].Offset == toks[i+1].End {
+			mod := t.Text
+			if m, ok := aliases[mod]; ok {
+				mod = m
+			}
+			key := mod + "." + toks[i+2].Text
+			if entry, ok := stdlibManDocs[key]; ok && entry.Kind == "type" {
+				href, end = stdlibPageName+"#"+key, toks[i+2].End
+				s.stdlibRefs[key] = true
+			}
+		}
+		if href == "" {
+			if target, ok := s.types[t.Text]; ok {
+				href = target
+			} else if entry, ok := builtinManDocs[t.Text]; ok && entry.Kind == "type" {
+				href = stdlibPageName + "#" + t.Text
+				s.stdlibRefs[t.Text] = true
+			} else {
+				continue
+			}
+		}
+		for i+1 < len(toks) && toks[i+1].Offset < end {
+			i++
+		}
+		b.WriteString(html.EscapeString(sig[prev:t.Offset]))
+		fmt.Fprintf(&b, "<a href=\"%s\">%s</a>", html.EscapeString(href), html.EscapeString(sig[t.Offset:end]))
+		prev = end
+	}
+	b.WriteString(html.EscapeString(sig[prev:]))
+	return b.String()
+}
+
+// renderStdlibPage documents the imported stdlib modules in full and any
+// other stdlib or builtin entries the signatures link to.
+func (s *docSite) renderStdlibPage() string {
+	byModule := map[string][]string{}
+	var builtins []string
+	for key := range s.stdlibRefs {
+		if entry, ok := stdlibManDocs[key]; ok {
+			if !s.stdlibModules[entry.Module] {
+				byModule[entry.Module] = append(byModule[entry.Module], key)
+			}
+		} else {
+			builtins = append(builtins, key)
+		}
+	}
+	for mod := range s.stdlibModules {
+		for _, g := range stdlibModuleGroups[mod] {
+			for _, name := range g.Names {
+				byModule[mod] = append(byModule[mod], mod+"."+name)
+			}
+		}
+	}
+
+	var body strings.Builder
+	body.WriteString("<h1>Standard library</h1>\n<p>Entries imported or referenced by this project. " +
+		"Run <code>gray man &lt;name&gt;</code> for the same text in a terminal.</p>\n")
+	mods := make([]string, 0, len(byModule))
+	for mod := range byModule {
+		mods = append(mods, mod)
+	}
+	sort.Strings(mods)
+	for _, mod := range mods {
+		fmt.Fprintf(&body, "<h2 id=\"module-%s\">%s</h2>\n", html.EscapeString(mod), html.EscapeString(mod))
+		for _, key := range byModule[mod] {
+			entry, ok := stdlibManDocs[key]
+			if !ok {
+				continue
+			}
+			writeManItem(&body, key, key, entry.Kind, entry.Sig, entry.Fields, entry.Desc, entry.Example)
+		}
+	}
+	if len(builtins) > 0 {
+		sort.Strings(builtins)
+		body.WriteString("<h2 id=\"module-builtin\">builtin</h2>\n")
+		for _, name := range builtins {
+			entry := builtinManDocs[name]
+			writeManItem(&body, name, name, entry.Kind, entry.Sig, entry.Fields, entry.Desc, entry.Example)
+		}
+	}
+	var b strings.Builder
+	s.writeHTMLPage(&b, "Standard library", nil, body.String())
+	return b.String()
+}
+
+// writeManItem renders one embedded man entry.
+func writeManItem(b *strings.Builder, anchor, label, kind, sig, fields, desc, example string) {
+	id := html.EscapeString(anchor)
+	fmt.Fprintf(b, "<section class=\"item\" id=\"%s\">\n<h3><a href=\"#%s\">%s</a><span class=\"kind\">%s</span></h3>\n",
+		id, id, html.EscapeString(label), html.EscapeString(kind))
+	switch {
+	case kind == "type" && fields != "":
+		fmt.Fprintf(b, "<pre><code>%s</code></pre>\n", html.EscapeString(fields))
+	case sig != "":
+		fmt.Fprintf(b, "<pre><code>%s</code></pre>\n", html.EscapeString(sig))
+	}
+	b.WriteString(renderDocText(desc))
+	if example != "" {
+		fmt.Fprintf(b, "<pre><code>%s</code></pre>\n", html.EscapeString(example))
+	}
+	b.WriteString("</section>\n")
+}
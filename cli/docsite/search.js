@@ -0,0 +1,50 @@
+// search.js — Client-side search for sites generated by `gray doc --html`.
+// Filters window.grayDocIndex (written to search-index.js) as you type.
+(function () {
+    var input = document.getElementById("search");
+    var results = document.getElementById("search-results");
+    var index = window.grayDocIndex;
+    if (!input || !results || !index) return;
+
+    function rank(entry, q) {
+        var name = entry.n.toLowerCase();
+        if (name === q) return 0;
+        if (name.indexOf(q) === 0) return 1;
+        if (name.indexOf(q) >= 0) return 2;
+        if (entry.s.toLowerCase().indexOf(q) >= 0) return 3;
+        return -1;
+    }
+
+    input.addEventListener("input", function () {
+        var q = input.value.trim().toLowerCase();
+        results.textContent = "";
+        if (!q) return;
+        var hits = [];
+        index.forEach(function (entry) {
+            var r = rank(entry, q);
+            if (r >= 0) hits.push({ entry: entry, rank: r });
+        });
+        hits.sort(function (a, b) {
+            return a.rank - b.rank || a.entry.n.localeCompare(b.entry.n);
+        });
+        hits.slice(0, 20).forEach(function (hit) {
+            var li = document.createElement("li");
+            var a = document.createElement("a");
+            a.href = hit.entry.p + "#" + hit.entry.a;
+            a.textContent = hit.entry.n;
+            if (hit.entry.s) a.title = hit.entry.s;
+            var kind = document.createElement("span");
+            kind.className = "kind";
+            kind.textContent = hit.entry.k;
+            li.appendChild(a);
+            li.appendChild(kind);
+            results.appendChild(li);
+        });
+    });
+
+    input.addEventListener("keydown", function (e) {
+        if (e.key === "Enter" && results.firstChild) {
+            window.location.href = results.firstChild.firstChild.href;
+        }
+    });
+})();
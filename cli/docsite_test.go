@@ -0,0 +1,111 @@
+// docsite_test.go — Tests for the HTML documentation site: per-file pages,
+// page naming, signature cross-links to project and stdlib types, the
+// stdlib page, and the search index.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUniquePageName(t *testing.T) {
+	used := map[string]bool{"index.html": true}
+	for _, tt := range []struct{ title, want string }{
+		{"src/shapes", "src.shapes.html"},
+		{"index", "index-2.html"},
+		{"src/shapes", "src.shapes-2.html"},
+	} {
+		if got := uniquePageName(tt.title, used); got != tt.want {
+			t.Errorf("uniquePageName(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestLinkSignature(t *testing.T) {
+	site := &docSite{
+		types:         map[string]string{"Point": "shapes.html#Point"},
+		stdlibModules: map[string]bool{},
+		stdlibRefs:    map[string]bool{},
+	}
+	page := &docPage{Imports: []grayImport{{Alias: "h", Path: "http", Stdlib: true}}}
+	got := site.linkSignature(`do fetch(p Point, r h.HttpResponse, s string = "<Point>") -> Error`, page)
+	want := `do fetch(p <a href="shapes.html#Point">Point</a>, r <a href="stdlib.html#http.HttpResponse">h.HttpResponse</a>, ` +
+		`s string = &#34;&lt;Point&gt;&#34;) -&gt; <a href="stdlib.html#Error">Error</a>`
+	if got != want {
+		t.Errorf("linkSignature:\n%s\nwant:\n%s", got, want)
+	}
+	if !site.stdlibRefs["http.HttpResponse"] || !site.stdlibRefs["Error"] {
+		t.Errorf("stdlibRefs = %v", site.stdlibRefs)
+	}
+
+	// A project type used as a qualifier is still linked.
+	got = site.linkSignature("const C Color = Point.origin", page)
+	if !strings.Contains(got, `<a href="shapes.html#Point">Point</a>.origin`) {
+		t.Errorf("qualifier not linked: %s", got)
+	}
+}
+
+func TestGenerateDocSite(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "src", "shapes.gray"), "#doc(\"A point.\")\nconst Point struct {\n    x int\n}\n")
+	writeTestFile(t, filepath.Join(dir, "main.gray"),
+		"import @http\n\n#doc(\"Fetches.\")\ndo fetch(p Point, r http.HttpResponse) {}\n")
+	dumps := map[string]string{
+		"shapes.gray": `{"decls":[{"kind":"struct","name":"Point","line":2,"doc":"A point.",` +
+			`"fields":[{"name":"x","type":"int"}],"functions":[]}]}`,
+		"main.gray": `{"imports":[{"alias":"http","path":"http","stdlib":true,"line":1}],` +
+			`"decls":[{"kind":"function","name":"fetch","line":4,"doc":"Fetches.","params":[` +
+			`{"name":"p","type":"Point","type_span":{"line":4,"column":12,"end_line":4,"end_column":12,"start":42,"end":47}},` +
+			`{"name":"r","type":"http_HttpResponse","type_span":{"line":4,"column":21,"end_line":4,"end_column":26,"start":51,"end":68}}],"returns":[]}]}`,
+	}
+	prev := dumpDecls
+	dumpDecls = func(file string) ([]byte, error) { return []byte(dumps[filepath.Base(file)]), nil }
+	t.Cleanup(func() { dumpDecls = prev })
+
+	runFromTempDir(t, dir, func() {
+		if err := generateDocSite([]string{".", "./src"}, ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	read := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, "site", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	for _, name := range []string{"index.html", "style.css", "search.js"} {
+		read(name)
+	}
+	mainPage := read("main.html")
+	for _, want := range []string{
+		`<a href="src.shapes.html#Point">Point</a>`,
+		`<a href="stdlib.html#http.HttpResponse">http.HttpResponse</a>`,
+		`<a href="stdlib.html#module-http">@http</a>`,
+		`<li><a href="src.shapes.html">src/shapes</a></li>`,
+	} {
+		if !strings.Contains(mainPage, want) {
+			t.Errorf("main.html missing %q:\n%s", want, mainPage)
+		}
+	}
+	if !strings.Contains(read("src.shapes.html"), `<section class="item" id="Point">`) {
+		t.Error("src.shapes.html does not define Point")
+	}
+	stdlib := read("stdlib.html")
+	if !strings.Contains(stdlib, `id="module-http"`) || !strings.Contains(stdlib, `id="http.HttpResponse"`) {
+		t.Errorf("stdlib.html missing http entries:\n%s", stdlib)
+	}
+	index := read("search-index.js")
+	if !strings.Contains(index, `{"n":"fetch","k":"function","p":"main.html","a":"fetch","s":"Fetches."}`) {
+		t.Errorf("search index missing fetch: %s", index)
+	}
+}
@@ -0,0 +1,198 @@
+// doctags.go — Structured #doc text for "gray doc". Splits a doc string
+// into its description and @param, @return, @example, @see, and
+// @deprecated tags, removes the common indentation of multi-line raw
+// strings, and checks @param and @return against the declaration.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DocParam is one @param tag.
+type DocParam struct {
+	Name string `json:"name"`
+	Text string `json:"text"`
+}
+
+// docComment is a parsed #doc string. Problems found while parsing
+// (unknown tags, malformed @param lines) are collected in Warnings.
+type docComment struct {
+	Description    string
+	Params         []DocParam
+	Returns        []string
+	Examples       []string
+	See            []string
+	Deprecated     bool
+	DeprecatedNote string
+	Warnings       []string
+}
+
+// dedentDoc strips the leading and trailing blank lines of a doc string
+// and the indentation shared by its lines, so a raw string indented to
+// match the code reads the same as a plain one. A first line written
+// straight after the opening backtick does not count towards the shared
+// indentation.
+func dedentDoc(doc string) string {
+	lines := strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n")
+	first := strings.TrimSpace(lines[0])
+	lines = lines[1:]
+
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " \t")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = strings.TrimRight(line[indent:], " \t")
+		}
+	}
+	if first != "" {
+		lines = append([]string{first}, lines...)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// docTags are the tags parseDocComment recognises.
+var docTags = map[string]bool{"param": true, "return": true, "example": true, "see": true, "deprecated": true}
+
+// parseDocComment splits doc into a description and tags. A tag starts a
+// line that begins with "@" after dedenting; everything up to the next tag
+// belongs to it. Lines starting with an unknown "@word" end the current
+// tag and stay in the description. @example keeps its lines as written
+// (less their shared indentation); the other tags join theirs into one
+// line of text.
+func parseDocComment(doc string) docComment {
+	var c docComment
+	var desc []string
+	tag, arg := "", ""
+	var body []string
+
+	flush := func() {
+		switch tag {
+		case "":
+			return
+		case "example":
+			if example := dedentDoc(strings.Join(append([]string{arg}, body...), "\n")); example != "" {
+				c.Examples = append(c.Examples, example)
+			} else {
+				c.Warnings = append(c.Warnings, "@example has no code")
+			}
+		default:
+			text := strings.Join(strings.Fields(strings.Join(append([]string{arg}, body...), " ")), " ")
+			switch tag {
+			case "param":
+				name, rest, _ := strings.Cut(text, " ")
+				if name == "" {
+					c.Warnings = append(c.Warnings, "@param is missing a parameter name")
+				} else {
+					c.Params = append(c.Params, DocParam{Name: name, Text: rest})
+				}
+			case "return":
+				c.Returns = append(c.Returns, text)
+			case "see":
+				if text == "" {
+					c.Warnings = append(c.Warnings, "@see is missing a target")
+				} else {
+					c.See = append(c.See, text)
+				}
+			case "deprecated":
+				c.Deprecated = true
+				c.DeprecatedNote = text
+			}
+		}
+		tag, arg, body = "", "", nil
+	}
+
+	for _, line := range strings.Split(dedentDoc(doc), "\n") {
+		if strings.HasPrefix(line, "@") {
+			name, rest, _ := strings.Cut(line[1:], " ")
+			if docTags[name] {
+				flush()
+				tag, arg = name, strings.TrimSpace(rest)
+				continue
+			}
+			// An unknown tag is kept as description text rather than
+			// run into the tag before it.
+			flush()
+			c.Warnings = append(c.Warnings, fmt.Sprintf("unknown tag @%s", name))
+		}
+		if tag != "" {
+			body = append(body, line)
+		} else {
+			desc = append(desc, line)
+		}
+	}
+	flush()
+	c.Description = strings.TrimSpace(strings.Join(desc, "\n"))
+	return c
+}
+
+// applyDocComment parses e.Description into e's tag fields and returns
+// warnings for tags that do not match decl: @param naming no parameter or
+// repeated, parameters left out once any are documented, @return on a
+// function without results, and @param/@return outside functions. Tags
+// that draw a warning are left out of e, so the rendered docs never
+// describe a parameter or result the declaration does not have.
+func applyDocComment(e *DocEntry, decl dumpedDecl) []string {
+	c := parseDocComment(e.Description)
+	e.Description = c.Description
+	e.Params, e.Returns = nil, nil
+	e.Examples = c.Examples
+	e.See = c.See
+	e.Deprecated = c.Deprecated
+	e.DeprecatedNote = c.DeprecatedNote
+
+	warnings := c.Warnings
+	if decl.Kind != "function" {
+		if len(c.Params) > 0 {
+			warnings = append(warnings, "@param only applies to functions")
+		}
+		if len(c.Returns) > 0 {
+			warnings = append(warnings, "@return only applies to functions")
+		}
+		return warnings
+	}
+
+	documented := map[string]bool{}
+	for _, p := range c.Params {
+		known := false
+		for _, dp := range decl.Params {
+			known = known || dp.Name == p.Name
+		}
+		switch {
+		case !known:
+			warnings = append(warnings, fmt.Sprintf("@param %s does not match any parameter", p.Name))
+		case documented[p.Name]:
+			warnings = append(warnings, fmt.Sprintf("@param %s is documented more than once", p.Name))
+		default:
+			e.Params = append(e.Params, p)
+		}
+		documented[p.Name] = true
+	}
+	if len(c.Params) > 0 {
+		for _, dp := range decl.Params {
+			if !documented[dp.Name] {
+				warnings = append(warnings, fmt.Sprintf("parameter %s has no @param", dp.Name))
+			}
+		}
+	}
+	if len(c.Returns) > 0 && len(decl.Returns) == 0 {
+		warnings = append(warnings, "@return on a function that returns nothing")
+	} else {
+		e.Returns = c.Returns
+	}
+	return warnings
+}
@@ -0,0 +1,164 @@
+// doctags_test.go — Tests for #doc tags: dedenting raw-string docs,
+// splitting out @param/@return/@example/@see/@deprecated, checking tags
+// against the declaration, and rendering them.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDedentDoc(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Adds two numbers.", "Adds two numbers."},
+		{"\n    Adds.\n\n    More:\n        indented\n  ", "Adds.\n\nMore:\n    indented"},
+		{"Adds.\n    more", "Adds.\nmore"},
+		{"Line one\nLine two", "Line one\nLine two"},
+		{"\n\n", ""},
+	}
+	for _, tt := range tests {
+		if got := dedentDoc(tt.in); got != tt.want {
+			t.Errorf("dedentDoc(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+const docTagsSource = `
+    Adds two numbers.
+
+    Negative numbers work too.
+
+    @param a the first
+      operand
+    @param b
+    @return the sum
+    @example
+        using std
+        println(add(1, 2))
+    @see sub
+    @see https://example.com
+    @deprecated use plus
+    @todo later
+`
+
+func TestParseDocComment(t *testing.T) {
+	got := parseDocComment(docTagsSource)
+	want := docComment{
+		Description:    "Adds two numbers.\n\nNegative numbers work too.\n\n@todo later",
+		Params:         []DocParam{{"a", "the first operand"}, {"b", ""}},
+		Returns:        []string{"the sum"},
+		Examples:       []string{"using std\nprintln(add(1, 2))"},
+		See:            []string{"sub", "https://example.com"},
+		Deprecated:     true,
+		DeprecatedNote: "use plus",
+		Warnings:       []string{"unknown tag @todo"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDocComment:\n%#v\nwant:\n%#v", got, want)
+	}
+
+	// Stacked #doc strings arrive joined by newlines.
+	got = parseDocComment("Subtracts.\n@deprecated")
+	if got.Description != "Subtracts." || !got.Deprecated || got.DeprecatedNote != "" {
+		t.Errorf("bare @deprecated: %#v", got)
+	}
+	got = parseDocComment("@param\n@see\n@example")
+	if len(got.Warnings) != 3 || got.Params != nil || got.See != nil || got.Examples != nil {
+		t.Errorf("empty tags: %#v", got)
+	}
+}
+
+func TestApplyDocCommentWarnings(t *testing.T) {
+	fn := dumpedDecl{Kind: "function", Params: []dumpedParam{{Name: "a"}, {Name: "b"}}}
+	tests := []struct {
+		doc  string
+		decl dumpedDecl
+		want []string
+	}{
+		{"Adds.", fn, nil},
+		{"@param a x\n@param b y\n@return z", dumpedDecl{Kind: "function", Params: fn.Params, Returns: []dumpedReturn{{Type: "int"}}}, nil},
+		{"@param a x\n@param c y\n@param a again", fn, []string{
+			"@param c does not match any parameter",
+			"@param a is documented more than once",
+			"parameter b has no @param",
+		}},
+		{"@return z", fn, []string{"@return on a function that returns nothing"}},
+		{"@param a\n@return z", dumpedDecl{Kind: "struct"}, []string{
+			"@param only applies to functions",
+			"@return only applies to functions",
+		}},
+	}
+	for _, tt := range tests {
+		e := DocEntry{Description: tt.doc}
+		if got := applyDocComment(&e, tt.decl); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("applyDocComment(%q) = %q, want %q", tt.doc, got, tt.want)
+		}
+	}
+}
+
+func TestApplyDocCommentDropsMismatchedTags(t *testing.T) {
+	fn := dumpedDecl{Kind: "function", Params: []dumpedParam{{Name: "a"}, {Name: "b"}}}
+	e := DocEntry{Description: "@param a x\n@param c y\n@param a again\n@param b z\n@return r"}
+	applyDocComment(&e, fn)
+	want := []DocParam{{Name: "a", Text: "x"}, {Name: "b", Text: "z"}}
+	if !reflect.DeepEqual(e.Params, want) || e.Returns != nil {
+		t.Errorf("params = %v, returns = %v; want %v and none", e.Params, e.Returns, want)
+	}
+
+	e = DocEntry{Description: "@param a x\n@return r"}
+	applyDocComment(&e, dumpedDecl{Kind: "struct"})
+	if e.Params != nil || e.Returns != nil {
+		t.Errorf("struct kept params %v, returns %v", e.Params, e.Returns)
+	}
+
+	e = DocEntry{Name: "f", Kind: "function", Signature: "do f(a int)", Description: "@param a x\n@param nope y"}
+	applyDocComment(&e, dumpedDecl{Kind: "function", Params: []dumpedParam{{Name: "a"}}})
+	if md := generateMarkdown([]DocEntry{e}, "."); strings.Contains(md, "nope") {
+		t.Errorf("unknown @param rendered:\n%s", md)
+	}
+}
+
+func TestDocTagsRendering(t *testing.T) {
+	e := DocEntry{Name: "add", Kind: "function", Signature: "do add(a, b int) -> int", Description: docTagsSource}
+	applyDocComment(&e, dumpedDecl{Kind: "function", Params: []dumpedParam{{Name: "a"}, {Name: "b"}}, Returns: []dumpedReturn{{Type: "int"}}})
+	sub := DocEntry{Name: "sub", Kind: "function", Signature: "do sub()"}
+
+	md := generateMarkdown([]DocEntry{e, sub}, ".")
+	for _, want := range []string{
+		"> **Deprecated:** use plus\n\nAdds two numbers.",
+		"**Parameters**\n\n- `a` — the first operand\n- `b`\n\n",
+		"**Returns** the sum\n\n",
+		"**Example**\n\n```gray\nusing std\nprintln(add(1, 2))\n```\n\n",
+		"**See also:** [sub](#sub), <https://example.com>\n\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	site := &docSite{
+		items:         map[string]string{"sub": "lib.html#sub"},
+		types:         map[string]string{},
+		stdlibModules: map[string]bool{},
+		stdlibRefs:    map[string]bool{},
+	}
+	var b strings.Builder
+	site.renderItem(&b, &docPage{}, e, "h3", "item")
+	for _, want := range []string{
+		`<section class="item deprecated" id="add">`,
+		`<p class="deprecation"><strong>Deprecated.</strong> use plus</p>`,
+		"<dt><code>a</code></dt><dd>the first operand</dd>",
+		"<h5>Returns</h5>\n<p>the sum</p>",
+		`<a href="lib.html#sub"><code>sub</code></a>, <a href="https://example.com">https://example.com</a>`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("HTML missing %q:\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// doctest.go — Runs the @example blocks of #doc attributes as tests
+// ("gray doc --test"). Each example is compiled against the file it
+// documents; examples ending in an "// Output:" comment are also run and
+// their stdout compared, as with Go example tests.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+// docTestCase is one @example to test.
+type docTestCase struct {
+	File    string // absolute path of the documented source file
+	Path    string // File as shown to the user
+	Name    string // documented item
+	Index   int    // 1-based position among the item's examples
+	Code    string // example without its output comment
+	Want    string // expected stdout when HasWant is set
+	HasWant bool
+	Line    int // source line of the example's first code line
+}
+
+func (tc docTestCase) label() string {
+	return fmt.Sprintf("%s:%d %s (example %d)", tc.Path, tc.Line, tc.Name, tc.Index)
+}
+
+// splitExampleOutput separates an example from a trailing "// Output:"
+// comment. The expected output is the text after "Output:" and the
+// comment lines that follow it, with their "//" markers removed.
+func splitExampleOutput(example string) (code, want string, hasWant bool) {
+	lines := strings.Split(example, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "//") {
+			return example, "", false
+		}
+		rest, ok := strings.CutPrefix(strings.TrimSpace(strings.TrimPrefix(line, "//")), "Output:")
+		if !ok {
+			continue
+		}
+		var out []string
+		if first := strings.TrimSpace(rest); first != "" {
+			out = append(out, first)
+		}
+		for _, l := range lines[i+1:] {
+			l = strings.TrimPrefix(strings.TrimSpace(l), "//")
+			out = append(out, strings.TrimPrefix(l, " "))
+		}
+		return strings.TrimRight(strings.Join(lines[:i], "\n"), "\n"), strings.Join(out, "\n"), true
+	}
+	return example, "", false
+}
+
+// normalizeDocOutput trims trailing space from each line and blank lines
+// from the end, so expected output can be written without worrying about
+// either.
+func normalizeDocOutput(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// locateExample finds the source line where example's code starts by
+// matching its lines, ignoring indentation, above the declaration at
+// declLine. It returns 0 when the example cannot be found (for instance
+// when it was written with escapes in a quoted string).
+func locateExample(srcLines []string, example string, declLine int) int {
+	ex := strings.Split(example, "\n")
+	for start := min(declLine-1, len(srcLines)) - len(ex); start >= 0; start-- {
+		match := true
+		for j, l := range ex {
+			if strings.TrimSpace(srcLines[start+j]) != strings.TrimSpace(l) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return start + 1
+		}
+	}
+	return 0
+}
+
+// collectDocTests gathers the @example blocks of the items documented in
+// args.
+func collectDocTests(args []string) ([]docTestCase, error) {
+	entries, _, err := collectDocs(args)
+	if err != nil {
+		return nil, err
+	}
+	sources := map[string][]string{}
+	var cases []docTestCase
+	for _, e := range entries {
+		for _, item := range append([]DocEntry{e}, e.Members...) {
+			if len(item.Examples) == 0 {
+				continue
+			}
+			abs, err := filepath.Abs(item.File)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := sources[abs]; !ok {
+				src, err := os.ReadFile(abs)
+				if err != nil {
+					return nil, err
+				}
+				sources[abs] = strings.Split(string(src), "\n")
+			}
+			for i, example := range item.Examples {
+				code, want, hasWant := splitExampleOutput(example)
+				line := locateExample(sources[abs], example, item.Line)
+				if line == 0 {
+					line = item.Line
+				}
+				cases = append(cases, docTestCase{
+					File: abs, Path: relPath(abs), Name: item.Name, Index: i + 1,
+					Code: code, Want: want, HasWant: hasWant, Line: line,
+				})
+			}
+		}
+	}
+	return cases, nil
+}
+
+// docTestProgram builds the program for tc, to be written in dir: the
+// documented file imported by its path from dir and opened with using, so
+// the example can name its items with or without the module prefix,
+// followed by the wrapped example. The documented file's own imports
+// resolve from its directory, as they do in the project. The line map
+// follows wrapSnippet's.
+func docTestProgram(tc docTestCase, dir string) (string, []int, error) {
+	rel, err := filepath.Rel(dir, tc.File)
+	if err != nil {
+		return "", nil, err
+	}
+	rel = strings.TrimSuffix(filepath.ToSlash(rel), ".gray")
+	module := filepath.Base(rel)
+	src, lineMap := wrapSnippet(tc.Code)
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	header := fmt.Sprintf("import \"%s\"\nusing %s\n", rel, module)
+	return header + src, append([]int{-1, -1}, lineMap...), nil
+}
+
+// testDocExample compiles, and when it has expected output runs, one
+// example.
+func testDocExample(tc docTestCase) mdTestResult {
+	// The program gets a directory of its own, so an interrupted run
+	// leaves nothing in the project.
+	dir, err := os.MkdirTemp("", "gray-doctest-")
+	if err != nil {
+		return mdTestResult{Status: mdTestFail, Message: err.Error()}
+	}
+	defer os.RemoveAll(dir)
+	// ".." in the import is resolved on disk, so it is counted from the
+	// temp directory's real path (/tmp may be a symlink).
+	if real, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = real
+	}
+	src, lineMap, err := docTestProgram(tc, dir)
+	if err != nil {
+		return mdTestResult{Status: mdTestFail, Message: err.Error()}
+	}
+	file := filepath.Join(dir, "doctest.gray")
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		return mdTestResult{Status: mdTestFail, Message: err.Error()}
+	}
+	remap := func(out []byte) string {
+		s := remapSnippetOutput(string(out), file, tc.Path, mdCodeBlock{Line: tc.Line - 1}, lineMap)
+		return unwindImportPaths(s, dir)
+	}
+
+	if tc.HasWant {
+		stdout, stderr, code, err := grayc.RunOutput(file, []string{"--no-color", "--quiet"})
+		switch {
+		case err != nil:
+			return mdTestResult{Status: mdTestFail, Message: err.Error()}
+		case code != 0:
+			return mdTestResult{Status: mdTestFail, Message: fmt.Sprintf("exited with status %d", code), Output: remap(append(stderr, stdout...))}
+		}
+		if got, want := normalizeDocOutput(string(stdout)), normalizeDocOutput(tc.Want); got != want {
+			return mdTestResult{Status: mdTestFail, Message: "output mismatch",
+				Output: "got:\n" + indentLines(got) + "\nwant:\n" + indentLines(want)}
+		}
+		return mdTestResult{Status: mdTestPass}
+	}
+
+	out, code, err := grayc.CheckOutput(file, []string{"--no-color", "--quiet"})
+	if err != nil {
+		return mdTestResult{Status: mdTestFail, Message: err.Error()}
+	}
+	if code != 0 {
+		return mdTestResult{Status: mdTestFail, Message: "does not compile", Output: remap(out)}
+	}
+	return mdTestResult{Status: mdTestPass}
+}
+
+// unwindImportPaths rewrites the paths grayc prints for files the program
+// in dir reaches through "..", such as dir/../proj/lib.gray for the
+// documented file, as paths from the working directory. Their line
+// numbers are the files' own and are kept.
+func unwindImportPaths(out, dir string) string {
+	re := regexp.MustCompile(regexp.QuoteMeta(dir+string(filepath.Separator)) + `[^\s:]+`)
+	return re.ReplaceAllStringFunc(out, func(p string) string {
+		return relPath(filepath.Clean(p))
+	})
+}
+
+func indentLines(s string) string {
+	return "    " + strings.ReplaceAll(s, "\n", "\n    ")
+}
+
+// runDocTests tests the examples documented in args and returns the exit
+// code (1 when any example fails).
+func runDocTests(args []string, jobs int, verbose bool) int {
+	cases, err := collectDocTests(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gray doc: %v\n", err)
+		return 1
+	}
+	if len(cases) == 0 {
+		fmt.Println("gray doc: no @example blocks found")
+		return 0
+	}
+
+	results := parallelMap(len(cases), jobs, func(i int) mdTestResult {
+		return testDocExample(cases[i])
+	})
+
+	passed, failed := 0, 0
+	for i, r := range results {
+		if r.Status == mdTestPass {
+			passed++
+			if verbose {
+				fmt.Printf("ok    %s\n", cases[i].label())
+			}
+			continue
+		}
+		failed++
+		fmt.Printf("FAIL  %s: %s\n", cases[i].label(), r.Message)
+		if out := strings.TrimRight(r.Output, "\n"); out != "" {
+			for _, line := range strings.Split(out, "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+	fmt.Printf("gray doc: %d example(s) passed, %d failed\n", passed, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
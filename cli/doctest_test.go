@@ -0,0 +1,144 @@
+// doctest_test.go — Tests for gray doc --test: splitting "// Output:"
+// comments from examples, finding examples in the source, building the
+// program that imports the documented file, and checking examples with
+// grayc when it is available.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+func TestSplitExampleOutput(t *testing.T) {
+	tests := []struct {
+		example, code, want string
+		hasWant             bool
+	}{
+		{"println(1)", "println(1)", "", false},
+		{"println(1)\n// Output: 1", "println(1)", "1", true},
+		{"println(1)\nprintln(2)\n// Output:\n// 1\n//   2", "println(1)\nprintln(2)", "1\n  2", true},
+		{"// just a comment\nprintln(1)", "// just a comment\nprintln(1)", "", false},
+		{"println(1)\n// Output: 1\n// note", "println(1)", "1\nnote", true},
+		{"println(1) // Output: 1", "println(1) // Output: 1", "", false},
+	}
+	for _, tt := range tests {
+		code, want, hasWant := splitExampleOutput(tt.example)
+		if code != tt.code || want != tt.want || hasWant != tt.hasWant {
+			t.Errorf("splitExampleOutput(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.example, code, want, hasWant, tt.code, tt.want, tt.hasWant)
+		}
+	}
+}
+
+func TestNormalizeDocOutput(t *testing.T) {
+	if got := normalizeDocOutput("a  \r\nb\t\n\n"); got != "a\nb" {
+		t.Errorf("normalizeDocOutput = %q", got)
+	}
+}
+
+func TestLocateExample(t *testing.T) {
+	src := strings.Split("#doc(`\n    Adds.\n    @example\n        println(add(1, 2))\n        println(3)\n`)\ndo add() {}\nprintln(3)", "\n")
+	if got := locateExample(src, "println(add(1, 2))\nprintln(3)", 7); got != 4 {
+		t.Errorf("locateExample = %d, want 4", got)
+	}
+	// Only lines above the declaration are searched.
+	if got := locateExample(src, "println(3)", 7); got != 5 {
+		t.Errorf("locateExample = %d, want 5", got)
+	}
+	if got := locateExample(src, "missing()", 7); got != 0 {
+		t.Errorf("locateExample = %d, want 0", got)
+	}
+}
+
+func TestDocTestProgram(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "proj", "src", "mathx.gray")
+	writeTestFile(t, file, "")
+
+	src, lineMap, err := docTestProgram(docTestCase{File: file, Code: "println(add(1, 2))"}, filepath.Join(root, "tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "import \"../proj/src/mathx\"\nusing mathx\ndo main() {\nprintln(add(1, 2))\n}\n"
+	if src != want {
+		t.Errorf("program:\n%s\nwant:\n%s", src, want)
+	}
+	if len(lineMap) != 5 || lineMap[3] != 0 || lineMap[0] != -1 {
+		t.Errorf("lineMap = %v", lineMap)
+	}
+}
+
+func TestDocTestsGrayc(t *testing.T) {
+	if _, err := grayc.Find(); err != nil {
+		t.Skip("grayc not available")
+	}
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "mathx.gray"), "#doc(`\n    Adds.\n\n    @example\n        println(add(1, 2))\n"+
+		"    @example\n        println(mathx.add(1, 2))\n    @example\n        println(add(\"x\", 2))\n`)\n"+
+		"do add(a, b int) -> int {\n    return a + b\n}\n")
+	cases, err := collectDocTests([]string{filepath.Join(dir, "mathx.gray")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 3 {
+		t.Fatalf("got %d cases, want 3", len(cases))
+	}
+	for i, wantPass := range []bool{true, true, false} {
+		r := testDocExample(cases[i])
+		if (r.Status == mdTestPass) != wantPass {
+			t.Errorf("example %d: %s %s\n%s", i+1, r.Status, r.Message, r.Output)
+		}
+		if !wantPass && !strings.Contains(r.Output, "mathx.gray:9:13") {
+			t.Errorf("example %d: error not mapped to the source:\n%s", i+1, r.Output)
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("doctest files left behind: %v", entries)
+	}
+}
+
+func TestDocTestsGraycImportedError(t *testing.T) {
+	if _, err := grayc.Find(); err != nil {
+		t.Skip("grayc not available")
+	}
+	dir := t.TempDir()
+	file := filepath.Join(dir, "mathx.gray")
+	writeTestFile(t, file, "#doc(`\n    Adds.\n\n    @example\n        println(add(1, 2))\n`)\n"+
+		"do add(a, b int) -> int {\n    return \"x\"\n}\n")
+	cases, err := collectDocTests([]string{file})
+	if err != nil || len(cases) != 1 {
+		t.Fatalf("collectDocTests = %d cases, %v", len(cases), err)
+	}
+	r := testDocExample(cases[0])
+	if r.Status == mdTestPass {
+		t.Fatal("example importing a broken file passed")
+	}
+	// The excerpt is of mathx.gray itself, so its gutter keeps line 8,
+	// and it is named by its own path rather than through the temp dir.
+	if !strings.Contains(r.Output, "--> "+cases[0].Path+":8:") || !strings.Contains(r.Output, "  8 |     return \"x\"") {
+		t.Errorf("error in the imported file renumbered:\n%s", r.Output)
+	}
+	if strings.Contains(r.Output, "gray-doctest-") {
+		t.Errorf("temp directory in the output:\n%s", r.Output)
+	}
+}
+
+func TestUnwindImportPaths(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "gray-doctest-1")
+	proj := filepath.Join(filepath.Dir(dir), "proj")
+	out := "  --> " + dir + "/../proj/lib.gray:3:5\n" + dir + "/../proj/src/util.gray:7: note\n"
+	want := "  --> " + relPath(filepath.Join(proj, "lib.gray")) + ":3:5\n" + relPath(filepath.Join(proj, "src", "util.gray")) + ":7: note\n"
+	if got := unwindImportPaths(out, dir); got != want {
+		t.Errorf("unwindImportPaths =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,217 @@
+// explain.go — "gray explain <code>": the long form of a compiler error,
+// warning, or runtime panic code. The code's kind, category, and message
+// template come from grayc/src/util/error_codes.h and its example from the
+// integration tests (both generated into explain_codes_data.go); the
+// explanation and usual fix for common codes are written out here. "gray
+// man E2013" shows the same page.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var errorCodePattern = regexp.MustCompile(`^[EWP][0-9]{4,5}$`)
+
+// isErrorCode reports whether name looks like a diagnostic code such as
+// E2013, W1001, or P0004 (in either case).
+func isErrorCode(name string) bool {
+	return errorCodePattern.MatchString(strings.ToUpper(name))
+}
+
+// errorCodeRanges describes each range of codes.
+var errorCodeRanges = []struct {
+	Prefix, Range, Meaning string
+}{
+	{"E1", "E1xxx", "Problems reading your code (invalid characters, malformed literals)"},
+	{"E2", "E2xxx", "Problems understanding your code (missing brackets, unexpected symbols)"},
+	{"E3", "E3xxx", "Type problems (wrong types, invalid operations)"},
+	{"E4", "E4xxx", "Name problems (undefined variables, duplicate names)"},
+	{"E5", "E5xxx", "Usage problems (wrong number of arguments, invalid assignment targets)"},
+	{"E6", "E6xxx", "Import problems (unknown modules, missing files)"},
+	{"E7", "E7xxx", "Standard library problems (wrong usage of built-in functions)"},
+	{"E8", "E8xxx", "Bitwise operator errors (non-integer operands)"},
+	{"E9", "E9xxx", "Array errors (empty array ops, invalid ranges)"},
+	{"E12", "E12xxx", "Map errors (unhashable keys, duplicate keys)"},
+	{"W1", "W1xxx", "Cleanup suggestions (unused variables, functions)"},
+	{"W2", "W2xxx", "Safety warnings (shadowing, unused imports)"},
+	{"W3", "W3xxx", "Quality warnings (uninitialized elements, pointer lifetime)"},
+	{"P0", "P0xxx", "Runtime panics (overflow, out-of-bounds, nil dereference)"},
+}
+
+// errorCodeRange returns the range a code belongs to and what it means.
+func errorCodeRange(code string) (string, string) {
+	for _, r := range errorCodeRanges {
+		// The prefix is followed by three digits, so E12001 is E12xxx, not E1xxx.
+		if strings.HasPrefix(code, r.Prefix) && len(code) == len(r.Prefix)+3 {
+			return r.Range, r.Meaning
+		}
+	}
+	return "", ""
+}
+
+// errorExplanation is the hand-written part of an explain page.
+type errorExplanation struct {
+	Explain string
+	Fix     string
+	Example string // used when no integration test triggers the code
+}
+
+// errorExplanations holds the long-form text for common codes. Codes not
+// listed here still get their message, range, and example, and their page
+// says it has no long-form explanation.
+var errorExplanations = map[string]errorExplanation{
+	"E1006": {
+		Explain: "A backslash in a string starts an escape sequence, and only a few are defined. Any other character after the backslash, or \\x not followed by two hex digits, is rejected rather than kept as written.",
+		Fix:     "Use one of \\n \\t \\\\ \\\" or \\xHH. To keep a literal backslash, write \\\\, or use a raw string with backticks.",
+	},
+	"E2001": {
+		Explain: "The parser found a token that cannot appear at this point, often left over from another language (a semicolon, a stray bracket) or a missing operator between two values.",
+		Fix:     "Look at the token the caret points to and the one just before it; remove the extra symbol or add what is missing between them.",
+	},
+	"E2002": {
+		Explain: "A construct was not finished: a bracket, parenthesis, or brace was opened and never closed, or a keyword such as 'is' is missing where the grammar needs one.",
+		Fix:     "Check that every (, [ and { before this line has its matching close, and that the statement is complete.",
+	},
+	"E2012": {
+		Explain: "Two parameters of the same function have the same name, so the body could not tell which one a use of that name refers to.",
+		Fix:     "Rename one of the parameters.",
+	},
+	"E2013": {
+		Explain: "A struct declares the same field name twice. Each field of a struct must have its own name, since struct literals and field access (p.x) name fields directly.",
+		Fix:     "Rename or remove one of the duplicate fields.",
+		Example: "const Point struct {\n    x int\n    y int\n    x int\n}\n\ndo main() {\n    mut p = Point{x: 1, y: 2}\n    println(p.y)\n}",
+	},
+	"E2014": {
+		Explain: "An enum lists the same variant twice. Variants are referred to by name (Direction.NORTH), so each one must be unique within its enum.",
+		Fix:     "Rename or remove one of the duplicate variants.",
+	},
+	"E3001": {
+		Explain: "A value of one type was given where another type is required: assigned to a variable declared with a different type, passed as an argument, or returned from a function. Grayscale does not convert between types implicitly.",
+		Fix:     "Convert the value explicitly (for example with int(), float(), or string()), or change the declared type to match.",
+	},
+	"E3002": {
+		Explain: "The operator is not defined for the operand types. Arithmetic needs numbers (and + also joins strings), comparisons need values of the same type, and logical operators need bools.",
+		Fix:     "Convert the operands to a type the operator supports, or use a stdlib function for the operation.",
+	},
+	"E4001": {
+		Explain: "The name is not declared anywhere visible from this line. Variables are visible from their declaration to the end of the enclosing block, so a name declared inside a loop or if-block is gone after it.",
+		Fix:     "Check the spelling, declare the variable with const or mut before using it, or move the declaration to an enclosing block.",
+	},
+	"E4002": {
+		Explain: "No function with this name is declared in the file, its imports, or the builtins.",
+		Fix:     "Check the spelling. For a stdlib function, import its module and call it qualified, as in strings.split(); see gray man <name>.",
+	},
+	"E6001": {
+		Explain: "The @ form of import names a standard library module, and no module by that name exists.",
+		Fix:     "Check the spelling against 'gray man' (which lists the modules). Import a file of your own by its path instead: import \"./src/shapes\".",
+	},
+	"W1001": {
+		Explain: "A variable is declared but its value is never read. This is often a leftover from a refactor or a typo in a later use.",
+		Fix:     "Remove the variable, use it, or assign the value to _ if it is only computed for its side effects.",
+	},
+	"W2002": {
+		Explain: "A variable in an inner block has the same name as one in an enclosing scope, so the outer variable cannot be reached inside the block. Assignments meant for the outer variable silently go to the inner one.",
+		Fix:     "Rename the inner variable, or assign to the outer one instead of declaring a new variable.",
+	},
+	"P0003": {
+		Explain: "A function called itself (directly or through other functions) more times than the runtime allows, usually because the recursion has no base case or never reaches it.",
+		Fix:     "Check the condition that ends the recursion, or rewrite the function with a loop.",
+	},
+	"P0004": {
+		Explain: "An integer addition produced a value outside the range of its type. Grayscale checks integer arithmetic at run time instead of wrapping around.",
+		Fix:     "Use a wider type (for example i64 or u64), or check the operands before adding.",
+	},
+}
+
+// errorCodeFix returns the usual fix for a code: the hand-written one, or
+// the advice after the ";" in its message.
+func errorCodeFix(code string, entry ErrorCodeEntry) string {
+	if x, ok := errorExplanations[code]; ok && x.Fix != "" {
+		return x.Fix
+	}
+	if _, fix, ok := strings.Cut(entry.Message, "; "); ok {
+		return strings.ToUpper(fix[:1]) + fix[1:] + "."
+	}
+	return ""
+}
+
+// runExplain prints the explain page for code.
+func runExplain(code string) error {
+	code = strings.ToUpper(code)
+	entry, ok := errorCodeDocs[code]
+	if !ok {
+		var hint string
+		codes := make([]string, 0, len(errorCodeDocs))
+		for c := range errorCodeDocs {
+			codes = append(codes, c)
+		}
+		sort.Strings(codes)
+		if suggestions := suggestManNames(code, codes); len(suggestions) > 0 {
+			hint = "\n    did you mean: " + strings.Join(suggestions, ", ")
+		}
+		return fmt.Errorf("gray: unknown error code '%s'%s\n    See the full list: https://github.com/grayscale-lang/grayscale/blob/main/ERRORS.md", code, hint)
+	}
+	fmt.Printf("%s: %s\n\n", code, entry.Message)
+	fmt.Printf("Kind:      %s\n", entry.Kind)
+	fmt.Printf("Category:  %s\n", entry.Category)
+	if r, meaning := errorCodeRange(code); r != "" {
+		fmt.Printf("Range:     %s — %s\n", r, meaning)
+	}
+	x := errorExplanations[code]
+	if x.Explain != "" {
+		fmt.Printf("\n%s\n", x.Explain)
+	} else {
+		fmt.Println("\nNo long-form explanation for this code yet; only common codes have one.")
+	}
+	if fix := errorCodeFix(code, entry); fix != "" {
+		fmt.Printf("\nFix:\n  %s\n", fix)
+	}
+	example, file := entry.Example, entry.ExampleFile
+	if example == "" {
+		example = x.Example
+	}
+	if example != "" {
+		if file != "" {
+			fmt.Printf("\nExample (%s):\n", file)
+		} else {
+			fmt.Println("\nExample:")
+		}
+		for _, line := range strings.Split(example, "\n") {
+			fmt.Println(strings.TrimRight("  "+line, " "))
+		}
+	}
+	return nil
+}
+
+// printExplainUsage lists the code ranges.
+func printExplainUsage() {
+	fmt.Println("Usage: gray explain <code>   (e.g. gray explain E2013)")
+	fmt.Println("\nCode ranges:")
+	for _, r := range errorCodeRanges {
+		fmt.Printf("  %-7s %s\n", r.Range, r.Meaning)
+	}
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <code>",
+	Short: "Explain a compiler error, warning, or panic code",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			printExplainUsage()
+			return nil
+		}
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		return showManOutput(noColor, func() error { return runExplain(args[0]) })
+	},
+}
@@ -0,0 +1,383 @@
+// Code generated by scripts/generate_explain.sh — do not edit.
+package main
+
+// ErrorCodeEntry holds the registry data for one error, warning, or panic code.
+type ErrorCodeEntry struct {
+	Kind        string // "error", "warning", or "panic"
+	Category    string
+	Message     string // message template from error_codes.h
+	Example     string // smallest integration test that triggers the code
+	ExampleFile string
+}
+
+// errorCodeDocs is the lookup table used by gray explain <code>.
+var errorCodeDocs = map[string]ErrorCodeEntry{
+	"E1003":  {Kind: "error", Category: "syntax", Message: "unclosed multi-line comment; add */", Example: "do main() {\n    /* This comment is never closed\n    println(\"hello\")\n}", ExampleFile: "integration-tests/fail/errors/E1003_unclosed_comment.gray"},
+	"E1005":  {Kind: "error", Category: "syntax", Message: "unclosed character; add a closing single quote", Example: "", ExampleFile: ""},
+	"E1006":  {Kind: "error", Category: "syntax", Message: "invalid escape sequence in string; valid escapes are \\n \\t \\\\ \\\" and \\x", Example: "do main() {\n    mut bad = \"\\xGG\"  // GG is not valid hex\n}", ExampleFile: "integration-tests/fail/errors/E1006_invalid_hex_escape.gray"},
+	"E1007":  {Kind: "error", Category: "syntax", Message: "invalid escape sequence in character", Example: "do main() {\n    mut c char = '\\q'  // \\q is not valid escape\n}", ExampleFile: "integration-tests/fail/errors/E1007_invalid_escape_char.gray"},
+	"E1010":  {Kind: "error", Category: "syntax", Message: "invalid number format; hex (0x), octal (0o), or binary (0b) prefix must be followed by digits", Example: "do main() {\n    mut x int = 0x  // hex without digits\n}", ExampleFile: "integration-tests/fail/errors/E1010_invalid_number_format.gray"},
+	"E1011":  {Kind: "error", Category: "syntax", Message: "number cannot have consecutive underscores", Example: "do main() {\n    mut x int = 1__000  // consecutive underscores\n}", ExampleFile: "integration-tests/fail/errors/E1011_consecutive_underscores.gray"},
+	"E1012":  {Kind: "error", Category: "syntax", Message: "numeric literals cannot start with an underscore; did you mean '%s'?", Example: "do main() {\n    mut x int = _1000  // leading underscore\n}", ExampleFile: "integration-tests/fail/errors/E1012_leading_underscore.gray"},
+	"E1013":  {Kind: "error", Category: "syntax", Message: "number cannot end with an underscore", Example: "do main() {\n    mut x int = 1000_  // trailing underscore\n}", ExampleFile: "integration-tests/fail/errors/E1013_trailing_underscore.gray"},
+	"E1014":  {Kind: "error", Category: "syntax", Message: "number cannot have an underscore before the decimal point", Example: "do main() {\n    mut x float = 1_.5  // underscore before decimal\n}", ExampleFile: "integration-tests/fail/errors/E1014_underscore_before_decimal.gray"},
+	"E1015":  {Kind: "error", Category: "syntax", Message: "number cannot have an underscore after the decimal point", Example: "do main() {\n    mut x float = 1._5  // underscore after decimal\n}", ExampleFile: "integration-tests/fail/errors/E1015_underscore_after_decimal.gray"},
+	"E1016":  {Kind: "error", Category: "syntax", Message: "number cannot end with a trailing decimal point; add a digit after the dot", Example: "do main() {\n    mut x float = 1.  // trailing decimal\n}", ExampleFile: "integration-tests/fail/errors/E1016_trailing_decimal.gray"},
+	"E1017":  {Kind: "error", Category: "syntax", Message: "unclosed raw string; add a closing backtick", Example: "do main() {\n    mut s string = `this raw string never ends\n}", ExampleFile: "integration-tests/fail/errors/E1017_unclosed_raw_string.gray"},
+	"E1018":  {Kind: "error", Category: "syntax", Message: "a char holds exactly one character; use a string for multiple", Example: "do main() {\n    mut c char = ''  // Empty char literal\n}", ExampleFile: "integration-tests/fail/errors/E1018_empty_char.gray"},
+	"E1019":  {Kind: "error", Category: "syntax", Message: "unexpected '#' character; use '//' for comments", Example: "#foo\n\ndo main() {\n    println(\"hello\")\n}", ExampleFile: "integration-tests/fail/errors/E1019_unexpected_hash.gray"},
+	"E1020":  {Kind: "error", Category: "syntax", Message: "unexpected '|' character; use '||' for logical OR", Example: "do main() {\n    mut x bool = true | false  // Single | is illegal, should be ||\n}", ExampleFile: "integration-tests/fail/errors/E1020_illegal_or.gray"},
+	"E1021":  {Kind: "error", Category: "syntax", Message: "unclosed string; add a closing double quote", Example: "do main() {\n    mut s string = \"this string never ends\n}", ExampleFile: "integration-tests/fail/errors/E1021_unclosed_string.gray"},
+	"E1022":  {Kind: "error", Category: "syntax", Message: "unexpected character", Example: "do main() {\n    mut x int = 42 ~ 3  // tilde is not a valid operator\n}", ExampleFile: "integration-tests/fail/errors/E1022_unexpected_character.gray"},
+	"E1023":  {Kind: "error", Category: "syntax", Message: "string literals cannot span multiple lines; use a raw string with backticks for multi-line text", Example: "do main() {\n    const s string = \"line one\nline two\"\n    println(s)\n}", ExampleFile: "integration-tests/fail/errors/E1023_newline_in_string.gray"},
+	"E2001":  {Kind: "error", Category: "syntax", Message: "unexpected symbol", Example: "do main() {\n    mut bit_and int = 5\n}", ExampleFile: "integration-tests/fail/errors/E2001_bit_and_as_keyword.gray"},
+	"E2002":  {Kind: "error", Category: "syntax", Message: "missing symbol; expected a bracket, parenthesis, or keyword", Example: "do main() {\n    mut x int = 5\n    mut s string = \"${x +}\"\n    println(s)\n}", ExampleFile: "integration-tests/fail/errors/E2002_interpolation_incomplete_expr.gray"},
+	"E2010":  {Kind: "error", Category: "syntax", Message: "cannot use module '%s' before importing it; add 'import @%s' before the using statement", Example: "", ExampleFile: ""},
+	"E2011":  {Kind: "error", Category: "syntax", Message: "constant '%s' must have a value; add = followed by a value", Example: "do main() {\n    const x int  // const without value\n}", ExampleFile: "integration-tests/fail/errors/E2011_const_no_value.gray"},
+	"E2012":  {Kind: "error", Category: "syntax", Message: "duplicate parameter name '%s'", Example: "do foo(x int, x string) {  // Duplicate param name\n    println(\"test\")\n}\n\ndo main() {\n    foo(1, \"test\")\n}", ExampleFile: "integration-tests/fail/errors/E2012_duplicate_param.gray"},
+	"E2013":  {Kind: "error", Category: "syntax", Message: "duplicate field name '%s' in struct '%s'", Example: "", ExampleFile: ""},
+	"E2014":  {Kind: "error", Category: "syntax", Message: "duplicate variant name '%s' in enum '%s'", Example: "const Direction enum {\n    NORTH\n    SOUTH\n    EAST\n    NORTH\n}\n\ndo main() {\n    mut d = Direction.NORTH\n}", ExampleFile: "integration-tests/fail/errors/E2014_duplicate_enum_variant.gray"},
+	"E2015":  {Kind: "error", Category: "syntax", Message: "duplicate field '%s' in struct literal; field can only be initialized once", Example: "const Point struct {\n    x int\n    y int\n}\n\ndo main() {\n    mut p = Point{x: 1, y: 2, x: 3}\n}", ExampleFile: "integration-tests/fail/errors/E2015_duplicate_struct_literal_field.gray"},
+	"E2016":  {Kind: "error", Category: "syntax", Message: "enum '%s' has no values; an enum must have at least one value", Example: "const Status enum {\n    // Empty enum - no values\n}\n\ndo main() {\n    println(\"test\")\n}", ExampleFile: "integration-tests/fail/errors/E2016_empty_enum.gray"},
+	"E2017":  {Kind: "error", Category: "syntax", Message: "stray comma; remove the extra ','", Example: "do main() {\n    mut arr [int] = {1, 2, 3,}  // Trailing comma\n}", ExampleFile: "integration-tests/fail/errors/E2017_trailing_comma.gray"},
+	"E2025":  {Kind: "error", Category: "syntax", Message: "expected integer or constant for array size; the second value in [type, size] must be a positive integer or a const integer identifier", Example: "do main() {\n    mut arr [int, \"ten\"] = {1, 2, 3}  // String instead of int size\n}", ExampleFile: "integration-tests/fail/errors/E2025_invalid_array_size.gray"},
+	"E2036":  {Kind: "error", Category: "syntax", Message: "imports must be at the top of the file, not inside a function", Example: "do main() {\n    import and use @math  // imports not allowed inside blocks\n    println(\"test\")\n}", ExampleFile: "integration-tests/fail/errors/E2036_import_inside_block.gray"},
+	"E2037":  {Kind: "error", Category: "syntax", Message: "duplicate function name in struct; each function must have a unique name", Example: "const int struct {\n    x int\n}\n\ndo main() {\n    println(\"Should not run!\")\n}", ExampleFile: "integration-tests/fail/errors/E2037_reserved_struct_name.gray"},
+	"E2038":  {Kind: "error", Category: "syntax", Message: "reserved name for struct or enum; this name is used by the language", Example: "do main() {\n    mut int = 42\n}", ExampleFile: "integration-tests/fail/errors/E2038_reserved_type_as_variable.gray"},
+	"E2039":  {Kind: "error", Category: "syntax", Message: "required parameter '%s' cannot come after a parameter with a default value", Example: "do bad_func(x int = 10, y int) {\n    // This should fail - y is required but comes after x which has a default\n    println(x + y)\n}\n\ndo main() {\n    bad_func(5, 10)\n}", ExampleFile: "integration-tests/fail/errors/E2039_required_after_default.gray"},
+	"E2043":  {Kind: "error", Category: "syntax", Message: "duplicate case value in when statement", Example: "do main() {\n    mut x = 1\n    when x {\n        is 1 { println(\"one\") }\n        is 2 { println(\"two\") }\n        is 1 { println(\"one again\") }\n        default { println(\"other\") }\n    }\n}", ExampleFile: "integration-tests/fail/errors/E2043_when_duplicate_case.gray"},
+	"E2050":  {Kind: "error", Category: "syntax", Message: "break and continue can only be used inside a loop", Example: "do main() {\n    continue  // continue outside of any loop\n}", ExampleFile: "integration-tests/fail/errors/E2050_continue_outside_loop.gray"},
+	"E2051":  {Kind: "error", Category: "syntax", Message: "nested function declarations are not allowed; define '%s' at the top level", Example: "do main() {\n    do inner() {  // nested function not allowed\n        println(\"nested\")\n    }\n}", ExampleFile: "integration-tests/fail/errors/E2051_nested_function.gray"},
+	"E2053":  {Kind: "error", Category: "syntax", Message: "%s '%s' must be defined at the file scope, not inside a function", Example: "", ExampleFile: ""},
+	"E2056":  {Kind: "error", Category: "syntax", Message: "statements cannot sit at file scope; move this into do main()", Example: "println(\"function call at file scope\")\n\ndo main() {\n    println(\"main\")\n}", ExampleFile: "integration-tests/fail/errors/E2056_function_call_at_file_scope.gray"},
+	"E2057":  {Kind: "error", Category: "syntax", Message: "invalid interpolation syntax; use ${variable} instead of $variable", Example: "do main() {\n    mut file string = \"test.gray\"\n    mut s string = \"$File: something\"\n}", ExampleFile: "integration-tests/fail/errors/E2057_invalid_interpolation_syntax.gray"},
+	"E2058":  {Kind: "error", Category: "syntax", Message: "cannot declare a struct or enum inside %s '%s'; define it at the file scope", Example: "const Color enum {\n    Red\n    const Point struct {\n        x int\n    }\n}\n\ndo main() {\n    println(\"should not compile\")\n}", ExampleFile: "integration-tests/fail/errors/E2058_nested_struct_in_enum.gray"},
+	"E2059":  {Kind: "error", Category: "syntax", Message: "empty when block; add at least one 'is' branch", Example: "do main() {\n    mut x int = 5\n    when x {\n    }\n}", ExampleFile: "integration-tests/fail/errors/E2059_empty_when.gray"},
+	"E2060":  {Kind: "error", Category: "syntax", Message: "too many return values; a function can return at most %d values", Example: "do too_many() -> (a int, b int, c int, d int, e int, f int, g int, h int, i int, j int, k int, l int, m int, n int, o int, p int, q int) {\n    return a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, p, q\n}\n\ndo main() {\n    mut a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, p, q = too_many()\n    println(a)\n}", ExampleFile: "integration-tests/fail/errors/E2060_too_many_return_values.gray"},
+	"E2061":  {Kind: "error", Category: "syntax", Message: "'module' declarations are not supported; imported files are identified by their file path", Example: "module mypackage\n\ndo main() {\n    println(\"hello\")\n}", ExampleFile: "integration-tests/fail/errors/E2061_module_declaration.gray"},
+	"E2062":  {Kind: "error", Category: "syntax", Message: "too many variables in multi-variable declaration; maximum is %d", Example: "", ExampleFile: ""},
+	"E2063":  {Kind: "error", Category: "syntax", Message: "duplicate or conflicting named return value; each name must be unique and not collide with parameters", Example: "do compute(x int) -> (result int, result int) {\n    return x, x * 2\n}\n\ndo main() {\n    mut a, b = compute(5)\n}", ExampleFile: "integration-tests/fail/errors/E2063_duplicate_named_return.gray"},
+	"E2064":  {Kind: "error", Category: "syntax", Message: "function '%s' conflicts with field '%s' in struct '%s'", Example: "const Point struct {\n    x int\n    y int\n\n    do x() -> int {\n        return 0\n    }\n}\n\ndo main() {\n    mut p = new(Point)\n}", ExampleFile: "integration-tests/fail/errors/E2064_struct_func_shadows_field.gray"},
+	"E2065":  {Kind: "error", Category: "syntax", Message: "enum variant '%s' cannot have the same name as its enum type '%s'", Example: "const Color enum {\n    Color\n    RED\n    BLUE\n}\n\ndo main() {\n    mut c = Color.RED\n}", ExampleFile: "integration-tests/fail/errors/E2065_enum_variant_shadows_type.gray"},
+	"E2066":  {Kind: "error", Category: "syntax", Message: "struct field '%s' cannot have the same name as its struct type '%s'", Example: "const Foo struct {\n    Foo int\n    bar string\n}\n\ndo main() {\n    mut f = new(Foo)\n}", ExampleFile: "integration-tests/fail/errors/E2066_struct_field_shadows_type.gray"},
+	"E2067":  {Kind: "error", Category: "syntax", Message: "struct '%s' has no fields; a struct must have at least one field", Example: "const Empty struct {\n}\n\ndo main() {\n    mut e = new(Empty)\n}", ExampleFile: "integration-tests/fail/errors/E2067_empty_struct.gray"},
+	"E2068":  {Kind: "error", Category: "syntax", Message: "%ss must be declared with 'const', not 'mut'; change 'mut' to 'const'", Example: "mut Color enum {\n    RED\n    GREEN\n    BLUE\n}\n\ndo main() {\n    mut c = Color.RED\n}", ExampleFile: "integration-tests/fail/errors/E2068_mut_enum.gray"},
+	"E2069":  {Kind: "error", Category: "syntax", Message: "unexpected semicolon; statements and declarations are separated by newlines, not semicolons", Example: "const Point struct {\n    x int;\n    y int\n}\n\ndo main() {\n    println(\"should not compile\")\n}", ExampleFile: "integration-tests/fail/errors/E2069_semicolon_in_struct.gray"},
+	"E2070":  {Kind: "error", Category: "syntax", Message: "wildcard type '?' is only allowed in function parameter and return types; not in variable declarations, struct fields, or enum types", Example: "const S struct {\n    ? int\n}\n\ndo main() {}", ExampleFile: "integration-tests/fail/errors/E2070_wildcard_in_struct_field_name.gray"},
+	"E2071":  {Kind: "error", Category: "syntax", Message: "empty string interpolation '${}'; interpolation requires an expression between the braces", Example: "do main() {\n    mut s string = \"value: ${ }\"\n}", ExampleFile: "integration-tests/fail/errors/E2071_whitespace_string_interpolation.gray"},
+	"E2072":  {Kind: "error", Category: "syntax", Message: "'&' is not a valid operator; use 'addr(x)' to take the address of a variable", Example: "do main() {\n    mut x int = 42\n    mut p ^int = &x\n}", ExampleFile: "integration-tests/fail/errors/E2072_ampersand_int.gray"},
+	"E2073":  {Kind: "error", Category: "syntax", Message: "function calls cannot have whitespace between the name and the opening parenthesis; write 'name(...)' with no space or newline", Example: "do main() {\n    println (\"hello\")\n}", ExampleFile: "integration-tests/fail/errors/E2073_call_with_space_before_paren.gray"},
+	"E2074":  {Kind: "error", Category: "syntax", Message: "member access cannot have whitespace before the dot; write 'obj.field' or 'Enum.VARIANT' with no space or newline", Example: "", ExampleFile: ""},
+	"E2075":  {Kind: "error", Category: "syntax", Message: "index expressions cannot have whitespace before the opening bracket; write 'arr[i]' with no space or newline", Example: "", ExampleFile: ""},
+	"E2076":  {Kind: "error", Category: "syntax", Message: "postfix operators ('++', '--', '^') cannot have whitespace before them; write 'x++', 'x--', or 'p^' with no space or newline", Example: "", ExampleFile: ""},
+	"E2078":  {Kind: "error", Category: "syntax", Message: "variable declarations must start with 'const' or 'mut'; did you mean 'const %s' or 'mut %s'?", Example: "def Person struct {\n    name string\n    data UnknownType  // Unknown type in struct field\n}\n\ndo main() {\n    mut p Person = new Person{}\n}", ExampleFile: "integration-tests/fail/errors/E2078_undefined_type_in_struct.gray"},
+	"E2079":  {Kind: "error", Category: "syntax", Message: "'nil' is a value, not a type; for a function that returns nothing, omit the '-> ...' clause", Example: "", ExampleFile: ""},
+	"E2080":  {Kind: "error", Category: "syntax", Message: "invalid character in C header path; only [A-Za-z0-9./_+-] are permitted", Example: "", ExampleFile: ""},
+	"E2081":  {Kind: "error", Category: "syntax", Message: "'^' is a dereference operator, not a type modifier; for a pointer return type write '^%s', not '%s^'", Example: "", ExampleFile: ""},
+	"E2082":  {Kind: "error", Category: "syntax", Message: "arrays of typed func signatures are not supported; use '[func]' or '[func, N]' with '()func_name' elements instead", Example: "do double(n int) -> int { return n * 2 }\ndo main() {\n    const fns [func(int) -> int] = {}\n}", ExampleFile: "integration-tests/fail/errors/E2082_typed_func_array.gray"},
+	"E2083":  {Kind: "error", Category: "syntax", Message: "enum variant '%s' cannot have both a payload and an explicit value", Example: "", ExampleFile: ""},
+	"E2084":  {Kind: "error", Category: "syntax", Message: "blank identifier '_' requires '='; use '%s _ = <expr>' to discard a result", Example: "do foo() -> int {\n    return 1\n}\n\ndo main() {\n    mut _ foo()\n}", ExampleFile: "integration-tests/fail/errors/E2084_blank_ident_missing_eq.gray"},
+	"E2085":  {Kind: "error", Category: "syntax", Message: "when statement already has a default branch; only one default is allowed", Example: "do main() {\n    mut x int = 5\n    when x {\n        is 1 { println(\"one\") }\n        default { println(\"default1\") }\n        default { println(\"default2\") }\n    }\n}", ExampleFile: "integration-tests/fail/errors/E2085_when_duplicate_default.gray"},
+	"E2086":  {Kind: "error", Category: "syntax", Message: "'%s' requires a value on the left side; '%s' checks whether a value belongs to a collection or range", Example: "do main() {\n    if in range(0, 10) {\n        println(\"test\")\n    }\n}", ExampleFile: "integration-tests/fail/errors/E2086_in_missing_lhs.gray"},
+	"E2087":  {Kind: "error", Category: "syntax", Message: "type parameters (<?>) cannot be mixed with value parameters in the same function", Example: "", ExampleFile: ""},
+	"E2088":  {Kind: "error", Category: "syntax", Message: "mixed keyword aliases in the same file; '%s' used here, but '%s' was used on line %d", Example: "do main() {\n    mut i int = 0\n    while i < 3 {\n        i++\n    }\n\n    mut j int = 0\n    as_long_as j < 2 {\n        j++\n    }\n}", ExampleFile: "integration-tests/fail/errors/E2088_mixed_while_alias.gray"},
+	"E3001":  {Kind: "error", Category: "types", Message: "type mismatch; a value of one type is used where a different type is expected", Example: "do main() {\n    mut b byte = 65\n    mut u u8 = b\n    println(u)\n}", ExampleFile: "integration-tests/fail/errors/E3001_byte_assigned_to_u8.gray"},
+	"E3002":  {Kind: "error", Category: "types", Message: "this operator does not work on this type; for example, strings cannot be subtracted", Example: "do main() {\n    mut x int = 10 % 0  // modulo by zero\n}", ExampleFile: "integration-tests/fail/errors/E3002_modulo_by_zero.gray"},
+	"E3003":  {Kind: "error", Category: "types", Message: "invalid array index type; array indices must be integers", Example: "do main() {\n    mut arr [int] = {1, 2, 3}\n    println(arr[-1])\n}", ExampleFile: "integration-tests/fail/errors/E3003_negative_literal_index.gray"},
+	"E3004":  {Kind: "error", Category: "types", Message: "strings are not element-assignable; individual string characters cannot be modified by index", Example: "do main() {\n    mut s string = \"hello\"\n    s[0] = 5\n}", ExampleFile: "integration-tests/fail/errors/E3004_invalid_index_assignment.gray"},
+	"E3005":  {Kind: "error", Category: "types", Message: "cannot modify constant '%s'; declare with 'mut' to make it mutable", Example: "do main() {\n    const x int = 5\n    x = 10  // Cannot reassign const\n}", ExampleFile: "integration-tests/fail/errors/E3005_const_reassign.gray"},
+	"E3006":  {Kind: "error", Category: "types", Message: "too many variables; the function returns %d value(s) but variable %d was requested", Example: "do pair() -> (int, int) {\n    return 1, 2\n}\n\ndo main() {\n    mut a, b, c = pair()\n}", ExampleFile: "integration-tests/fail/errors/E3006_multi_return_too_many_vars.gray"},
+	"E3007":  {Kind: "error", Category: "types", Message: "cannot negate type '%s'; only numeric types support negation", Example: "", ExampleFile: ""},
+	"E3008":  {Kind: "error", Category: "types", Message: "type '%s' does not support indexing; only arrays, maps, and strings can be indexed", Example: "do main() {\n    mut x int = 5\n    x[0] = 10  // Trying to index an integer\n}", ExampleFile: "integration-tests/fail/errors/E3008_not_indexable.gray"},
+	"E3009":  {Kind: "error", Category: "types", Message: "cannot iterate over type '%s'; for_each requires an array, map, or string", Example: "do main() {\n    mut x int = 5\n    for_each item in x {  // Cannot iterate over an integer\n        println(item)\n    }\n}", ExampleFile: "integration-tests/fail/errors/E3009_not_iterable.gray"},
+	"E3010":  {Kind: "error", Category: "types", Message: "struct '%s' has no field '%s'", Example: "const Point struct {\n    x int\n    y int\n}\n\ndo main() {\n    mut p = new(Point)\n    p.z = 10\n}", ExampleFile: "integration-tests/fail/errors/E3010_invalid_struct_field_pointer.gray"},
+	"E3011":  {Kind: "error", Category: "types", Message: "'%s' is a type, not a value; did you mean to declare a type? (e.g., mut x %s = ...)", Example: "do main() {\n    mut x = int\n}", ExampleFile: "integration-tests/fail/errors/E3011_type_name_as_value.gray"},
+	"E3012":  {Kind: "error", Category: "types", Message: "addr() needs a variable, field, or array element; the address of a value like 42 cannot be taken", Example: "do main() {\n    mut p ^int = addr(42)\n}", ExampleFile: "integration-tests/fail/errors/E3012_addr_of_literal.gray"},
+	"E3013":  {Kind: "error", Category: "types", Message: "type does not support access via dot notation", Example: "do main() {\n    mut s = \"hello\"\n    println(s.name)  // strings don't have members\n}", ExampleFile: "integration-tests/fail/errors/E3013_member_access_invalid_type2.gray"},
+	"E3015":  {Kind: "error", Category: "types", Message: "'%s' is a %s, not a function; it cannot be called", Example: "do main() {\n    mut x int = 5\n    x()  // Trying to call an integer\n}", ExampleFile: "integration-tests/fail/errors/E3015_not_callable.gray"},
+	"E3016":  {Kind: "error", Category: "types", Message: "cannot dereference non-pointer type '%s'; only ^T types can use ^", Example: "do main() {\n    mut x int = 42\n    mut y int = x^\n}", ExampleFile: "integration-tests/fail/errors/E3016_dereference_non_pointer.gray"},
+	"E3017":  {Kind: "error", Category: "types", Message: "fmt.%s() cannot format value of type '%s'; use println() for composite types, or access individual fields", Example: "import @fmt\n\ndo main() {\n    mut nums [int] = {1, 2, 3}\n    fmt.printf(\"%s\", nums)\n}", ExampleFile: "integration-tests/fail/errors/E3017_fmt_printf_array.gray"},
+	"E3018":  {Kind: "error", Category: "types", Message: "type mismatch in 'when'; comparing '%s' with '%s'", Example: "do main() {\n    mut x int = 5\n    when x {\n        is \"hello\" { }\n        default { }\n    }\n}", ExampleFile: "integration-tests/fail/errors/E3018_when_int_vs_string.gray"},
+	"E3019":  {Kind: "error", Category: "types", Message: "cannot assign signed type '%s' to unsigned type '%s'; value may be negative", Example: "do main() {\n    mut x int = -5\n    mut y uint = x  // cannot assign signed to unsigned\n}", ExampleFile: "integration-tests/fail/errors/E3019_signed_to_unsigned.gray"},
+	"E3024":  {Kind: "error", Category: "types", Message: "function '%s' must return a value but has no return statement", Example: "do getValue() -> int {\n    mut x int = 5\n    // missing return statement\n}\n\ndo main() {\n    mut v int = getValue()\n}", ExampleFile: "integration-tests/fail/errors/E3024_missing_return_statement.gray"},
+	"E3027":  {Kind: "error", Category: "types", Message: "cannot pass a constant to a mutable parameter; the function wants to modify this value", Example: "do modify(&x int) {\n    x = 99\n}\n\ndo main() {\n    modify(42)\n}", ExampleFile: "integration-tests/fail/errors/E3027_literal_to_mutable_param.gray"},
+	"E3031":  {Kind: "error", Category: "types", Message: "function '%s' cannot be used as a value; did you mean '%s()' or '()%s'?", Example: "do some_function() {\n    // Some function\n}\n\ndo main() {\n    some_function\n}", ExampleFile: "integration-tests/fail/errors/E3031_bare_function_name.gray"},
+	"E3032":  {Kind: "error", Category: "types", Message: "cannot compare enum '%s' with enum '%s'; different enum types are never equal", Example: "const Color enum {\n    RED\n    GREEN\n}\n\nconst Dir enum {\n    NORTH\n    SOUTH\n}\n\ndo main() {\n    mut c Color = Color.RED\n    mut d Dir = Dir.NORTH\n    if c == d {\n        println(\"same\")\n    }\n}", ExampleFile: "integration-tests/fail/errors/E3032_cross_enum_comparison.gray"},
+	"E3033":  {Kind: "error", Category: "types", Message: "duplicate value in enum '%s': '%s' and '%s' both have the same value", Example: "const Status enum {\n    ACTIVE = 1\n    INACTIVE = 1\n}\n\ndo main() {\n    println(\"${Status.ACTIVE}\")\n}", ExampleFile: "integration-tests/fail/errors/E3033_duplicate_enum_value.gray"},
+	"E3034":  {Kind: "error", Category: "types", Message: "'any' type is reserved for internal use and cannot be used in declarations", Example: "do main() {\n    mut x any = 42  // 'any' type is reserved\n}", ExampleFile: "integration-tests/fail/errors/E3034_any_type_not_allowed.gray"},
+	"E3035":  {Kind: "error", Category: "types", Message: "not all code paths in '%s' return a value", Example: "do maybe_return(x int) -> int {\n    if x > 0 {\n        return x\n    }\n    // Missing return when x <= 0\n}\n\ndo main() {\n    mut result int = maybe_return(5)\n    println(result)\n}", ExampleFile: "integration-tests/fail/errors/E3035_not_all_paths_return.gray"},
+	"E3036":  {Kind: "error", Category: "types", Message: "value %lld is out of range for type '%s' (valid range: %lld to %lld)", Example: "do main() {\n    mut b byte = 256  // byte must be 0-255\n}", ExampleFile: "integration-tests/fail/errors/E3036_byte_out_of_range.gray"},
+	"E3038":  {Kind: "error", Category: "types", Message: "'void' cannot be used as a variable type or in expressions like type_of()", Example: "", ExampleFile: ""},
+	"E3039":  {Kind: "error", Category: "types", Message: "ensure expects a function call; for example: ensure close(file)", Example: "do main() {\n    mut x int = 5\n    ensure x  // ERROR: ensure expects a function call, not an identifier\n}", ExampleFile: "integration-tests/fail/errors/E3039_ensure_expects_call.gray"},
+	"E3040":  {Kind: "error", Category: "types", Message: "'%s' returns %d values; use mut a, b = %s() to capture all of them", Example: "do get_pair() -> (int, int) {\n    return 10, 20\n}\n\ndo main() {\n    mut arr [int] = {get_pair()}\n}", ExampleFile: "integration-tests/fail/errors/E3040_multi_return_in_array.gray"},
+	"E3041":  {Kind: "error", Category: "types", Message: "cannot interpolate expression; interpolation supports primitives, strings, arrays, and maps", Example: "do say_hello() {\n    println(\"hello\")\n}\n\ndo main() {\n    mut s string = \"result: ${say_hello()}\"\n    println(s)\n}", ExampleFile: "integration-tests/fail/errors/E3041_void_interpolation.gray"},
+	"E3043":  {Kind: "error", Category: "types", Message: "cannot cast between incompatible types; only numeric, enum, and string conversions are allowed", Example: "do main() {\n    mut a [int] = {1, 2, 3}\n    mut r = string(a)\n}", ExampleFile: "integration-tests/fail/errors/E3043_string_cast_array.gray"},
+	"E3044":  {Kind: "error", Category: "types", Message: "cannot access field '%s' on type '%s'; use an instance variable instead", Example: "const Point struct {\n    x int\n    y int\n}\n\ndo main() {\n    mut val int = Point.x\n}", ExampleFile: "integration-tests/fail/errors/E3044_field_on_type_name.gray"},
+	"E3045":  {Kind: "error", Category: "types", Message: "'or_return' requires a function that returns (T, Error); '%s()' does not return an error", Example: "do do_nothing() {\n    return\n}\n\ndo main() -> (int, Error) {\n    mut v = do_nothing() or_return\n    return 0, nil\n}", ExampleFile: "integration-tests/fail/errors/E3045_or_return_void_func.gray"},
+	"E3046":  {Kind: "error", Category: "types", Message: "integer too large for 64 bits; max is 9223372036854775807", Example: "do main() {\n    mut x uint = 18446744073709551616  // UINT64_MAX + 1\n}", ExampleFile: "integration-tests/fail/errors/E3046_literal_exceeds_u64.gray"},
+	"E3047":  {Kind: "error", Category: "types", Message: "enum '%s' has no member '%s'", Example: "const Color enum {\n    RED\n    GREEN\n    BLUE\n}\n\ndo main() {\n    mut c = Color.YELLOW\n}", ExampleFile: "integration-tests/fail/errors/E3047_invalid_enum_member.gray"},
+	"E3048":  {Kind: "error", Category: "types", Message: "operator '+' is not defined for strings; use string interpolation or fmt.format() instead", Example: "do main() {\n    mut a string = \"hello\"\n    mut b string = \" world\"\n    mut c = a + b\n}", ExampleFile: "integration-tests/fail/errors/E3048_string_concat_plus.gray"},
+	"E3049":  {Kind: "error", Category: "types", Message: "cannot use '%s' on enum values; enums only support == and != comparisons", Example: "const Color enum {\n    RED\n    GREEN\n    BLUE\n}\n\ndo main() {\n    mut x = Color.RED + Color.GREEN\n}", ExampleFile: "integration-tests/fail/errors/E3049_enum_arithmetic_add.gray"},
+	"E3050":  {Kind: "error", Category: "types", Message: "array needs a type annotation; declare as [T] (e.g., mut x [int] = {1, 2, 3})", Example: "do main() {\n    mut x = {1, 2, 3}\n}", ExampleFile: "integration-tests/fail/errors/E3050_array_literal_no_type.gray"},
+	"E3051":  {Kind: "error", Category: "types", Message: "map needs a type annotation; declare as [K:V] or map[K:V] (e.g., mut x [string:int] = {\"a\": 1})", Example: "do main() {\n    mut m = {\"a\": 1, \"b\": 2}\n}", ExampleFile: "integration-tests/fail/errors/E3051_map_literal_no_type.gray"},
+	"E3052":  {Kind: "error", Category: "types", Message: "too many elements in array initializer; declared size is %d, got %d", Example: "do main() {\n    const a [int, 3] = {1, 2, 3, 4, 5}\n}", ExampleFile: "integration-tests/fail/errors/E3052_array_overflow.gray"},
+	"E3053":  {Kind: "error", Category: "types", Message: "type mismatch in array initializer; expected '%s', got '%s'", Example: "do main() {\n    mut x int = 42\n    mut s string = \"hello\"\n    mut m map[string:^int] = { \"a\": addr(x), \"b\": addr(s) }\n    println(m[\"a\"]^)\n}", ExampleFile: "integration-tests/fail/errors/E3053_pointer_type_mismatch_map_literal.gray"},
+	"E3054":  {Kind: "error", Category: "types", Message: "mutable arrays cannot have a fixed size; remove the size or use 'const' (e.g., mut %s %.*s] = ...)", Example: "do main() {\n    mut a [int, 3] = {1, 2, 3}\n}", ExampleFile: "integration-tests/fail/errors/E3054_mut_array_with_size.gray"},
+	"E3055":  {Kind: "error", Category: "types", Message: "const arrays must have a fixed size; declare as [T, N] (e.g., const %s [%.*s, %d] = ...)", Example: "do main() {\n    const a [int] = {1, 2, 3}\n}", ExampleFile: "integration-tests/fail/errors/E3055_const_array_no_size.gray"},
+	"E3056":  {Kind: "error", Category: "types", Message: "#strict when is not exhaustive; missing variant '%s.%s'", Example: "\nconst Color enum {\n    RED\n    GREEN\n    BLUE\n}\n\ndo main() {\n    mut c Color = Color.RED\n\n    #strict\n    when c {\n        is Color.RED {\n            println(\"red\")\n        }\n        // Missing GREEN and BLUE\n    }\n}", ExampleFile: "integration-tests/fail/errors/E3056_strict_when_missing_cases.gray"},
+	"E3057":  {Kind: "error", Category: "types", Message: "type '%s' cannot be used as a map key; only primitive types (int, string, bool, char, byte, float) and enums are hashable", Example: "const Point struct {\n    x int\n    y int\n}\n\ndo main() {\n    mut m map[Point:string] = {:}\n}", ExampleFile: "integration-tests/fail/errors/E3057_map_struct_key.gray"},
+	"E3058":  {Kind: "error", Category: "types", Message: "in instantiation of generic function '%s' with '?' = %s", Example: "do max(a ?, b ?) -> ? {\n    if a > b { return a }\n    return b\n}\n\ndo main() {\n    mut s string = max(\"apple\", \"banana\")\n}", ExampleFile: "integration-tests/fail/errors/E3058_wildcard_unsupported_op.gray"},
+	"E3059":  {Kind: "error", Category: "types", Message: "maps cannot be declared const; use 'mut' for maps or a struct for fixed data", Example: "do main() {\n    const m map[string:int] = {\"a\": 1, \"b\": 2}\n    println(\"${m[\"a\"]}\")\n}", ExampleFile: "integration-tests/fail/errors/E3059_const_map_declaration.gray"},
+	"E3060":  {Kind: "error", Category: "types", Message: "wildcard '?' in return type cannot be resolved; at least one parameter must also use '?' to bind the concrete type", Example: "do foo() -> ? {\n    return 10\n}\n\ndo main() {\n    println(\"should not compile\")\n}", ExampleFile: "integration-tests/fail/errors/E3060_wildcard_return_no_wildcard_param.gray"},
+	"E3061":  {Kind: "error", Category: "types", Message: "struct '%s' cannot contain itself by value through '%s'; break the cycle with a pointer field '^%s'", Example: "const Node struct {\n    next ^Node\n    self Node\n}\n\ndo main() {\n}", ExampleFile: "integration-tests/fail/errors/E3061_self_reference_with_valid_pointer_sibling.gray"},
+	"E3062":  {Kind: "error", Category: "types", Message: "%s cannot be declared const; use 'mut' (every operation on a %s mutates its state)", Example: "import @sync\n\ndo main() {\n    const m = sync.mutex()\n}", ExampleFile: "integration-tests/fail/errors/E3062_const_mutex.gray"},
+	"E3063":  {Kind: "error", Category: "types", Message: "cannot return addr(%s); '%s' is a local variable whose memory is freed when this function returns", Example: "do bad() -> ^int {\n    mut x int = 42\n    return addr(x)\n}\n\ndo main() {\n    mut p ^int = bad()\n    println(p^)\n}", ExampleFile: "integration-tests/fail/errors/E3063_addr_local_in_return.gray"},
+	"E3064":  {Kind: "error", Category: "types", Message: "%s(%s) called again; '%s' was already destroyed", Example: "import @mem\n\ndo main() {\n    mut a = mem.arena(1024)\n    mem.destroy(a)\n    mem.destroy(a)\n}", ExampleFile: "integration-tests/fail/errors/E3064_double_arena_destroy.gray"},
+	"E3066":  {Kind: "error", Category: "types", Message: "function reference signature mismatch; expected and actual function types differ", Example: "const Handler struct {\n    callback func(int) -> int\n}\n\ndo takes_float(n float) -> int { return 0 }\n\ndo main() {\n    mut h Handler\n    h.callback = ()takes_float\n}", ExampleFile: "integration-tests/fail/errors/E3066_struct_field_func_sig_mismatch.gray"},
+	"E3067":  {Kind: "error", Category: "types", Message: "argument %d of '%s' is passed to a '&' parameter; pass a mutable variable, not a literal or expression", Example: "", ExampleFile: ""},
+	"E3068":  {Kind: "error", Category: "types", Message: "'void' is not a user-facing type; omit the '-> R' clause to declare a function with no return value", Example: "do work() -> (int, int) { return 1, 2 }\n\ndo main() {\n    mut f func() -> (void, int) = ()work\n    f\n}", ExampleFile: "integration-tests/fail/errors/E3068_void_in_func_return.gray"},
+	"E3069":  {Kind: "error", Category: "types", Message: "'&' on a parameter must come before the name, not the type; write '&%s %s' to mark this parameter mutable", Example: "do bump(x &int) { x = x + 1 }\n\ndo main() {}", ExampleFile: "integration-tests/fail/errors/E3069_inverted_amp_param.gray"},
+	"E3070":  {Kind: "error", Category: "types", Message: "'ensure' may only appear at the top level of a function body; lift it out of the enclosing block", Example: "do cleanup() { println(\"cleanup\") }\n\ndo main() {\n    for i in range(0, 3) {\n        ensure cleanup()\n    }\n}", ExampleFile: "integration-tests/fail/errors/E3070_ensure_in_for.gray"},
+	"E3071":  {Kind: "error", Category: "types", Message: "cannot 'return nil' from a function whose return type contains '?'; 'nil' is not a valid value for every binding (e.g. int, string)", Example: "do identity(x ?) -> ? {\n    return nil\n}\n\ndo main() {}", ExampleFile: "integration-tests/fail/errors/E3071_return_nil_wildcard.gray"},
+	"E3072":  {Kind: "error", Category: "types", Message: "cannot return 'nil' from a function that returns '%s'; nil is only valid for pointer and error types", Example: "", ExampleFile: ""},
+	"E3073":  {Kind: "error", Category: "types", Message: "'return' is not allowed in main(); main exits when control reaches the closing brace", Example: "do main() {\n    return\n}", ExampleFile: "integration-tests/fail/errors/E3073_return_in_main.gray"},
+	"E3074":  {Kind: "error", Category: "types", Message: "arrays cannot be compared with comparison operators; use arrays.is_equal(a, b) for equality, or compare elements individually for ordering", Example: "do main() {\n    mut a [int] = {1, 2, 3}\n    mut b [int] = {1, 2, 3}\n    if a == b {\n        println(\"equal\")\n    }\n}", ExampleFile: "integration-tests/fail/errors/E3074_array_compared_with_eq.gray"},
+	"E3075":  {Kind: "error", Category: "types", Message: "chained struct function calls are not supported; assign the intermediate result to a variable, then call the next struct function on it", Example: "", ExampleFile: ""},
+	"E3076":  {Kind: "error", Category: "types", Message: "maps cannot be compared with comparison operators; use maps.is_equal(a, b) for equality (maps have no defined ordering)", Example: "", ExampleFile: ""},
+	"E3077":  {Kind: "error", Category: "types", Message: "structs cannot be compared with comparison operators; compare individual fields instead (e.g., a.x == b.x, a.x < b.x)", Example: "", ExampleFile: ""},
+	"E3078":  {Kind: "error", Category: "types", Message: "pointer arithmetic is not supported; '^T' is the address of one value, not a buffer", Example: "", ExampleFile: ""},
+	"E3079":  {Kind: "error", Category: "types", Message: "cannot take a mutable reference to a const variable; declare the reference as 'const', or copy() the value to get an independent mutable instance", Example: "", ExampleFile: ""},
+	"E3080":  {Kind: "error", Category: "types", Message: "function must return named variable '%s', not a different expression", Example: "", ExampleFile: ""},
+	"E3081":  {Kind: "error", Category: "types", Message: "function '%s' used as a statement without being called; did you mean '%s()'?", Example: "", ExampleFile: ""},
+	"E3082":  {Kind: "error", Category: "types", Message: "wildcard type '?' cannot be used in named return positions; use an unnamed return instead", Example: "do first_and_len(arr [?]) -> (first ?, count int) {\n    return arr[0], len(arr)\n}\n\ndo main() {\n    mut v int, n int = first_and_len({10, 20, 30})\n    println(v)\n}", ExampleFile: "integration-tests/fail/errors/E3082_wildcard_named_return.gray"},
+	"E3083":  {Kind: "error", Category: "types", Message: "c_string() requires a raw C pointer; cannot convert a non-pointer type", Example: "do main() {\n    mut msg = c_string(\"hello\")\n}", ExampleFile: "integration-tests/fail/errors/E3083_c_string_non_pointer.gray"},
+	"E3084":  {Kind: "error", Category: "types", Message: "type_of() expects a value, not a type name; use type_of(instance) instead", Example: "const Point struct {\n    x int\n    y int\n}\n\ndo main() {\n    println(type_of(Point))\n}", ExampleFile: "integration-tests/fail/errors/E3084_type_of_type_name.gray"},
+	"E3085":  {Kind: "error", Category: "types", Message: "'in' operator type mismatch: cannot check if '%s' is in '%s'", Example: "do main() {\n    mut s string = \"hello\"\n    if 42 in s {\n        println(\"found\")\n    }\n}", ExampleFile: "integration-tests/fail/errors/E3085_in_type_mismatch_string.gray"},
+	"E3086":  {Kind: "error", Category: "types", Message: "fmt.%s format string must be a string literal; use string interpolation for dynamic values", Example: "", ExampleFile: ""},
+	"E3087":  {Kind: "error", Category: "types", Message: "%%n is not permitted in fmt format strings", Example: "", ExampleFile: ""},
+	"E3088":  {Kind: "error", Category: "types", Message: "fmt.%s format directive '%%%s' expects %s but argument %d has type '%s'", Example: "", ExampleFile: ""},
+	"E3089":  {Kind: "error", Category: "usage", Message: "'%s()' can fail; use 'mut val, err = %s()' to handle the error, or 'mut val, _ = %s()' to discard it", Example: "import @io\n\ndo main() {\n    mut content = io.read_file(\"/tmp/test.txt\")\n    println(content)\n}", ExampleFile: "integration-tests/fail/errors/E3089_fallible_unhandled.gray"},
+	"E3090":  {Kind: "error", Category: "types", Message: "'!' only works on bool; got '%s'", Example: "do main() {\n    mut s string = \"hello\"\n    mut r bool = !s\n    println(r)\n}", ExampleFile: "integration-tests/fail/errors/E3090_not_on_string.gray"},
+	"E3091":  {Kind: "error", Category: "types", Message: "'%s' cannot be used as a condition", Example: "do main() {\n    mut s string = \"hello\"\n    if s { println(\"truthy\") }\n}", ExampleFile: "integration-tests/fail/errors/E3091_string_as_if_condition.gray"},
+	"E3092":  {Kind: "error", Category: "types", Message: "cannot compare '%s' to nil; only Error types and pointers can be nil", Example: "do main() {\n    mut x int = 5\n    if x == nil { println(\"nil\") }\n}", ExampleFile: "integration-tests/fail/errors/E3092_int_nil_compare.gray"},
+	"E3093":  {Kind: "error", Category: "types", Message: "cannot use '%s' on '%s'", Example: "do main() {\n    mut a [int] = {1, 2}\n    mut b [int] = {3, 4}\n    mut c = a + b\n    println(c)\n}", ExampleFile: "integration-tests/fail/errors/E3093_array_arithmetic.gray"},
+	"E3094":  {Kind: "error", Category: "types", Message: "cannot assign '%s' to element of '%s'", Example: "do main() {\n    mut arr [int] = {1, 2, 3}\n    arr[0] = \"hello\"\n    println(arr[0])\n}", ExampleFile: "integration-tests/fail/errors/E3094_array_index_wrong_type.gray"},
+	"E3095":  {Kind: "error", Category: "types", Message: "'in' only works with arrays, maps, and strings; got '%s'", Example: "", ExampleFile: ""},
+	"E3096":  {Kind: "error", Category: "types", Message: "cannot negate unsigned type '%s'; negation of unsigned types is not defined", Example: "do main() {\n    mut a u8 = 5\n    mut b u8 = -a\n    println(b)\n}", ExampleFile: "integration-tests/fail/errors/E3096_negate_u8.gray"},
+	"E3097":  {Kind: "error", Category: "safety", Message: "pointer '%s' assigned address of inner-scope variable '%s'; the variable's memory is freed when the scope exits", Example: "do main() {\n    mut p ^int = nil\n    for i in range(3) {\n        mut x int = i\n        p = addr(x)\n    }\n    println(p^)\n}", ExampleFile: "integration-tests/fail/errors/E3097_dangling_addr_loop.gray"},
+	"E3098":  {Kind: "error", Category: "types", Message: "type mismatch: cannot assign '%s' to '%s' through pointer dereference", Example: "const Vec2 struct {\n    x int\n    y int\n}\n\nconst Vec3 struct {\n    x int\n    y int\n    z int\n}\n\ndo main() {\n    mut v2 = new(Vec2)\n    mut v3 = new(Vec3)\n    v3^ = v2^\n}", ExampleFile: "integration-tests/fail/errors/E3098_struct_mismatch_pointer_deref.gray"},
+	"E3099":  {Kind: "error", Category: "types", Message: "'%s' is a reserved stdlib type name and cannot be used as a struct name", Example: "const Router struct {\n    name string\n}\n\ndo main() {\n    mut r = new(Router)\n    println(r.name)\n}", ExampleFile: "integration-tests/fail/errors/E3099_reserved_stdlib_struct_name.gray"},
+	"E3100":  {Kind: "error", Category: "types", Message: "type name '%s' cannot be used as a value", Example: "", ExampleFile: ""},
+	"E3101":  {Kind: "error", Category: "types", Message: "func reference variables must be declared with 'const', not 'mut'; func references are compile-time aliases", Example: "do double(n int) -> int { return n * 2 }\n\ndo main() {\n    mut f func(int) -> int = ()double\n    mut g = ()double\n}", ExampleFile: "integration-tests/fail/errors/E3101_mut_func_ref.gray"},
+	"E3102":  {Kind: "error", Category: "types", Message: "function '%s' returns a func type; func references cannot be assigned from function return values. Use '()func_name' or 'ref(func_name)' to create a func reference", Example: "do double(n int) -> int { return n * 2 }\ndo get_fn() -> func(int) -> int { return ()double }\ndo main() {\n    const f = get_fn()\n    println(f(5))\n}", ExampleFile: "integration-tests/fail/errors/E3102_func_return_assigned_to_var.gray"},
+	"E3103":  {Kind: "error", Category: "types", Message: "#json struct '%s' cannot have func-typed field '%s'; func references have no JSON representation", Example: "do double(n int) -> int { return n * 2 }\n\n#json\nconst Wrapper struct {\n    value int\n    transform func(int) -> int\n}\n\ndo main() {\n    mut w = Wrapper{value: 10, transform: ()double}\n}", ExampleFile: "integration-tests/fail/errors/E3103_json_struct_func_field.gray"},
+	"E3104":  {Kind: "error", Category: "types", Message: "#json struct '%s' cannot declare functions; #json structs are data-only — move '%s' to a standalone function", Example: "#json\nconst User struct {\n    name string\n    age  int\n\n    do greet() {\n        println(\"hi\")\n    }\n}\n\ndo main() {}", ExampleFile: "integration-tests/fail/errors/E3104_json_struct_has_func.gray"},
+	"E3105":  {Kind: "error", Category: "types", Message: "fmt.%s: unknown format directive '%%%c'", Example: "import @fmt\n\ndo main() {\n    mut x int = 5\n    fmt.printf(\"%p\\n\", x)\n}", ExampleFile: "integration-tests/fail/errors/E3105_unknown_directive_p.gray"},
+	"E3106":  {Kind: "error", Category: "types", Message: "fmt.%s: dangling '%%' at end of format string", Example: "import @fmt\n\ndo main() {\n    fmt.printf(\"hello %\")\n}", ExampleFile: "integration-tests/fail/errors/E3106_dangling_percent_after_text.gray"},
+	"E3107":  {Kind: "error", Category: "types", Message: "fmt.%s: format string has %d directive(s) but %d argument(s) were passed (too few)", Example: "import @fmt\n\ndo main() {\n    fmt.printf(\"%d %s %f\\n\")\n}", ExampleFile: "integration-tests/fail/errors/E3107_zero_args_for_directive.gray"},
+	"E3108":  {Kind: "error", Category: "types", Message: "fmt.%s: format string has %d directive(s) but %d argument(s) were passed (too many)", Example: "import @fmt\n\ndo main() {\n    fmt.printf(\"hello\\n\", 42)\n}", ExampleFile: "integration-tests/fail/errors/E3108_no_directives_with_args.gray"},
+	"E3109":  {Kind: "error", Category: "types", Message: "#json struct '%s' cannot have default field values; field '%s' has a default", Example: "#json\nconst Settings struct {\n    name string = \"default\"\n    value int = 42\n}\n\ndo main() {\n    mut s = new(Settings)\n    println(s^.name)\n}", ExampleFile: "integration-tests/fail/errors/E3109_json_struct_field_default.gray"},
+	"E3110":  {Kind: "error", Category: "types", Message: "implicit enum selector '.%s' requires type context; use the full form 'EnumName.%s' or add a type annotation", Example: "const Color enum {\n    RED\n    GREEN\n    BLUE\n}\n\ndo main() {\n    mut x = .RED\n}", ExampleFile: "integration-tests/fail/errors/E3110_implicit_enum_no_context.gray"},
+	"E3111":  {Kind: "error", Category: "types", Message: "payload types are not allowed on string enum variants", Example: "", ExampleFile: ""},
+	"E3112":  {Kind: "error", Category: "types", Message: "payload types are not allowed on #flags enum variants", Example: "", ExampleFile: ""},
+	"E3113":  {Kind: "error", Category: "types", Message: "variant '%s' of enum '%s' expects %d payload value(s), got %d", Example: "", ExampleFile: ""},
+	"E3114":  {Kind: "error", Category: "types", Message: "variant '%s' of enum '%s' has no payload; remove the arguments", Example: "", ExampleFile: ""},
+	"E3115":  {Kind: "error", Category: "types", Message: "enum '%s' is not a tagged enum; variant '%s' cannot be called", Example: "", ExampleFile: ""},
+	"E3116":  {Kind: "error", Category: "types", Message: "wrong number of bindings for variant '%s'; expected %d, got %d", Example: "", ExampleFile: ""},
+	"E3117":  {Kind: "error", Category: "types", Message: "cannot compare enum '%s' with %s; use an enum variant like '%s.VARIANT', or cast to int with cast(value, int)", Example: "const Color enum {\n    RED\n    GREEN\n    BLUE\n}\n\ndo main() {\n    mut c Color = .RED\n    mut x int = 0\n    if c == x { println(\"bad\") }\n}", ExampleFile: "integration-tests/fail/errors/E3117_enum_int_comparison.gray"},
+	"E3118":  {Kind: "error", Category: "types", Message: "cannot assign %s to enum '%s'; use an enum variant like '%s.VARIANT'", Example: "const Color enum {\n    RED\n    GREEN\n    BLUE\n}\n\ndo main() {\n    mut c Color = .RED\n    c = 1\n}", ExampleFile: "integration-tests/fail/errors/E3118_int_to_enum_assign.gray"},
+	"E3119":  {Kind: "error", Category: "types", Message: "fixed-size arrays are not allowed in function parameters; use '[%s]' instead of '%s' for parameter '%s'", Example: "do take_ints(arr [int, 5]) {\n    println(\"bad\")\n}\n\ndo take_strings(arr [string, 2]) {\n    println(\"bad\")\n}\n\ndo main() { }", ExampleFile: "integration-tests/fail/errors/E3119_sized_array_in_param.gray"},
+	"E3120":  {Kind: "error", Category: "types", Message: "pointer ordering comparisons are not supported; only == and != are allowed on pointers", Example: "do main() {\n    mut x int = 10\n    mut y int = 20\n    mut px ^int = addr(x)\n    mut py ^int = addr(y)\n    if px < py { println(\"less\") }\n}", ExampleFile: "integration-tests/fail/errors/E3120_pointer_ordering_comparison.gray"},
+	"E3121":  {Kind: "error", Category: "types", Message: "cannot use '%s' as a condition in a when statement; allowed types are int, uint, string, char, byte, bool, float, and enum", Example: "do main() {\n    mut arr [int] = {1, 2, 3}\n    when arr {\n        default { println(\"default\") }\n    }\n}", ExampleFile: "integration-tests/fail/errors/E3121_when_array_subject.gray"},
+	"E3122":  {Kind: "error", Category: "safety", Message: "cannot take the address of const '%s'; addr() on an immutable variable would allow mutation through the pointer", Example: "do main() {\n    const x int = 5\n    mut p ^int = addr(x)\n}", ExampleFile: "integration-tests/fail/errors/E3122_addr_const_int.gray"},
+	"E3123":  {Kind: "error", Category: "iteration", Message: "for_each with both positions discarded accesses nothing; use 'for _ in range(0, len(collection))' to iterate by count", Example: "do main() {\n    mut items [int] = {1, 2, 3}\n    mut count int = 0\n    for_each _, _ in items {\n        count++\n    }\n    println(count)\n}", ExampleFile: "integration-tests/fail/errors/E3123_foreach_both_blank.gray"},
+	"E3124":  {Kind: "error", Category: "types", Message: "operator '%s' is not defined for tagged enum '%s'; tagged enums carry payloads and cannot be compared with == or !=", Example: "const Shape enum {\n    Circle(float)\n    Rect(float, float)\n    Point\n}\n\ndo main() {\n    mut s1 Shape = Shape.Circle(3.14)\n    mut s2 Shape = Shape.Circle(3.14)\n    if s1 == s2 {\n        println(\"equal\")\n    }\n}", ExampleFile: "integration-tests/fail/errors/E3124_tagged_enum_equality.gray"},
+	"E3125":  {Kind: "error", Category: "types", Message: "'%s' is not a compile-time integer constant; array size must be a const int/uint value", Example: "", ExampleFile: ""},
+	"E3126":  {Kind: "error", Category: "types", Message: "array size must be greater than zero; '%s' resolves to %d", Example: "const NEG i8 = -1\nconst arr [string, NEG] = {\"a\"}", ExampleFile: "integration-tests/fail/errors/E3126_negative_array_size.gray"},
+	"E3127":  {Kind: "error", Category: "types", Message: "type parameter expects a struct type name, but '%s' is not a struct; only struct types can be passed as type arguments", Example: "", ExampleFile: ""},
+	"E3128":  {Kind: "error", Category: "types", Message: "type parameter expects a struct type name, but got a non-type expression; pass a struct type name like 'MyStruct'", Example: "", ExampleFile: ""},
+	"E3129":  {Kind: "error", Category: "safety", Message: "empty loop body; this will loop forever at runtime", Example: "", ExampleFile: ""},
+	"E3130":  {Kind: "error", Category: "types", Message: "bare 'func' is not allowed as a struct field type", Example: "const Foobar struct {\n    name string\n    callback func\n}\n\ndo main() {\n    println(\"unreachable\")\n}", ExampleFile: "integration-tests/fail/errors/E3130_bare_func_struct_field.gray"},
+	"E4001":  {Kind: "error", Category: "names", Message: "this variable does not exist; check the spelling or make sure it is declared above this line", Example: "do main() {\n    c.puts(\"no import\")\n}", ExampleFile: "integration-tests/fail/errors/E4001_c_interop_no_import.gray"},
+	"E4002":  {Kind: "error", Category: "names", Message: "this function does not exist; check the spelling or make sure it is defined", Example: "do main() {\n    nonexistent_func()  // Function doesn't exist\n}", ExampleFile: "integration-tests/fail/errors/E4002_undefined_function.gray"},
+	"E4003":  {Kind: "error", Category: "names", Message: "variable '%s' already declared in this scope (line %d)", Example: "do main() {\n    mut x int = 5\n    mut x int = 10  // duplicate declaration\n}", ExampleFile: "integration-tests/fail/errors/E4003_duplicate_declaration.gray"},
+	"E4004":  {Kind: "error", Category: "names", Message: "function '%s' already declared", Example: "", ExampleFile: ""},
+	"E4005":  {Kind: "error", Category: "names", Message: "module '%s' has no function named '%s'", Example: "do helper() {\n    println(\"I'm not main!\")\n}", ExampleFile: "integration-tests/fail/errors/E4005_no_main.gray"},
+	"E4006":  {Kind: "error", Category: "names", Message: "name '%s' uses reserved prefix (gray_, _gray_, Gray); these are reserved for the compiler", Example: "do main() {\n    mut gray_value int = 42\n    println(\"${gray_value}\")\n}", ExampleFile: "integration-tests/fail/errors/E4006_reserved_prefix_ez_.gray"},
+	"E4007":  {Kind: "error", Category: "names", Message: "a type with this name already exists; each struct and enum must have a unique name", Example: "const Point struct {\n    x int\n    y int\n}\n\nconst Point struct {\n    a float\n    b float\n}\n\ndo main() {\n    mut p = new(Point)\n}", ExampleFile: "integration-tests/fail/errors/E4007_duplicate_struct_name.gray"},
+	"E4008":  {Kind: "error", Category: "names", Message: "main() cannot have parameters or a return type; it must be declared as do main() { }", Example: "do main() -> int {\n    return 0\n}", ExampleFile: "integration-tests/fail/errors/E4008_main_with_return_type.gray"},
+	"E4012":  {Kind: "error", Category: "names", Message: "variable '%s' shadows a type definition with the same name", Example: "", ExampleFile: ""},
+	"E4013":  {Kind: "error", Category: "names", Message: "variable '%s' shadows a function with the same name", Example: "do helper() { println(\"hi\") }\n\ndo main() {\n    mut helper = \"shadowed\"\n}", ExampleFile: "integration-tests/fail/errors/E4013_shadows_function.gray"},
+	"E4014":  {Kind: "error", Category: "names", Message: "variable '%s' shadows an imported module with the same name", Example: "", ExampleFile: ""},
+	"E4015":  {Kind: "error", Category: "names", Message: "'%s' is private and cannot be accessed from outside its file", Example: "", ExampleFile: ""},
+	"E4016":  {Kind: "error", Category: "names", Message: "undefined type '%s'; check the spelling or import the module that defines it", Example: "do main() {\n    mut x FooBar = 5  // FooBar is not defined\n}", ExampleFile: "integration-tests/fail/errors/E4016_undefined_type.gray"},
+	"E4017":  {Kind: "error", Category: "names", Message: "function '%s.%s' is private and cannot be called from outside the struct", Example: "const Calc struct {\n    value int\n\n    private do secret(n int) -> int {\n        return n * 99\n    }\n}\n\ndo main() {\n    const f = ()Calc.secret\n    println(f(2))\n}", ExampleFile: "integration-tests/fail/errors/E4017_funcref_private_struct_fn.gray"},
+	"E4018":  {Kind: "error", Category: "names", Message: "struct '%s' has no function named '%s'", Example: "const Point struct {\n    x int\n    y int\n}\n\ndo main() {\n    Point.doesnt_exist(1, 2, 3)\n}", ExampleFile: "integration-tests/fail/errors/E4018_nonexistent_struct_func.gray"},
+	"E4019":  {Kind: "error", Category: "names", Message: "cannot take a function reference to '%s'; builtin and stdlib functions are not first-class values", Example: "do main() {\n    const f = ()println\n}", ExampleFile: "integration-tests/fail/errors/E4019_funcref_builtin.gray"},
+	"E5007":  {Kind: "error", Category: "usage", Message: "cannot modify immutable %s '%s'; declare with 'mut' to allow modification", Example: "import @maps\n\ndo main() {\n    const m map[string:int] = {\"a\": 1}\n    maps.clear(m)\n}", ExampleFile: "integration-tests/fail/errors/E5007_const_map_clear.gray"},
+	"E5008":  {Kind: "error", Category: "arguments", Message: "wrong number of arguments; the function expects a different count than was provided", Example: "do main() {\n    println(len())\n}", ExampleFile: "integration-tests/fail/errors/E5008_len_zero_args.gray"},
+	"E5009":  {Kind: "error", Category: "arguments", Message: "invalid base for integer conversion; base must be between 2 and 36", Example: "import @strconv\n\ndo main() {\n    mut x int = strconv.to_int(\"42\", 1)\n}", ExampleFile: "integration-tests/fail/errors/E5009_invalid_strconv_base.gray"},
+	"E5011":  {Kind: "error", Category: "usage", Message: "return value of '%s' is not used; assign it to a variable or use '_' to discard", Example: "do getValue() -> int {\n    return 42\n}\n\ndo main() {\n    getValue()  // return value not used\n}", ExampleFile: "integration-tests/fail/errors/E5011_return_value_unused.gray"},
+	"E5012":  {Kind: "error", Category: "usage", Message: "the throwaway '_' is only meaningful when discarding the result of a function call; the right-hand side has no return value to discard", Example: "", ExampleFile: ""},
+	"E5013":  {Kind: "error", Category: "usage", Message: "function calls are not allowed in file-scope initializers; move this declaration into a function body", Example: "", ExampleFile: ""},
+	"E5014":  {Kind: "error", Category: "usage", Message: "here() takes no arguments; the call site's file, line, and column are substituted at compile time", Example: "do main() {\n    mut loc SourceLocation = here(42)\n    println(\"${loc.line}\")\n}", ExampleFile: "integration-tests/fail/errors/E5014_here_with_args.gray"},
+	"E5015":  {Kind: "error", Category: "usage", Message: "postfix ++ and -- require a variable, not a value or expression", Example: "do main() {\n    5++  // postfix operator needs variable\n}", ExampleFile: "integration-tests/fail/errors/E5015_postfix_requires_identifier.gray"},
+	"E5016":  {Kind: "error", Category: "naming", Message: "this name is reserved by a builtin function and cannot be redeclared", Example: "do f(here int) {\n    println(\"${here}\")\n}\n\ndo main() {\n    f(1)\n}", ExampleFile: "integration-tests/fail/errors/E5016_here_parameter.gray"},
+	"E5017":  {Kind: "error", Category: "usage", Message: "embed() argument must be a string literal file path, not an expression", Example: "do main() {\n    mut path string = \"config.txt\"\n    const content string = embed(path)\n    println(content)\n}", ExampleFile: "integration-tests/fail/errors/E5017_embed_non_literal.gray"},
+	"E5018":  {Kind: "error", Category: "usage", Message: "embed() cannot open '%s': file not found or unreadable", Example: "const Missing string = embed(\"this_file_does_not_exist.txt\")\n\ndo main() {\n    println(Missing)\n}", ExampleFile: "integration-tests/fail/errors/E5018_embed_file_not_found.gray"},
+	"E5023":  {Kind: "error", Category: "usage", Message: "cannot use '%s' on type '%s'; only integer types support increment/decrement", Example: "do main() {\n    mut x float = 3.14\n    x++  // postfix operator needs integer operand\n}", ExampleFile: "integration-tests/fail/errors/E5023_postfix_requires_integer.gray"},
+	"E5024":  {Kind: "error", Category: "usage", Message: "return type mismatch: cannot return signed '%s' as unsigned '%s'", Example: "do get_value() -> uint {\n    mut x int = -1\n    return x  // Runtime error: int -1 cannot be returned as uint\n}\n\ndo main() {\n    mut val uint = get_value()\n    println(val)\n}", ExampleFile: "integration-tests/fail/errors/E5024_return_type_mismatch.gray"},
+	"E5025":  {Kind: "error", Category: "usage", Message: "invalid assignment target; left side of '=' must be a variable, field, or index expression", Example: "do main() {\n    5 = 10\n}", ExampleFile: "integration-tests/fail/errors/E5025_assign_to_literal.gray"},
+	"E5026":  {Kind: "error", Category: "arguments", Message: "argument type mismatch; the function expects a different type than what was provided", Example: "import @os\n\ndo main() {\n    os.unset_env(42)\n}", ExampleFile: "integration-tests/fail/errors/E5026_os_unset_env_wrong_type.gray"},
+	"E5027":  {Kind: "error", Category: "usage", Message: "embed() path must not escape the source file's directory tree", Example: "", ExampleFile: ""},
+	"E5028":  {Kind: "error", Category: "usage", Message: "func references are not printable values; func references cannot be passed to print functions", Example: "do double(n int) -> int { return n * 2 }\n\ndo main() {\n    const f = ()double\n    println(f)\n}", ExampleFile: "integration-tests/fail/errors/E5028_print_func_ref.gray"},
+	"E5029":  {Kind: "error", Category: "usage", Message: "copy() cannot be used on a func reference; func references are compile-time aliases, not copyable values", Example: "do double(n int) -> int { return n * 2 }\n\ndo main() {\n    const f = ()double\n    const g = copy(f)\n}", ExampleFile: "integration-tests/fail/errors/E5029_copy_func_ref.gray"},
+	"E5030":  {Kind: "error", Category: "usage", Message: "cannot call the return value of '%s' directly; func references must be created with '()func_name' or 'ref(func_name)' before calling", Example: "do double(n int) -> int { return n * 2 }\ndo get_fn() -> func(int) -> int { return ()double }\ndo main() {\n    println(get_fn()(5))\n}", ExampleFile: "integration-tests/fail/errors/E5030_chained_call_on_func_return.gray"},
+	"E5031":  {Kind: "error", Category: "usage", Message: "unknown parameter name '%s' in call to '%s'", Example: "do add(a int, b int) -> int {\n    return a + b\n}\n\ndo main() {\n    mut x int = add(z: 5, b: 10)\n}", ExampleFile: "integration-tests/fail/errors/E5031_unknown_named_arg.gray"},
+	"E5032":  {Kind: "error", Category: "usage", Message: "parameter '%s' is already provided positionally (argument %d) in call to '%s'", Example: "do add(a int, b int) -> int {\n    return a + b\n}\n\ndo main() {\n    mut x int = add(1, a: 5)\n}", ExampleFile: "integration-tests/fail/errors/E5032_named_arg_already_positional.gray"},
+	"E5033":  {Kind: "error", Category: "usage", Message: "positional argument after named argument in call to '%s'", Example: "do add(a int, b int) -> int {\n    return a + b\n}\n\ndo main() {\n    mut x int = add(a: 1, 2)\n}", ExampleFile: "integration-tests/fail/errors/E5033_positional_after_named.gray"},
+	"E5034":  {Kind: "error", Category: "usage", Message: "named arguments are not supported for builtin function '%s'", Example: "do main() {\n    println(value: \"hello\")\n}", ExampleFile: "integration-tests/fail/errors/E5034_named_args_builtin.gray"},
+	"E5035":  {Kind: "error", Category: "naming", Message: "this name is reserved by a standard library module and cannot be redeclared", Example: "", ExampleFile: ""},
+	"E5036":  {Kind: "error", Category: "usage", Message: "'%s' is a type, not a function; use cast(value, %s) to convert", Example: "do main() {\n    mut a = i8(100)\n    mut b = i16(200)\n    mut c = i32(300)\n    mut d = i64(400)\n    mut e = u8(50)\n    mut f = u16(600)\n    mut g = u32(700)\n    mut h = u64(800)\n    mut i = f32(1.5)\n    mut j = f64(2.5)\n}", ExampleFile: "integration-tests/fail/errors/E5036_sized_type_as_function.gray"},
+	"E5037":  {Kind: "error", Category: "usage", Message: "copy() cannot be applied to a pointer; dereference first with copy(p^)", Example: "const Point struct {\n    x int\n}\ndo main() {\n    mut p = new(Point)\n    mut q = copy(p)\n    println(q)\n}", ExampleFile: "integration-tests/fail/errors/E5037_copy_pointer.gray"},
+	"E5038":  {Kind: "error", Category: "usage", Message: "tagged enum '%s' cannot be passed to %s(); use when/is to destructure the payload first", Example: "const Shape enum {\n    Circle(float)\n    Rect(float, float)\n    Point\n}\n\ndo main() {\n    mut s Shape = Shape.Point\n    print(s)\n}", ExampleFile: "integration-tests/fail/errors/E5038_tagged_enum_print.gray"},
+	"E5039":  {Kind: "error", Category: "usage", Message: "constant expression overflows type '%s'", Example: "const MAX int = 9223372036854775807\nconst OVER int = MAX + 1\n\ndo main() {\n    println(OVER)\n}", ExampleFile: "integration-tests/fail/errors/E5039_const_int_overflow.gray"},
+	"E5040":  {Kind: "error", Category: "usage", Message: "constant requires a compile-time value; function calls are evaluated at runtime", Example: "do main() {\n    const foobar = input()\n}", ExampleFile: "integration-tests/fail/errors/E5040_const_runtime_call.gray"},
+	"E6001":  {Kind: "error", Category: "imports", Message: "unknown module '@%s'", Example: "import @nonexistent_module\n\ndo main() {\n    println(\"test\")\n}", ExampleFile: "integration-tests/fail/errors/E6001_module_not_found.gray"},
+	"E6002":  {Kind: "error", Category: "imports", Message: "cannot find file or directory '%s'", Example: "import \"./does_not_exist.gray\"\n\ndo main() {\n    println(\"should not compile\")\n}", ExampleFile: "integration-tests/fail/errors/E6002_import_file_not_found.gray"},
+	"E6003":  {Kind: "error", Category: "imports", Message: "directory '%s' contains no .gray files", Example: "import \"./empty_dir\"\n\ndo main() {\n    println(\"should not compile\")\n}", ExampleFile: "integration-tests/fail/errors/E6003_empty_directory_import.gray"},
+	"E6004":  {Kind: "error", Category: "imports", Message: "cannot import own module directory", Example: "", ExampleFile: ""},
+	"E6008":  {Kind: "error", Category: "imports", Message: "'%s.%s' is a module constant and cannot be assigned to", Example: "import @math\n\ndo main() {\n    math.PI = 3.0  // Trying to modify stdlib constant\n}", ExampleFile: "integration-tests/fail/errors/E6008_module_member_readonly.gray"},
+	"E7004":  {Kind: "error", Category: "stdlib", Message: "function argument must be an integer, not a float", Example: "import @strings\n\ndo main() {\n    mut s string = strings.repeat(\"x\", 3.5)  // float instead of int\n}", ExampleFile: "integration-tests/fail/errors/E7004_requires_integer.gray"},
+	"E7006":  {Kind: "error", Category: "stdlib", Message: "threads.spawn() needs a function reference; use ()function_name to pass a function", Example: "import @threads\nusing threads\n\ndo worker() {\n    println(\"working\")\n}\n\ndo main() {\n    mut t Thread = threads.spawn(worker)\n}", ExampleFile: "integration-tests/fail/errors/E7006_spawn_no_func_ref.gray"},
+	"E7014":  {Kind: "error", Category: "stdlib", Message: "cannot convert %lld to char; value must be a valid Unicode code point (0 or greater)", Example: "do main() {\n    mut c char = char(-1)  // Negative value cannot convert to char\n}", ExampleFile: "integration-tests/fail/errors/E7014_char_negative_int.gray"},
+	"E7015":  {Kind: "error", Category: "stdlib", Message: "len() is not supported for type '%s'; len() works on string, array, and map types", Example: "do main() {\n    mut x int = len(42)  // len not supported for int\n}", ExampleFile: "integration-tests/fail/errors/E7015_len_unsupported_type.gray"},
+	"E9002":  {Kind: "error", Category: "stdlib", Message: "arrays.%s() requires a numeric array, got array of %s", Example: "import @arrays\n\ndo main() {\n    mut arr [string] = {\"a\", \"b\", \"c\"}\n    mut total = arrays.sum(arr)  // sum requires numeric array\n}", ExampleFile: "integration-tests/fail/errors/E9002_array_non_numeric.gray"},
+	"E9003":  {Kind: "error", Category: "stdlib", Message: "arrays.%s() requires a function reference; use ()func_name to pass a function", Example: "import @arrays\n\ndo main() {\n    mut nums [int] = {1, 2, 3}\n    mut result [int] = arrays.map(nums, 42)\n}", ExampleFile: "integration-tests/fail/errors/E9003_arrays_map_not_func_ref.gray"},
+	"E9004":  {Kind: "error", Category: "stdlib", Message: "arrays.%s() callback signature mismatch; %s", Example: "import @arrays\n\ndo not_bool(x int) -> int { return x * 2 }\n\ndo main() {\n    mut nums [int] = {1, 2, 3}\n    mut result [int] = arrays.filter(nums, ()not_bool)\n}", ExampleFile: "integration-tests/fail/errors/E9004_arrays_filter_wrong_return.gray"},
+	"E9005":  {Kind: "error", Category: "stdlib", Message: "invalid range: start (%lld) must be less than end (%lld)", Example: "do main() {\n    for i in range(10, 5) {\n        // This should error - start > end\n    }\n}", ExampleFile: "integration-tests/fail/errors/E9005_range_invalid_bounds.gray"},
+	"E9006":  {Kind: "error", Category: "stdlib", Message: "arrays.contains() does not support arrays of %s; only primitive and string element types are supported", Example: "import @arrays\n\nconst Point struct {\n    x int\n    y int\n}\n\ndo main() {\n    mut pts [Point] = {Point{x: 1, y: 2}, Point{x: 3, y: 4}}\n    mut target Point = Point{x: 1, y: 2}\n    mut found bool = arrays.contains(pts, target)\n    println(\"${found}\")\n}", ExampleFile: "integration-tests/fail/errors/E9006_arrays_contains_struct.gray"},
+	"E12001": {Kind: "error", Category: "stdlib", Message: "maps.%s() requires a map argument, got an array", Example: "import @maps\n\ndo main() {\n    mut m = maps.from_pairs({{1, 2, 3}})  // not a valid pair\n}", ExampleFile: "integration-tests/fail/errors/E12001_map_invalid_pair.gray"},
+	"E12006": {Kind: "error", Category: "stdlib", Message: "duplicate key in map literal", Example: "do main() {\n    mut m map[string:int] = {\n        \"a\": 1,\n        \"b\": 2,\n        \"a\": 3\n    }\n}", ExampleFile: "integration-tests/fail/errors/E12006_map_duplicate_key.gray"},
+	"E12007": {Kind: "error", Category: "stdlib", Message: "maps.contains_value() does not support maps with %s values; only primitive and string value types are supported", Example: "import @maps\n\nconst Point struct {\n    x int\n    y int\n}\n\ndo main() {\n    mut pts map[string:Point] = {\"origin\": Point{x: 0, y: 0}}\n    mut target Point = Point{x: 0, y: 0}\n    mut found bool = maps.contains_value(pts, target)\n    println(\"${found}\")\n}", ExampleFile: "integration-tests/fail/errors/E12007_maps_contains_value_struct.gray"},
+	"E8001":  {Kind: "error", Category: "bitwise", Message: "'%s' can only be used with integers; got '%s' and '%s'", Example: "do main() {\n    mut x float = 1.5\n    mut y float = 2.5\n    mut z = x bit_or y\n}", ExampleFile: "integration-tests/fail/errors/E8001_bit_or_on_float.gray"},
+	"E8002":  {Kind: "error", Category: "bitwise", Message: "'bit_not' can only be used with integers; got '%s'", Example: "do main() {\n    mut x float = 3.14\n    mut z = bit_not x\n}", ExampleFile: "integration-tests/fail/errors/E8002_bit_not_on_float.gray"},
+	"P0001":  {Kind: "panic", Category: "memory", Message: "cannot allocate from a destroyed arena; mem.destroy() was already called on this arena", Example: "", ExampleFile: ""},
+	"P0002":  {Kind: "panic", Category: "memory", Message: "mem.destroy() called on an arena that was already destroyed; each arena can only be destroyed once", Example: "", ExampleFile: ""},
+	"P0003":  {Kind: "panic", Category: "runtime", Message: "maximum recursion depth exceeded (%d calls deep); your function is calling itself too many times", Example: "", ExampleFile: ""},
+	"P0004":  {Kind: "panic", Category: "arithmetic", Message: "addition result is too large; value exceeds the range of int", Example: "do main() {\n    for i in range(9223372036854775806, 9223372036854775807, 2) {\n        println(i)\n    }\n}", ExampleFile: "integration-tests/fail/errors/P0004_range_step_overflow.gray"},
+	"P0005":  {Kind: "panic", Category: "arithmetic", Message: "subtraction result is too large; value exceeds the range of int", Example: "", ExampleFile: ""},
+	"P0006":  {Kind: "panic", Category: "arithmetic", Message: "multiplication result is too large; value exceeds the range of int", Example: "", ExampleFile: ""},
+	"P0007":  {Kind: "panic", Category: "arithmetic", Message: "negation result is too large; value exceeds the range of int", Example: "", ExampleFile: ""},
+	"P0008":  {Kind: "panic", Category: "arithmetic", Message: "addition result is too large; value exceeds the range of uint", Example: "", ExampleFile: ""},
+	"P0009":  {Kind: "panic", Category: "arithmetic", Message: "subtraction result is negative, but uint cannot hold negative values", Example: "", ExampleFile: ""},
+	"P0010":  {Kind: "panic", Category: "arithmetic", Message: "multiplication result is too large; value exceeds the range of uint", Example: "", ExampleFile: ""},
+	"P0011":  {Kind: "panic", Category: "arithmetic", Message: "%s addition result is too large; value exceeds the range of this type", Example: "", ExampleFile: ""},
+	"P0012":  {Kind: "panic", Category: "arithmetic", Message: "%s subtraction result is too large; value exceeds the range of this type", Example: "", ExampleFile: ""},
+	"P0013":  {Kind: "panic", Category: "arithmetic", Message: "%s multiplication result is too large; value exceeds the range of this type", Example: "", ExampleFile: ""},
+	"P0014":  {Kind: "panic", Category: "arithmetic", Message: "%s negation result is too large; value exceeds the range of this type", Example: "", ExampleFile: ""},
+	"P0015":  {Kind: "panic", Category: "arithmetic", Message: "%s addition result is too large; value exceeds the range of this unsigned type", Example: "", ExampleFile: ""},
+	"P0016":  {Kind: "panic", Category: "arithmetic", Message: "%s subtraction result is negative, but this unsigned type cannot hold negative values", Example: "", ExampleFile: ""},
+	"P0017":  {Kind: "panic", Category: "arithmetic", Message: "%s multiplication result is too large; value exceeds the range of this unsigned type", Example: "", ExampleFile: ""},
+	"P0018":  {Kind: "panic", Category: "arithmetic", Message: "cast to %s failed; value %lld is outside the valid range (%lld to %lld)", Example: "do main() {\n    mut u uint = 9223372036854775808\n    mut n int = cast(u, int)\n    println(n)\n}", ExampleFile: "integration-tests/fail/errors/P0018_cast_uint_to_int_overflow.gray"},
+	"P0019":  {Kind: "panic", Category: "arithmetic", Message: "cast to %s failed; value %lld is outside the valid range (0 to %llu)", Example: "do main() {\n    mut n int = -1\n    mut u uint = cast(n, uint)\n    println(u)\n}", ExampleFile: "integration-tests/fail/errors/P0019_cast_int_to_uint_negative.gray"},
+	"P0020":  {Kind: "panic", Category: "arithmetic", Message: "cannot convert float to int; the value is too large, too small, or NaN", Example: "", ExampleFile: ""},
+	"P0021":  {Kind: "panic", Category: "arithmetic", Message: "i128 addition result is too large; value exceeds the range of i128", Example: "", ExampleFile: ""},
+	"P0022":  {Kind: "panic", Category: "arithmetic", Message: "i128 subtraction result is too large; value exceeds the range of i128", Example: "", ExampleFile: ""},
+	"P0023":  {Kind: "panic", Category: "arithmetic", Message: "i128 multiplication result is too large; value exceeds the range of i128", Example: "", ExampleFile: ""},
+	"P0024":  {Kind: "panic", Category: "arithmetic", Message: "u128 addition result is too large; value exceeds the range of u128", Example: "", ExampleFile: ""},
+	"P0025":  {Kind: "panic", Category: "arithmetic", Message: "u128 subtraction result is negative, but u128 cannot hold negative values", Example: "", ExampleFile: ""},
+	"P0026":  {Kind: "panic", Category: "arithmetic", Message: "u128 multiplication result is too large; value exceeds the range of u128", Example: "", ExampleFile: ""},
+	"P0027":  {Kind: "panic", Category: "arithmetic", Message: "i256 addition result is too large; value exceeds the range of i256", Example: "", ExampleFile: ""},
+	"P0028":  {Kind: "panic", Category: "arithmetic", Message: "i256 subtraction result is too large; value exceeds the range of i256", Example: "", ExampleFile: ""},
+	"P0029":  {Kind: "panic", Category: "arithmetic", Message: "i256 multiplication result is too large; value exceeds the range of i256", Example: "", ExampleFile: ""},
+	"P0030":  {Kind: "panic", Category: "arithmetic", Message: "u256 addition result is too large; value exceeds the range of u256", Example: "", ExampleFile: ""},
+	"P0031":  {Kind: "panic", Category: "arithmetic", Message: "u256 subtraction result is negative, but u256 cannot hold negative values", Example: "", ExampleFile: ""},
+	"P0032":  {Kind: "panic", Category: "arithmetic", Message: "u256 multiplication result is too large; value exceeds the range of u256", Example: "", ExampleFile: ""},
+	"P0033":  {Kind: "panic", Category: "bounds", Message: "index out of bounds; tried to access index %d but the length is %d", Example: "", ExampleFile: ""},
+	"P0034":  {Kind: "panic", Category: "iteration", Message: "cannot modify array during for_each iteration", Example: "", ExampleFile: ""},
+	"P0035":  {Kind: "panic", Category: "iteration", Message: "cannot modify map during for_each iteration", Example: "", ExampleFile: ""},
+	"P0036":  {Kind: "panic", Category: "encoding", Message: "encoding.base64_decode: input length %d is not a multiple of 4", Example: "", ExampleFile: ""},
+	"P0037":  {Kind: "panic", Category: "encoding", Message: "encoding.base64_decode: padding character '=' before end of input", Example: "", ExampleFile: ""},
+	"P0038":  {Kind: "panic", Category: "encoding", Message: "encoding.base64_decode: invalid padding", Example: "", ExampleFile: ""},
+	"P0039":  {Kind: "panic", Category: "encoding", Message: "encoding.base64_decode: invalid character in input", Example: "", ExampleFile: ""},
+	"P0040":  {Kind: "panic", Category: "encoding", Message: "encoding.hex_decode: input length %d is not even", Example: "", ExampleFile: ""},
+	"P0041":  {Kind: "panic", Category: "encoding", Message: "encoding.hex_decode: invalid hex character at position %d", Example: "", ExampleFile: ""},
+	"P0042":  {Kind: "panic", Category: "encoding", Message: "encoding.url_decode: invalid percent-escape at position %d", Example: "", ExampleFile: ""},
+	"P0043":  {Kind: "panic", Category: "bounds", Message: "arrays.insert_at: index %d is out of bounds for an array of length %d", Example: "", ExampleFile: ""},
+	"P0044":  {Kind: "panic", Category: "bounds", Message: "arrays.remove_at: index %d is out of bounds for an array of length %d", Example: "", ExampleFile: ""},
+	"P0045":  {Kind: "panic", Category: "bounds", Message: "arrays.get_first called on an empty array", Example: "", ExampleFile: ""},
+	"P0046":  {Kind: "panic", Category: "bounds", Message: "arrays.get_last called on an empty array", Example: "", ExampleFile: ""},
+	"P0047":  {Kind: "panic", Category: "bounds", Message: "arrays.remove_first called on an empty array", Example: "", ExampleFile: ""},
+	"P0048":  {Kind: "panic", Category: "bounds", Message: "arrays.remove_last called on an empty array", Example: "", ExampleFile: ""},
+	"P0049":  {Kind: "panic", Category: "bounds", Message: "to_char() index out of bounds; index %lld is negative", Example: "", ExampleFile: ""},
+	"P0050":  {Kind: "panic", Category: "bounds", Message: "to_char() index out of bounds; index %lld but string has %lld characters", Example: "", ExampleFile: ""},
+	"P0051":  {Kind: "panic", Category: "crypto", Message: "crypto.random_hex: length must be non-negative (got %lld)", Example: "", ExampleFile: ""},
+	"P0052":  {Kind: "panic", Category: "crypto", Message: "crypto.random_hex: failed to read from /dev/urandom", Example: "", ExampleFile: ""},
+	"P0053":  {Kind: "panic", Category: "io", Message: "io.read_file: input exceeds maximum string length", Example: "", ExampleFile: ""},
+	"P0054":  {Kind: "panic", Category: "strconv", Message: "strconv.to_int: invalid base %d; must be between 2 and 36", Example: "", ExampleFile: ""},
+	"P0055":  {Kind: "panic", Category: "strconv", Message: "strconv.to_int: cannot convert '%s' to int (base %d)", Example: "", ExampleFile: ""},
+	"P0056":  {Kind: "panic", Category: "strconv", Message: "strconv.to_uint: invalid base %d; must be between 2 and 36", Example: "", ExampleFile: ""},
+	"P0057":  {Kind: "panic", Category: "strconv", Message: "strconv.to_uint: cannot convert '%s' to uint (base %d)", Example: "", ExampleFile: ""},
+	"P0058":  {Kind: "panic", Category: "strconv", Message: "strconv.to_uint: cannot convert '%s' to uint; value is negative", Example: "", ExampleFile: ""},
+	"P0059":  {Kind: "panic", Category: "strconv", Message: "strconv.to_float: cannot convert '%s' to float", Example: "", ExampleFile: ""},
+	"P0060":  {Kind: "panic", Category: "strconv", Message: "strconv.to_bool: cannot convert '%s' to bool", Example: "", ExampleFile: ""},
+	"P0061":  {Kind: "panic", Category: "memory", Message: "mem.arena() size %lld bytes exceeds the maximum allowed size of 1 GB", Example: "", ExampleFile: ""},
+	"P0062":  {Kind: "panic", Category: "random", Message: "random.sample() count %d exceeds array length %d", Example: "", ExampleFile: ""},
+	"P0063":  {Kind: "panic", Category: "random", Message: "random.sample() count cannot be negative (%d)", Example: "", ExampleFile: ""},
+	"P0064":  {Kind: "panic", Category: "math", Message: "math.sqrt() requires a non-negative number, got %g", Example: "", ExampleFile: ""},
+	"P0065":  {Kind: "panic", Category: "math", Message: "math.log() requires a positive number, got %g", Example: "", ExampleFile: ""},
+	"P0066":  {Kind: "panic", Category: "math", Message: "math.log2() requires a positive number, got %g", Example: "", ExampleFile: ""},
+	"P0067":  {Kind: "panic", Category: "math", Message: "math.log10() requires a positive number, got %g", Example: "", ExampleFile: ""},
+	"P0068":  {Kind: "panic", Category: "math", Message: "math.asin() requires value in [-1, 1], got %g", Example: "", ExampleFile: ""},
+	"P0069":  {Kind: "panic", Category: "math", Message: "math.acos() requires value in [-1, 1], got %g", Example: "", ExampleFile: ""},
+	"P0070":  {Kind: "panic", Category: "math", Message: "math.factorial() requires a non-negative integer, got %lld", Example: "", ExampleFile: ""},
+	"P0071":  {Kind: "panic", Category: "strings", Message: "strings.replace() result exceeds maximum string length", Example: "", ExampleFile: ""},
+	"P0072":  {Kind: "panic", Category: "strings", Message: "strings.repeat() count cannot be negative (%lld)", Example: "", ExampleFile: ""},
+	"P0073":  {Kind: "panic", Category: "strings", Message: "strings.repeat() result exceeds maximum string length", Example: "", ExampleFile: ""},
+	"P0074":  {Kind: "panic", Category: "uuid", Message: "uuid.parse: invalid UUID string", Example: "", ExampleFile: ""},
+	"P0075":  {Kind: "panic", Category: "runtime", Message: "assertion failed", Example: "do main() {\n    assert(false)\n}", ExampleFile: "integration-tests/fail/errors/P0075_assert_no_message.gray"},
+	"P0076":  {Kind: "panic", Category: "runtime", Message: "panic", Example: "", ExampleFile: ""},
+	"P0077":  {Kind: "panic", Category: "io", Message: "io.delete_file() cannot delete a directory; use io.remove_dir() for directories", Example: "", ExampleFile: ""},
+	"P0078":  {Kind: "panic", Category: "arithmetic", Message: "division by zero", Example: "", ExampleFile: ""},
+	"P0079":  {Kind: "panic", Category: "arithmetic", Message: "%s result is too large; value exceeds the range of this type", Example: "", ExampleFile: ""},
+	"P0080":  {Kind: "panic", Category: "runtime", Message: "nil pointer dereference", Example: "", ExampleFile: ""},
+	"P0081":  {Kind: "panic", Category: "runtime", Message: "key not found in map", Example: "", ExampleFile: ""},
+	"P0082":  {Kind: "panic", Category: "bounds", Message: "string index %d out of bounds (length %d)", Example: "", ExampleFile: ""},
+	"P0083":  {Kind: "panic", Category: "runtime", Message: "sleep duration cannot be negative (%lld)", Example: "", ExampleFile: ""},
+	"P0084":  {Kind: "panic", Category: "runtime", Message: "cannot convert '%s' to int", Example: "", ExampleFile: ""},
+	"P0085":  {Kind: "panic", Category: "runtime", Message: "cannot convert '%s' to float", Example: "", ExampleFile: ""},
+	"P0086":  {Kind: "panic", Category: "io", Message: "io.read_file() cannot read a directory; use io.list_dir() or io.walk() to list directory contents", Example: "", ExampleFile: ""},
+	"P0087":  {Kind: "panic", Category: "io", Message: "io.write_file() cannot write to a directory", Example: "", ExampleFile: ""},
+	"P0088":  {Kind: "panic", Category: "io", Message: "io.append_file() cannot append to a directory", Example: "", ExampleFile: ""},
+	"P0089":  {Kind: "panic", Category: "io", Message: "io.copy_file() cannot copy a directory; use io.walk() to enumerate files and copy them individually", Example: "", ExampleFile: ""},
+	"P0090":  {Kind: "panic", Category: "runtime", Message: "range step cannot be zero", Example: "do main() {\n    for i in range(0, 10, 0) {\n        println(i)\n    }\n}", ExampleFile: "integration-tests/fail/errors/P0090_range_step_zero.gray"},
+	"P0091":  {Kind: "panic", Category: "arithmetic", Message: "cannot convert float to uint; the value is negative, too large, or NaN", Example: "do main() {\n    println(cast(-1.0, u64))\n}", ExampleFile: "integration-tests/fail/errors/P0091_cast_negative_float_to_u64.gray"},
+	"P0092":  {Kind: "panic", Category: "arithmetic", Message: "shift amount %lld is out of range; must be in [0, 63]", Example: "do main() {\n    mut amount int = -1\n    mut result int = 1 bit_shift_left amount\n    println(result)\n}", ExampleFile: "integration-tests/fail/errors/P0092_shift_amount_out_of_range.gray"},
+	"P0093":  {Kind: "panic", Category: "arithmetic", Message: "cast from i128 failed; value is outside the representable range of int64", Example: "", ExampleFile: ""},
+	"P0094":  {Kind: "panic", Category: "arithmetic", Message: "cast from i128 failed; value is negative or outside the representable range of uint64", Example: "", ExampleFile: ""},
+	"P0095":  {Kind: "panic", Category: "arithmetic", Message: "cast from u128 failed; value exceeds the representable range of int64", Example: "", ExampleFile: ""},
+	"P0096":  {Kind: "panic", Category: "arithmetic", Message: "cast from u128 failed; value exceeds the representable range of uint64", Example: "", ExampleFile: ""},
+	"P0097":  {Kind: "panic", Category: "arithmetic", Message: "cast from i256 failed; value is outside the representable range of int64", Example: "", ExampleFile: ""},
+	"P0098":  {Kind: "panic", Category: "arithmetic", Message: "cast from i256 failed; value is negative or outside the representable range of uint64", Example: "", ExampleFile: ""},
+	"P0099":  {Kind: "panic", Category: "arithmetic", Message: "cast from u256 failed; value exceeds the representable range of int64", Example: "", ExampleFile: ""},
+	"P0100":  {Kind: "panic", Category: "arithmetic", Message: "cast from u256 failed; value exceeds the representable range of uint64", Example: "", ExampleFile: ""},
+	"P0101":  {Kind: "panic", Category: "server", Message: "server.cors: origin contains CR or LF — HTTP header injection is not allowed", Example: "import @server\n\ndo home(req HttpRequest) -> HttpResponse {\n    return server.text(200, \"hello\")\n}\n\ndo main() {\n    mut r = server.add_router()\n    server.cors(r, \"evil.com\\r\\nX-Admin: true\")\n    server.add_route(r, \"GET\", \"/\", ()home)\n}", ExampleFile: "integration-tests/fail/errors/P0101_cors_crlf_injection.gray"},
+	"W1001":  {Kind: "warning", Category: "cleanup", Message: "variable is declared but never used; remove it or use it", Example: "do main() {\n    mut x int = 42  // This variable is never used\n    println(\"hello\")\n}", ExampleFile: "integration-tests/pass/warnings/W1001_unused_variable.gray"},
+	"W1003":  {Kind: "warning", Category: "cleanup", Message: "function is declared but never called; remove it or call it", Example: "do unused_helper() -> int {\n    return 42\n}\n\ndo main() {\n    println(\"hello\")\n}", ExampleFile: "integration-tests/pass/warnings/W1003_unused_function.gray"},
+	"W1005":  {Kind: "warning", Category: "cleanup", Message: "typed blank identifier; '_' doesn't need a type annotation, use 'mut _ = <expr>' instead", Example: "do returnTwo() -> (int, string) {\n    return 42, \"hello\"\n}\n\ndo returnThree() -> (int, string, bool) {\n    return 1, \"two\", true\n}\n\ndo main() {\n    // Test 1: typed blank after named - should warn for the typed blank\n    mut x int, _ string = returnTwo()\n    println(x)\n\n    // Test 2: all typed blanks - should warn for both\n    mut _ int, _ string = returnTwo()\n    println(\"discarded both\")\n\n    // Test 3: typed blank in the middle\n    mut a int, _ string, c bool = returnThree()\n    println(a)\n    println(c)\n\n    // These should NOT warn (untyped blanks):\n    mut y, _ = returnTwo()\n    println(y)\n\n    mut _, z = returnTwo()\n    println(z)\n}", ExampleFile: "integration-tests/pass/warnings/W1005_typed_blank_identifier.gray"},
+	"W1002":  {Kind: "warning", Category: "cleanup", Message: "this import is never used; remove it or use a function from the module", Example: "import @math  // This import is never used\n\ndo main() {\n    println(\"hello\")\n}", ExampleFile: "integration-tests/pass/warnings/W1002_unused_import.gray"},
+	"W2002":  {Kind: "warning", Category: "safety", Message: "this variable shadows a variable with the same name in an outer scope", Example: "do main() {\n    mut x int = 10\n    if true {\n        mut x int = 20  // Shadows outer x\n        println(x)\n    }\n    println(x)\n}", ExampleFile: "integration-tests/pass/warnings/W2002_variable_shadow.gray"},
+	"W2003":  {Kind: "warning", Category: "safety", Message: "unreachable code; this statement will never execute because it comes after a return", Example: "do greet() -> string {\n    return \"hello\"\n    println(\"goodbye\")  // unreachable\n}\n\ndo main() {\n    println(greet())\n}", ExampleFile: "integration-tests/pass/warnings/W2003_unreachable_println_after_return.gray"},
+	"W2007":  {Kind: "warning", Category: "safety", Message: "this variable shadows a global constant or variable", Example: "const MAX int = 100\n\ndo main() {\n    mut MAX int = 50  // Shadows the global constant MAX\n    println(MAX)\n}", ExampleFile: "integration-tests/pass/warnings/W2007_shadows_global.gray"},
+	"W2008":  {Kind: "warning", Category: "safety", Message: "parameter shadows an enum variant name", Example: "const Dir enum {\n    NORTH\n    SOUTH\n}\n\ndo move(NORTH int) -> int {\n    return NORTH + 1\n}\n\ndo main() {\n    mut passed int = 0\n    mut failed int = 0\n\n    mut result = move(5)\n    if result == 6 {\n        println(\"  [PASS] param shadowing enum variant works\")\n        passed += 1\n    } otherwise {\n        println(\"  [FAIL] param shadowing enum variant: got ${result}\")\n        failed += 1\n    }\n\n    println(\"Results: ${passed} passed, ${failed} failed\")\n    if failed > 0 {\n        println(\"SOME TESTS FAILED\")\n    } otherwise {\n        println(\"ALL TESTS PASSED\")\n    }\n}", ExampleFile: "integration-tests/pass/warnings/W2008_param_shadows_enum_variant.gray"},
+	"W2011":  {Kind: "warning", Category: "safety", Message: "named return value is declared in the signature but no matching variable exists in the function body", Example: "do getValue() -> (result int) {\n    return 42\n}\n\ndo main() {\n    println(getValue())\n}", ExampleFile: "integration-tests/pass/warnings/W2011_named_return_unused.gray"},
+	"W2012":  {Kind: "warning", Category: "safety", Message: "when condition is a float; equality checks on floats are imprecise; prefer math.abs(x - y) < epsilon", Example: "do main() {\n    mut x float = 0.5\n    when x {\n        is 0.5 { println(\"half\") }\n        default { println(\"other\") }\n    }\n}", ExampleFile: "integration-tests/pass/warnings/W2012_when_float.gray"},
+	"W2013":  {Kind: "warning", Category: "imports", Message: "duplicate import of already-imported module", Example: "", ExampleFile: ""},
+	"W2014":  {Kind: "warning", Category: "imports", Message: "intra-directory import already included by directory import", Example: "", ExampleFile: ""},
+	"W2015":  {Kind: "warning", Category: "imports", Message: "file already imported as part of a directory import; redundant import", Example: "", ExampleFile: ""},
+	"W3003":  {Kind: "warning", Category: "safety", Message: "fixed-size array is not fully initialized; remaining elements will be zero-valued", Example: "do main() {\n    const arr [int, 5] = {42}\n    println(arr)\n}", ExampleFile: "integration-tests/pass/warnings/W3003_partial_init_one_element.gray"},
+	"W3004":  {Kind: "warning", Category: "safety", Message: "pointer may reference memory from a scope that has ended; assigning addr() of an inner-scope variable to an outer-scope pointer", Example: "", ExampleFile: ""},
+	"W3005":  {Kind: "warning", Category: "safety", Message: "when statement matches on enum values without #strict and no default; exhaustiveness is not checked", Example: "", ExampleFile: ""},
+	"W3006":  {Kind: "warning", Category: "safety", Message: "empty default branch in when statement; unmatched values are silently ignored", Example: "", ExampleFile: ""},
+}
@@ -0,0 +1,109 @@
+// explain_test.go — Tests for gray explain: code detection, ranges, the
+// generated code table, and the rendered page.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsErrorCode(t *testing.T) {
+	for name, want := range map[string]bool{
+		"E2013": true, "e2013": true, "W1001": true, "P0004": true, "E12001": true,
+		"E20": false, "X2013": false, "println": false, "E2013a": false,
+	} {
+		if got := isErrorCode(name); got != want {
+			t.Errorf("isErrorCode(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestErrorCodeRange(t *testing.T) {
+	for code, want := range map[string]string{
+		"E1006": "E1xxx", "E2013": "E2xxx", "E12001": "E12xxx", "W2002": "W2xxx", "P0004": "P0xxx",
+	} {
+		if got, _ := errorCodeRange(code); got != want {
+			t.Errorf("errorCodeRange(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestErrorCodeDocs(t *testing.T) {
+	for code, entry := range errorCodeDocs {
+		if r, _ := errorCodeRange(code); r == "" {
+			t.Errorf("%s has no code range", code)
+		}
+		if entry.Message == "" || entry.Category == "" {
+			t.Errorf("%s is missing its message or category", code)
+		}
+		if strings.HasPrefix(entry.Example, "/*") || strings.Contains(entry.Example, "Expected:") {
+			t.Errorf("%s example keeps its test header: %q", code, entry.Example)
+		}
+	}
+	for code := range errorExplanations {
+		if _, ok := errorCodeDocs[code]; !ok {
+			t.Errorf("errorExplanations has %s, which is not in error_codes.h", code)
+		}
+	}
+	if e := errorCodeDocs["E2012"]; e.Kind != "error" || !strings.Contains(e.Example, "do foo(x int, x string)") {
+		t.Errorf("E2012 = %+v", e)
+	}
+}
+
+func TestErrorCodeFix(t *testing.T) {
+	entry := ErrorCodeEntry{Message: "unclosed string; add a closing double quote"}
+	if got := errorCodeFix("E9999", entry); got != "Add a closing double quote." {
+		t.Errorf("errorCodeFix from message = %q", got)
+	}
+	if got := errorCodeFix("E9999", ErrorCodeEntry{Message: "unexpected character"}); got != "" {
+		t.Errorf("errorCodeFix without advice = %q", got)
+	}
+}
+
+func TestRunExplain(t *testing.T) {
+	var err error
+	out := captureStdout(t, func() { err = runExplain("e2013") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"E2013: duplicate field name '%s' in struct '%s'", "Kind:      error", "Category:  syntax",
+		"Range:     E2xxx", "declares the same field name twice", "Fix:\n  Rename or remove",
+		"Example:\n  const Point struct {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = captureStdout(t, func() { err = runExplain("E2012") })
+	if err != nil || !strings.Contains(out, "Example (integration-tests/fail/errors/E2012_") {
+		t.Errorf("E2012 page = %v:\n%s", err, out)
+	}
+	if strings.Contains(out, "Error Test") || strings.Contains(out, "No long-form explanation") {
+		t.Errorf("E2012 page has the test header or a missing-explanation note:\n%s", out)
+	}
+
+	out = captureStdout(t, func() { err = runExplain("E1005") })
+	if err != nil || !strings.Contains(out, "No long-form explanation for this code yet") || !strings.Contains(out, "Fix:\n  Add a closing single quote.") {
+		t.Errorf("E1005 page = %v:\n%s", err, out)
+	}
+
+	err = runExplain("E2031")
+	if err == nil || !strings.Contains(err.Error(), "unknown error code 'E2031'") || !strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("unknown code error = %v", err)
+	}
+}
+
+func TestManExplainsCodes(t *testing.T) {
+	var err error
+	out := captureStdout(t, func() { err = manCmd.RunE(manCmd, []string{"W1001"}) })
+	if err != nil || !strings.HasPrefix(out, "W1001: variable is declared but never used") {
+		t.Errorf("gray man W1001 = %v:\n%s", err, out)
+	}
+}
@@ -1,6 +1,10 @@
 // fmt.go — Source formatter for .gray files ("gray fmt"). Normalizes
-// indentation, trailing whitespace, blank-line runs, and EOF newlines,
-// with a --check mode for CI gating.
+// indentation, trailing whitespace, blank-line runs, EOF newlines, and
+// import blocks (see imports.go), with --check/--diff/--list/--json
+// reporting for CI, stdin/stdout streaming for editors, and --range for
+// formatting a subset of lines. Style settings come from gray.toml and
+// .editorconfig (see fmtconfig.go); ```gray blocks in Markdown files are
+// formatted too (see markdown.go).
 //
 // Author:  Marshall A Burns (@SchoolyB)
 // Copyright (c) 2025-Present Marshall A Burns
@@ -9,9 +13,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/grayscale-lang/grayscale/internal/grayc"
@@ -69,21 +76,27 @@ func formatGraySource(src []byte) []byte {
 	return []byte(strings.Join(out, "\n") + "\n")
 }
 
-// collectFmtFiles expands the user-supplied args into a deduplicated list
-// of .gray file paths. Supported forms:
+// collectGrayFiles expands the user-supplied args into a deduplicated list
+// of .gray file paths. prog prefixes diagnostics (e.g. "gray fmt").
+// Supported forms:
 //
 //   - "file.gray"           — single file
 //   - "dir"               — all .gray files directly inside dir (non-recursive)
 //   - "dir/subdir"        — all .gray files directly inside dir/subdir
 //   - "./..." or "dir/..." — recursive walk for .gray files
-func collectFmtFiles(args []string) []string {
+func collectGrayFiles(prog string, args []string) []string {
+	return collectSourceFiles(prog, args, ".gray")
+}
+
+// collectSourceFiles is collectGrayFiles for any set of file extensions.
+func collectSourceFiles(prog string, args []string, exts ...string) []string {
 	seen := make(map[string]struct{})
 	var files []string
 
 	add := func(p string) {
 		ap, err := filepath.Abs(p)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "gray fmt: cannot resolve %s: %v\n", p, err)
+			fmt.Fprintf(os.Stderr, "%s: cannot resolve %s: %v\n", prog, p, err)
 			return
 		}
 		if _, ok := seen[ap]; ok {
@@ -92,6 +105,14 @@ func collectFmtFiles(args []string) []string {
 		seen[ap] = struct{}{}
 		files = append(files, ap)
 	}
+	wanted := func(name string) bool {
+		for _, ext := range exts {
+			if strings.HasSuffix(name, ext) {
+				return true
+			}
+		}
+		return false
+	}
 
 	for _, arg := range args {
 		if strings.HasSuffix(arg, "/...") || arg == "..." {
@@ -103,7 +124,7 @@ func collectFmtFiles(args []string) []string {
 				if err != nil {
 					return nil
 				}
-				if !info.IsDir() && strings.HasSuffix(p, ".gray") {
+				if !info.IsDir() && wanted(p) {
 					add(p)
 				}
 				return nil
@@ -113,101 +134,348 @@ func collectFmtFiles(args []string) []string {
 
 		info, err := os.Stat(arg)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
+			fmt.Fprintf(os.Stderr, "%s: %v\n", prog, err)
 			continue
 		}
 
 		if info.IsDir() {
 			entries, err := os.ReadDir(arg)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
+				fmt.Fprintf(os.Stderr, "%s: %v\n", prog, err)
 				continue
 			}
 			for _, e := range entries {
-				if !e.IsDir() && strings.HasSuffix(e.Name(), ".gray") {
+				if !e.IsDir() && wanted(e.Name()) {
 					add(filepath.Join(arg, e.Name()))
 				}
 			}
-		} else if strings.HasSuffix(arg, ".gray") {
+		} else if wanted(arg) {
 			add(arg)
 		} else {
-			fmt.Fprintf(os.Stderr, "gray fmt: '%s' is not a .gray file or directory\n", arg)
+			fmt.Fprintf(os.Stderr, "%s: '%s' is not a %s file or directory\n", prog, arg, strings.Join(exts, "/"))
 		}
 	}
 	return files
 }
 
+// fmtOptions carries the fmtCmd flags into runFmt.
+type fmtOptions struct {
+	Check         bool
+	Diff          bool   // print a unified diff for each file that would change
+	List          bool   // print the path of each file that would change
+	JSON          bool   // print per-file status as JSON
+	Stdin         bool   // read source from stdin, write the result to stdout
+	StdinFilename string // name used for stdin in messages
+	Range         string // "start:end" line range to re-indent
+	Jobs          int    // parallel grayc processes; <= 0 means one per CPU
+
+	DropUnusedImports bool   // remove imports grayc check reports as unused (W1002)
+	Changed           string // git revision; only format files that differ from it
+}
+
+// reportOnly reports whether opts asks for a report rather than a rewrite.
+func (o fmtOptions) reportOnly() bool {
+	return o.Check || o.Diff || o.List || o.JSON
+}
+
+// Per-file formatting status values.
+const (
+	fmtUnchanged = "unchanged"
+	fmtChanged   = "changed"
+	fmtError     = "error"
+)
+
+// fmtFileResult is the outcome of formatting one file.
+type fmtFileResult struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	orig      []byte
+	formatted []byte
+	diags     string
+}
+
+// parseFmtRange parses a --range value of the form "start:end" (1-based,
+// inclusive). An empty string means the whole file.
+func parseFmtRange(s string) (grayc.FmtOpts, error) {
+	if s == "" {
+		return grayc.FmtOpts{}, nil
+	}
+	a, b, ok := strings.Cut(s, ":")
+	if !ok {
+		return grayc.FmtOpts{}, fmt.Errorf("invalid --range '%s' (expected start:end)", s)
+	}
+	start, err1 := strconv.Atoi(strings.TrimSpace(a))
+	end, err2 := strconv.Atoi(strings.TrimSpace(b))
+	if err1 != nil || err2 != nil || start < 1 || end < start {
+		return grayc.FmtOpts{}, fmt.Errorf("invalid --range '%s' (expected start:end)", s)
+	}
+	return grayc.FmtOpts{RangeStart: start, RangeEnd: end}, nil
+}
+
+// formatOne formats src in memory. A parse failure is reported as an
+// error result rather than being compared as if nothing changed. Import
+// blocks are organised unless only a --range of lines was requested.
+// unused may be nil; see unusedImportsFor.
+func formatOne(name string, src []byte, fopts grayc.FmtOpts, unused unusedImportFunc) fmtFileResult {
+	r := fmtFileResult{Path: name, orig: src}
+	fopts.Filename = name
+	res, err := grayc.FmtSource(src, fopts)
+	r.diags = res.Diagnostics
+	if err == nil && res.Code == 0 && fopts.RangeStart == 0 {
+		res.Output = []byte(organizeImports(string(res.Output), unused))
+	}
+	switch {
+	case err != nil:
+		r.Status, r.Error = fmtError, err.Error()
+	case res.Code != 0:
+		r.Status, r.Error = fmtError, firstLine(res.Diagnostics)
+		if r.Error == "" {
+			r.Error = fmt.Sprintf("grayc exited with status %d", res.Code)
+		}
+	case string(res.Output) == string(src):
+		r.Status = fmtUnchanged
+	default:
+		r.Status, r.formatted = fmtChanged, res.Output
+	}
+	return r
+}
+
+// firstLine returns the first non-empty line of s.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
 // runFmt is the entry point invoked by the Cobra fmtCmd. It returns the
-// exit code the caller should propagate (0 success, 1 on error).
-func runFmt(args []string, checkMode bool) int {
-	files := collectFmtFiles(args)
+// exit code the caller should propagate (0 success, 1 on error or, with
+// --check, when any file would change).
+func runFmt(args []string, opts fmtOptions) int {
+	fopts, err := parseFmtRange(opts.Range)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
+		return 1
+	}
+
+	if len(args) == 1 && args[0] == "-" {
+		opts.Stdin = true
+		args = nil
+	}
+	if opts.Stdin {
+		if len(args) > 0 {
+			fmt.Fprintln(os.Stderr, "gray fmt: cannot combine stdin input with file arguments")
+			return 1
+		}
+		if opts.DropUnusedImports {
+			fmt.Fprintln(os.Stderr, "gray fmt: --drop-unused-imports needs file paths; it cannot be used with stdin")
+			return 1
+		}
+		if opts.Changed != "" {
+			fmt.Fprintln(os.Stderr, "gray fmt: --changed cannot be used with stdin")
+			return 1
+		}
+		return runFmtStdin(opts, fopts)
+	}
+
+	var files []string
+	if opts.Changed != "" {
+		if files, err = changedFiles(opts.Changed, args, ".gray", ".md"); err != nil {
+			fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
+			return 1
+		}
+	} else {
+		files = collectSourceFiles("gray fmt", args, ".gray", ".md")
+	}
 	if len(files) == 0 {
+		if opts.JSON {
+			return printFmtJSON(nil)
+		}
+		if opts.Changed != "" {
+			fmt.Println("gray fmt: no changed .gray files")
+			return 0
+		}
 		fmt.Println("gray fmt: no .gray files found")
 		return 0
 	}
+	if opts.Range != "" && (len(files) != 1 || isMarkdownFile(files[0])) {
+		fmt.Fprintln(os.Stderr, "gray fmt: --range requires a single .gray file")
+		return 1
+	}
 
-	exit := 0
-	changed := 0
+	// Resolve per-file configuration up front; the loader caches parsed
+	// config files and is not safe for concurrent use.
+	loader := newFmtConfigLoader()
+	cfgs := make([]fmtConfig, len(files))
+	cfgErrs := make([]error, len(files))
+	for i, f := range files {
+		cfgs[i], cfgErrs[i] = loader.load(fmtConfigPath(f))
+	}
 
-	for _, path := range files {
-		orig, err := os.ReadFile(path)
+	results := parallelMap(len(files), opts.Jobs, func(i int) fmtFileResult {
+		if cfgErrs[i] != nil {
+			return fmtFileResult{Path: files[i], Status: fmtError, Error: cfgErrs[i].Error()}
+		}
+		src, err := os.ReadFile(files[i])
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
-			exit = 1
-			continue
+			return fmtFileResult{Path: files[i], Status: fmtError, Error: err.Error()}
 		}
+		if isMarkdownFile(files[i]) {
+			return formatMarkdown(files[i], src, cfgs[i].apply(fopts))
+		}
+		var unused unusedImportFunc
+		if opts.DropUnusedImports {
+			unused = unusedImportsFor(files[i], src)
+		}
+		return formatOne(files[i], src, cfgs[i].apply(fopts), unused)
+	})
 
-		if checkMode {
-			// Copy to temp, format it, compare
-			tmp, err := os.CreateTemp("", "gray-fmt-check-*.gray")
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
-				exit = 1
-				continue
-			}
-			tmpName := tmp.Name()
-			tmp.Write(orig)
-			tmp.Close()
-
-			grayc.Fmt(tmpName)
+	if !opts.reportOnly() {
+		return writeFmtResults(results)
+	}
+	return reportFmtResults(results, opts)
+}
 
-			formatted, err := os.ReadFile(tmpName)
-			os.Remove(tmpName)
-			if err != nil {
+// writeFmtResults rewrites every changed file in place, keeping its
+// permissions.
+func writeFmtResults(results []fmtFileResult) int {
+	exit := 0
+	changed := 0
+	for _, r := range results {
+		switch r.Status {
+		case fmtError:
+			printFmtError(r)
+			exit = 1
+		case fmtChanged:
+			mode := os.FileMode(0o644)
+			if info, err := os.Stat(r.Path); err == nil {
+				mode = info.Mode().Perm()
+			}
+			if err := os.WriteFile(r.Path, r.formatted, mode); err != nil {
 				fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
 				exit = 1
 				continue
 			}
-			if string(orig) != string(formatted) {
-				fmt.Printf("would format: %s\n", path)
-				if exit == 0 {
-					exit = 1
-				}
-			}
-			continue
+			fmt.Printf("formatted: %s\n", r.Path)
+			changed++
 		}
+	}
+	if changed == 0 && exit == 0 {
+		fmt.Printf("gray fmt: %d file(s) checked, already formatted\n", len(results))
+	}
+	return exit
+}
 
-		// Normal mode: format in place
-		code, err := grayc.Fmt(path)
-		if err != nil || code != 0 {
-			fmt.Fprintf(os.Stderr, "gray fmt: failed to format '%s'\n", path)
+// reportFmtResults prints results for --check, --diff, --list, and
+// --json without modifying anything. Errors always fail the run;
+// changes fail it only under --check.
+func reportFmtResults(results []fmtFileResult, opts fmtOptions) int {
+	exit := 0
+	for _, r := range results {
+		if r.Status == fmtError {
 			exit = 1
-			continue
-		}
-		after, err := os.ReadFile(path)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
+		} else if r.Status == fmtChanged && opts.Check {
 			exit = 1
-			continue
 		}
-		if string(orig) != string(after) {
-			fmt.Printf("formatted: %s\n", path)
-			changed++
+	}
+	if opts.JSON {
+		if code := printFmtJSON(results); code != 0 {
+			return code
 		}
+		return exit
 	}
 
-	if !checkMode && changed == 0 {
-		fmt.Printf("gray fmt: %d file(s) checked, already formatted\n", len(files))
+	for _, r := range results {
+		switch r.Status {
+		case fmtError:
+			printFmtError(r)
+		case fmtChanged:
+			if opts.List {
+				fmt.Println(r.Path)
+			}
+			if opts.Diff {
+				fmt.Print(unifiedDiff(r.Path+".orig", r.Path, string(r.orig), string(r.formatted)))
+			}
+			if !opts.List && !opts.Diff {
+				fmt.Printf("would format: %s\n", r.Path)
+			}
+		}
 	}
 	return exit
 }
+
+// printFmtError reports a file that could not be formatted, including
+// grayc's diagnostics when it produced any.
+func printFmtError(r fmtFileResult) {
+	if r.diags != "" {
+		fmt.Fprint(os.Stderr, r.diags)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "gray fmt: failed to format '%s': %s\n", r.Path, r.Error)
+}
+
+func printFmtJSON(results []fmtFileResult) int {
+	if results == nil {
+		results = []fmtFileResult{}
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
+		return 1
+	}
+	fmt.Println(string(data))
+	return 0
+}
+
+// runFmtStdin formats source read from stdin. By default the result is
+// written to stdout for editor integrations; the report flags describe
+// the input instead.
+func runFmtStdin(opts fmtOptions, fopts grayc.FmtOpts) int {
+	name := opts.StdinFilename
+	if name == "" {
+		name = "<stdin>"
+	}
+	src, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
+		return 1
+	}
+	// Configuration is looked up as if the source lived at --stdin-filename
+	// (relative to the working directory), or in the working directory.
+	cfgPath := opts.StdinFilename
+	if cfgPath == "" {
+		cfgPath = "stdin.gray"
+	}
+	cfg, err := newFmtConfigLoader().load(fmtConfigPath(cfgPath))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gray fmt: %v\n", err)
+		return 1
+	}
+	var r fmtFileResult
+	if isMarkdownFile(name) {
+		if fopts.RangeStart != 0 {
+			fmt.Fprintln(os.Stderr, "gray fmt: --range cannot be used with Markdown input")
+			return 1
+		}
+		r = formatMarkdown(name, src, cfg.apply(fopts))
+	} else {
+		r = formatOne(name, src, cfg.apply(fopts), nil)
+	}
+	if opts.reportOnly() {
+		return reportFmtResults([]fmtFileResult{r}, opts)
+	}
+	switch r.Status {
+	case fmtError:
+		printFmtError(r)
+		return 1
+	case fmtChanged:
+		os.Stdout.Write(r.formatted)
+	default:
+		os.Stdout.Write(src)
+	}
+	return 0
+}
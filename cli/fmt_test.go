@@ -1,6 +1,7 @@
 // fmt_test.go — Tests for the source formatter verifying whitespace
 // trimming, tab-to-space expansion, blank-line collapsing, EOF
-// normalization, and idempotency of formatGraySource.
+// normalization, and idempotency of formatGraySource, plus --range
+// parsing and report-mode option handling.
 //
 // Author:  Marshall A Burns (@SchoolyB)
 // Copyright (c) 2025-Present Marshall A Burns
@@ -78,3 +79,38 @@ func TestFormatGraySourceMixedTabsAndSpaces(t *testing.T) {
 		t.Fatalf("mixed tab+space indent not normalized\ngot:  %q\nwant: %q", got, want)
 	}
 }
+
+func TestParseFmtRange(t *testing.T) {
+	opts, err := parseFmtRange("10:20")
+	if err != nil {
+		t.Fatalf("parseFmtRange: %v", err)
+	}
+	if opts.RangeStart != 10 || opts.RangeEnd != 20 {
+		t.Errorf("got %+v, want 10:20", opts)
+	}
+	if opts, err := parseFmtRange(""); err != nil || opts.RangeStart != 0 || opts.RangeEnd != 0 {
+		t.Errorf("empty range = %+v, %v", opts, err)
+	}
+	for _, bad := range []string{"10", "a:b", "0:3", "5:2", ":4"} {
+		if _, err := parseFmtRange(bad); err == nil {
+			t.Errorf("parseFmtRange(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFmtOptionsReportOnly(t *testing.T) {
+	if (fmtOptions{}).reportOnly() {
+		t.Error("plain fmt should rewrite files")
+	}
+	for _, o := range []fmtOptions{{Check: true}, {Diff: true}, {List: true}, {JSON: true}} {
+		if !o.reportOnly() {
+			t.Errorf("%+v should not modify files", o)
+		}
+	}
+}
+
+func TestFirstLine(t *testing.T) {
+	if got := firstLine("\n  a.gray:2:7: error[E1010]: bad\nmore\n"); got != "a.gray:2:7: error[E1010]: bad" {
+		t.Errorf("firstLine = %q", got)
+	}
+}
@@ -0,0 +1,464 @@
+// fmtconfig.go — Formatter configuration for "gray fmt". Settings are
+// resolved per file from the nearest .editorconfig files (indent style and
+// size, max_line_length) and then the [fmt] table of the nearest gray.toml
+// project manifest, and are passed through to grayc's formatter.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+// manifestFileName is the project manifest looked up from each file's
+// directory upwards.
+const manifestFileName = "gray.toml"
+
+// Brace styles accepted by brace_style.
+var fmtBraceStyles = []string{"preserve", "same_line", "next_line"}
+
+// fmtConfig is the formatter configuration for one file.
+type fmtConfig struct {
+	IndentWidth   int
+	UseTabs       bool
+	MaxBlankLines int
+	MaxLineLength int    // 0 disables wrapping
+	BraceStyle    string // one of fmtBraceStyles
+}
+
+// defaultFmtConfig matches grayc's built-in formatting.
+var defaultFmtConfig = fmtConfig{
+	IndentWidth:   tabWidth,
+	MaxBlankLines: maxConsecutiveBlankLines,
+	BraceStyle:    "preserve",
+}
+
+// apply copies the configuration onto grayc format options.
+func (c fmtConfig) apply(o grayc.FmtOpts) grayc.FmtOpts {
+	o.IndentWidth = c.IndentWidth
+	o.UseTabs = c.UseTabs
+	o.MaxBlankLines = &c.MaxBlankLines
+	o.MaxLineLength = c.MaxLineLength
+	o.BraceStyle = c.BraceStyle
+	return o
+}
+
+// fmtConfigPath is the path whose configuration applies to file. Code
+// blocks in Markdown use the settings for .gray files beside the document,
+// not the document's own (e.g. an .editorconfig [*.md] indent_size).
+func fmtConfigPath(file string) string {
+	if isMarkdownFile(file) {
+		return strings.TrimSuffix(file, filepath.Ext(file)) + ".gray"
+	}
+	return file
+}
+
+// fmtConfigLoader resolves configuration for files, caching parsed
+// .editorconfig and gray.toml files. It is not safe for concurrent use.
+type fmtConfigLoader struct {
+	editorConfigs map[string]*editorConfigFile // by path; nil when absent
+	manifests     map[string]*fmtManifest      // by path; nil when absent
+}
+
+func newFmtConfigLoader() *fmtConfigLoader {
+	return &fmtConfigLoader{
+		editorConfigs: map[string]*editorConfigFile{},
+		manifests:     map[string]*fmtManifest{},
+	}
+}
+
+// load returns the configuration for the source file at path.
+func (l *fmtConfigLoader) load(path string) (fmtConfig, error) {
+	cfg := defaultFmtConfig
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	// .editorconfig: nearer files take precedence, and a file with
+	// root = true stops the search, so apply from the root down.
+	var chain []*editorConfigFile
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		ec, err := l.editorConfig(filepath.Join(dir, ".editorconfig"))
+		if err != nil {
+			return cfg, err
+		}
+		if ec != nil {
+			chain = append(chain, ec)
+			if ec.root {
+				break
+			}
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if err := chain[i].apply(abs, &cfg); err != nil {
+			return cfg, err
+		}
+	}
+
+	// gray.toml: only the nearest manifest applies.
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		m, err := l.manifest(filepath.Join(dir, manifestFileName))
+		if err != nil {
+			return cfg, err
+		}
+		if m != nil {
+			m.apply(&cfg)
+			break
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+	return cfg, nil
+}
+
+func (l *fmtConfigLoader) editorConfig(path string) (*editorConfigFile, error) {
+	if ec, ok := l.editorConfigs[path]; ok {
+		return ec, nil
+	}
+	ec, err := parseEditorConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	l.editorConfigs[path] = ec
+	return ec, nil
+}
+
+func (l *fmtConfigLoader) manifest(path string) (*fmtManifest, error) {
+	if m, ok := l.manifests[path]; ok {
+		return m, nil
+	}
+	m, err := parseFmtManifestFile(path)
+	if err != nil {
+		return nil, err
+	}
+	l.manifests[path] = m
+	return m, nil
+}
+
+// ---------------------------------------------------------------------------
+// gray.toml
+// ---------------------------------------------------------------------------
+
+// fmtManifest is the [fmt] table of a gray.toml manifest. Only keys that
+// were set are applied.
+type fmtManifest struct {
+	set map[string]bool
+	cfg fmtConfig
+}
+
+func (m *fmtManifest) apply(cfg *fmtConfig) {
+	if m.set["indent_width"] {
+		cfg.IndentWidth = m.cfg.IndentWidth
+	}
+	if m.set["use_tabs"] {
+		cfg.UseTabs = m.cfg.UseTabs
+	}
+	if m.set["max_blank_lines"] {
+		cfg.MaxBlankLines = m.cfg.MaxBlankLines
+	}
+	if m.set["max_line_length"] {
+		cfg.MaxLineLength = m.cfg.MaxLineLength
+	}
+	if m.set["brace_style"] {
+		cfg.BraceStyle = m.cfg.BraceStyle
+	}
+}
+
+// parseFmtManifestFile reads the [fmt] table from a gray.toml file. It
+// returns nil, nil when the file does not exist. Only the small TOML
+// subset the manifest needs is understood: [tables], key = value pairs
+// with integer, boolean, or double-quoted string values, and # comments.
+func parseFmtManifestFile(path string) (*fmtManifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseFmtManifest(path, string(data))
+}
+
+func parseFmtManifest(path, data string) (*fmtManifest, error) {
+	m := &fmtManifest{set: map[string]bool{}}
+	section := ""
+	for i, raw := range strings.Split(data, "\n") {
+		line := strings.TrimSpace(stripTOMLComment(raw))
+		if line == "" {
+			continue
+		}
+		bad := func(format string, args ...interface{}) error {
+			return fmt.Errorf("%s:%d: %s", path, i+1, fmt.Sprintf(format, args...))
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, bad("malformed table header %q", line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, bad("expected key = value")
+		}
+		if section != "fmt" {
+			continue
+		}
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		switch key {
+		case "indent_width", "max_blank_lines", "max_line_length":
+			n, err := strconv.Atoi(val)
+			if key == "indent_width" && (err != nil || n <= 0) {
+				return nil, bad("%s must be a positive integer, got %s", key, val)
+			}
+			if err != nil || n < 0 {
+				return nil, bad("%s must be a non-negative integer, got %s", key, val)
+			}
+			switch key {
+			case "indent_width":
+				m.cfg.IndentWidth = n
+			case "max_blank_lines":
+				m.cfg.MaxBlankLines = n
+			default:
+				m.cfg.MaxLineLength = n
+			}
+		case "use_tabs":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, bad("use_tabs must be true or false, got %s", val)
+			}
+			m.cfg.UseTabs = b
+		case "brace_style":
+			s, err := strconv.Unquote(val)
+			if err != nil || !validBraceStyle(s) {
+				return nil, bad("brace_style must be one of %q, got %s", fmtBraceStyles, val)
+			}
+			m.cfg.BraceStyle = s
+		default:
+			return nil, bad("unknown [fmt] key %q", key)
+		}
+		m.set[key] = true
+	}
+	return m, nil
+}
+
+// stripTOMLComment removes a # comment that is not inside a string.
+func stripTOMLComment(line string) string {
+	inStr := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inStr {
+				i++
+			}
+		case '"':
+			inStr = !inStr
+		case '#':
+			if !inStr {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+func validBraceStyle(s string) bool {
+	for _, b := range fmtBraceStyles {
+		if s == b {
+			return true
+		}
+	}
+	return false
+}
+
+// ---------------------------------------------------------------------------
+// .editorconfig
+// ---------------------------------------------------------------------------
+
+// editorConfigFile is one parsed .editorconfig file.
+type editorConfigFile struct {
+	path     string
+	root     bool
+	sections []editorConfigSection
+}
+
+type editorConfigSection struct {
+	glob  *regexp.Regexp
+	props map[string]string
+	lines map[string]int // property -> line number, for error messages
+}
+
+// parseEditorConfigFile parses path, returning nil, nil when it does not
+// exist.
+func parseEditorConfigFile(path string) (*editorConfigFile, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ec := &editorConfigFile{path: path}
+	var cur *editorConfigSection
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' && strings.HasSuffix(line, "]") {
+			re, err := editorConfigGlob(line[1 : len(line)-1])
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid section %s", path, n, line)
+			}
+			ec.sections = append(ec.sections, editorConfigSection{glob: re, props: map[string]string{}, lines: map[string]int{}})
+			cur = &ec.sections[len(ec.sections)-1]
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			continue // editorconfig ignores malformed lines
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		val = strings.TrimSpace(val)
+		if cur == nil {
+			if key == "root" {
+				ec.root = strings.EqualFold(val, "true")
+			}
+			continue
+		}
+		cur.props[key] = strings.ToLower(val)
+		cur.lines[key] = n
+	}
+	return ec, scanner.Err()
+}
+
+// apply overlays every section matching file onto cfg, in file order.
+func (ec *editorConfigFile) apply(file string, cfg *fmtConfig) error {
+	rel, err := filepath.Rel(filepath.Dir(ec.path), file)
+	if err != nil {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+	for _, s := range ec.sections {
+		if !s.glob.MatchString(rel) {
+			continue
+		}
+		bad := func(key string) error {
+			return fmt.Errorf("%s:%d: invalid %s value %q", ec.path, s.lines[key], key, s.props[key])
+		}
+		if v, ok := s.props["indent_style"]; ok {
+			switch v {
+			case "tab":
+				cfg.UseTabs = true
+			case "space":
+				cfg.UseTabs = false
+			default:
+				return bad("indent_style")
+			}
+		}
+		if v, ok := s.props["indent_size"]; ok && v != "tab" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return bad("indent_size")
+			}
+			cfg.IndentWidth = n
+		}
+		if v, ok := s.props["max_line_length"]; ok {
+			n, err := strconv.Atoi(v)
+			switch {
+			case v == "off":
+				cfg.MaxLineLength = 0
+			case err != nil || n < 1:
+				return bad("max_line_length")
+			default:
+				cfg.MaxLineLength = n
+			}
+		}
+		// Grayscale-specific extensions.
+		if v, ok := s.props["gray_max_blank_lines"]; ok {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return bad("gray_max_blank_lines")
+			}
+			cfg.MaxBlankLines = n
+		}
+		if v, ok := s.props["gray_brace_style"]; ok {
+			if !validBraceStyle(v) {
+				return bad("gray_brace_style")
+			}
+			cfg.BraceStyle = v
+		}
+	}
+	return nil
+}
+
+// editorConfigGlob translates an editorconfig section glob into a regexp
+// matched against slash-separated paths relative to the .editorconfig.
+// Globs without a slash match a file name in any directory.
+func editorConfigGlob(glob string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !strings.Contains(glob, "/") {
+		sb.WriteString("(?:.*/)?")
+	}
+	glob = strings.TrimPrefix(glob, "/")
+	braces := 0
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case c == '*' && i+1 < len(glob) && glob[i+1] == '*':
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '{':
+			braces++
+			sb.WriteString("(?:")
+		case c == '}' && braces > 0:
+			braces--
+			sb.WriteString(")")
+		case c == ',' && braces > 0:
+			sb.WriteString("|")
+		case c == '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end
+		case c == '\\' && i+1 < len(glob):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,192 @@
+// fmtconfig_test.go — Tests for formatter configuration: gray.toml [fmt]
+// parsing and validation, .editorconfig section matching and root
+// handling, and precedence between the two.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+// writeTestFile writes content to path, creating its directories. The
+// package's tests share it for their fixtures.
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseFmtManifest(t *testing.T) {
+	m, err := parseFmtManifest("gray.toml", `
+# project manifest
+[project]
+name = "demo"   # ignored by fmt
+
+[fmt]
+indent_width = 2
+use_tabs = true
+max_blank_lines = 1   # trailing comment
+max_line_length = 80
+brace_style = "next_line"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := defaultFmtConfig
+	m.apply(&cfg)
+	want := fmtConfig{IndentWidth: 2, UseTabs: true, MaxBlankLines: 1, MaxLineLength: 80, BraceStyle: "next_line"}
+	if cfg != want {
+		t.Errorf("config = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestParseFmtManifestPartial(t *testing.T) {
+	m, err := parseFmtManifest("gray.toml", "[fmt]\nmax_line_length = 120\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := defaultFmtConfig
+	m.apply(&cfg)
+	if cfg.MaxLineLength != 120 || cfg.IndentWidth != defaultFmtConfig.IndentWidth {
+		t.Errorf("partial manifest overrode unset keys: %+v", cfg)
+	}
+}
+
+func TestParseFmtManifestZeroBlankLines(t *testing.T) {
+	m, err := parseFmtManifest("gray.toml", "[fmt]\nmax_blank_lines = 0\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := defaultFmtConfig
+	m.apply(&cfg)
+	opts := cfg.apply(grayc.FmtOpts{})
+	if opts.MaxBlankLines == nil || *opts.MaxBlankLines != 0 {
+		t.Errorf("max_blank_lines = 0 not passed to grayc: %v", opts.MaxBlankLines)
+	}
+}
+
+func TestParseFmtManifestErrors(t *testing.T) {
+	for _, src := range []string{
+		"[fmt]\nindent_width = 0\n",
+		"[fmt]\nindent_width = two\n",
+		"[fmt]\nuse_tabs = yes\n",
+		"[fmt]\nbrace_style = \"k&r\"\n",
+		"[fmt]\nbrace_style = same_line\n",
+		"[fmt]\ntab_size = 4\n",
+		"[fmt\n",
+		"[fmt]\nindent_width\n",
+	} {
+		_, err := parseFmtManifest("gray.toml", src)
+		if err == nil {
+			t.Errorf("parseFmtManifest(%q) succeeded, want error", src)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), "gray.toml:") {
+			t.Errorf("error %q lacks file:line position", err)
+		}
+	}
+}
+
+func TestEditorConfigGlob(t *testing.T) {
+	tests := []struct {
+		glob, path string
+		want       bool
+	}{
+		{"*", "a.gray", true},
+		{"*", "src/a.gray", true},
+		{"*.gray", "src/deep/a.gray", true},
+		{"*.gray", "a.go", false},
+		{"*.{gray,md}", "README.md", true},
+		{"*.{gray,md}", "main.go", false},
+		{"src/*.gray", "src/a.gray", true},
+		{"src/*.gray", "lib/src/a.gray", false},
+		{"src/*.gray", "src/x/a.gray", false},
+		{"/src/**.gray", "src/x/a.gray", true},
+		{"[ab].gray", "b.gray", true},
+		{"[!ab].gray", "b.gray", false},
+		{"?.gray", "c.gray", true},
+	}
+	for _, tt := range tests {
+		re, err := editorConfigGlob(tt.glob)
+		if err != nil {
+			t.Fatalf("editorConfigGlob(%q): %v", tt.glob, err)
+		}
+		if got := re.MatchString(tt.path); got != tt.want {
+			t.Errorf("glob %q on %q = %v, want %v", tt.glob, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFmtConfigEditorConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ".editorconfig"), `root = true
+
+[*]
+indent_style = space
+indent_size = 8
+
+[*.gray]
+indent_size = 2
+max_line_length = 90
+gray_brace_style = same_line
+`)
+	// A nested file without root = true overrides the parent.
+	writeTestFile(t, filepath.Join(dir, "sub", ".editorconfig"), "[*.gray]\nindent_style = tab\n")
+	file := filepath.Join(dir, "sub", "a.gray")
+
+	cfg, err := newFmtConfigLoader().load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmtConfig{IndentWidth: 2, UseTabs: true, MaxBlankLines: maxConsecutiveBlankLines, MaxLineLength: 90, BraceStyle: "same_line"}
+	if cfg != want {
+		t.Errorf("config = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadFmtConfigManifestWins(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ".editorconfig"), "root = true\n[*.gray]\nindent_size = 2\nmax_line_length = off\n")
+	writeTestFile(t, filepath.Join(dir, "gray.toml"), "[fmt]\nindent_width = 3\n")
+	cfg, err := newFmtConfigLoader().load(filepath.Join(dir, "src", "a.gray"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.IndentWidth != 3 || cfg.MaxLineLength != 0 {
+		t.Errorf("config = %+v, want indent 3 from gray.toml and no wrapping", cfg)
+	}
+}
+
+func TestLoadFmtConfigInvalidEditorConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ".editorconfig"), "root = true\n[*]\nindent_size = wide\n")
+	if _, err := newFmtConfigLoader().load(filepath.Join(dir, "a.gray")); err == nil {
+		t.Fatal("expected error for invalid indent_size")
+	}
+}
+
+func TestParseFmtManifestIntegerErrors(t *testing.T) {
+	for src, want := range map[string]string{
+		"[fmt]\nindent_width = 0\n":       "indent_width must be a positive integer, got 0",
+		"[fmt]\nmax_blank_lines = -1\n":   "max_blank_lines must be a non-negative integer, got -1",
+		"[fmt]\nmax_line_length = wide\n": "max_line_length must be a non-negative integer, got wide",
+	} {
+		_, err := parseFmtManifest("gray.toml", src)
+		if err == nil || !strings.HasSuffix(err.Error(), want) {
+			t.Errorf("parseFmtManifest(%q) = %v, want %q", src, err, want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// hooks.go — Git hook management ("gray hooks"). Installs a pre-commit
+// hook that runs "gray fmt --check" on the staged contents of each staged
+// .gray file and "gray check" on those files, so commits stay formatted
+// and type-correct without re-checking the whole tree.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// grayHookMarker identifies hooks written by gray so they can be replaced
+// or removed without touching hand-written ones.
+const grayHookMarker = "# Installed by `gray hooks install`."
+
+// preCommitHook checks formatting against the staged blob (what is about
+// to be committed, not the working tree) and then type-checks the files;
+// gray check reaches module files through the programs importing them.
+const preCommitHook = `#!/bin/sh
+` + grayHookMarker + `
+# Checks formatting and types of staged .gray files. Set GRAY to use a
+# specific gray binary; bypass once with 'git commit --no-verify'.
+GRAY=${GRAY:-gray}
+
+files=$(git diff --cached --name-only --diff-filter=ACMR -- '*.gray')
+[ -z "$files" ] && exit 0
+
+status=0
+IFS='
+'
+for f in $files; do
+    git show ":$f" | "$GRAY" fmt --check --stdin --stdin-filename "$f" || status=1
+done
+if [ $status -ne 0 ]; then
+    echo "pre-commit: run 'gray fmt' on the files above and stage the result" >&2
+    exit 1
+fi
+
+"$GRAY" check $files || exit 1
+`
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage git hooks for Grayscale projects",
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install a pre-commit hook that checks staged .gray files",
+	Long: `Write a git pre-commit hook that runs "gray fmt --check" and "gray check"
+on the .gray files staged for commit. Formatting is checked against the
+staged contents, so partially staged files are judged by what will be
+committed.
+
+An existing pre-commit hook that was not written by gray is left alone
+unless --force is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+		path, err := installPreCommitHook(force)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gray hooks: %v\n", err)
+			return &ExitError{1}
+		}
+		fmt.Printf("Installed pre-commit hook at %s\n", path)
+		return nil
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the pre-commit hook installed by gray",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := uninstallPreCommitHook()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gray hooks: %v\n", err)
+			return &ExitError{1}
+		}
+		fmt.Printf("Removed pre-commit hook %s\n", path)
+		return nil
+	},
+}
+
+// preCommitHookPath returns where git looks for the pre-commit hook of
+// the repository containing the working directory, honouring
+// core.hooksPath.
+func preCommitHookPath() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	out, err := gitOutput(cwd, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	dir := strings.TrimSpace(out)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(cwd, dir)
+	}
+	return filepath.Join(dir, "pre-commit"), nil
+}
+
+// installPreCommitHook writes the hook and returns its path.
+func installPreCommitHook(force bool) (string, error) {
+	path, err := preCommitHookPath()
+	if err != nil {
+		return "", err
+	}
+	if existing, err := os.ReadFile(path); err == nil && !force && !strings.Contains(string(existing), grayHookMarker) {
+		return "", fmt.Errorf("%s already exists and was not installed by gray; use --force to replace it", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(preCommitHook), 0o755); err != nil {
+		return "", err
+	}
+	// WriteFile keeps the mode of an existing file; make sure it runs.
+	return path, os.Chmod(path, 0o755)
+}
+
+// uninstallPreCommitHook removes the hook if gray installed it.
+func uninstallPreCommitHook() (string, error) {
+	path, err := preCommitHookPath()
+	if err != nil {
+		return "", err
+	}
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("no pre-commit hook installed at %s", path)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !strings.Contains(string(existing), grayHookMarker) {
+		return "", fmt.Errorf("%s was not installed by gray; leaving it in place", path)
+	}
+	return path, os.Remove(path)
+}
@@ -0,0 +1,137 @@
+// hooks_test.go — Tests for pre-commit hook installation: writing an
+// executable hook, refusing to overwrite foreign hooks without --force,
+// honouring core.hooksPath, uninstalling only gray's own hook, and running
+// the hook through git commit.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+// TestMain lets the test binary stand in for gray (GRAY_TEST_RUN_MAIN=1),
+// so an installed hook can be run through git commit.
+func TestMain(m *testing.M) {
+	if os.Getenv("GRAY_TEST_RUN_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestInstallPreCommitHook(t *testing.T) {
+	dir := newTestGitRepo(t, map[string]string{"a.gray": "a\n"})
+	runFromTempDir(t, dir, func() {
+		path, err := installPreCommitHook(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(dir, ".git", "hooks", "pre-commit"); path != want {
+			t.Errorf("hook path = %s, want %s", path, want)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm()&0o100 == 0 {
+			t.Errorf("hook is not executable: %v", info.Mode())
+		}
+		data, _ := os.ReadFile(path)
+		if !strings.Contains(string(data), "fmt --check --stdin") || !strings.Contains(string(data), "check $files") {
+			t.Errorf("hook does not run fmt --check and check:\n%s", data)
+		}
+
+		// Re-installing over our own hook is fine.
+		if _, err := installPreCommitHook(false); err != nil {
+			t.Errorf("reinstall: %v", err)
+		}
+		if _, err := uninstallPreCommitHook(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("hook still present after uninstall")
+		}
+	})
+}
+
+func TestInstallPreCommitHookForeign(t *testing.T) {
+	dir := newTestGitRepo(t, map[string]string{"a.gray": "a\n"})
+	hook := filepath.Join(dir, ".git", "hooks", "pre-commit")
+	writeTestFile(t, hook, "#!/bin/sh\nmake lint\n")
+	runFromTempDir(t, dir, func() {
+		if _, err := installPreCommitHook(false); err == nil {
+			t.Fatal("expected an error when a foreign hook exists")
+		}
+		if _, err := uninstallPreCommitHook(); err == nil {
+			t.Fatal("uninstall removed a foreign hook")
+		}
+		if _, err := installPreCommitHook(true); err != nil {
+			t.Fatalf("--force: %v", err)
+		}
+		data, _ := os.ReadFile(hook)
+		if !strings.Contains(string(data), grayHookMarker) {
+			t.Error("--force did not replace the hook")
+		}
+	})
+}
+
+func TestInstallPreCommitHookHooksPath(t *testing.T) {
+	dir := newTestGitRepo(t, map[string]string{"a.gray": "a\n"})
+	cmd := exec.Command("git", "config", "core.hooksPath", ".githooks")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git config: %v\n%s", err, out)
+	}
+	runFromTempDir(t, dir, func() {
+		path, err := installPreCommitHook(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(dir, ".githooks", "pre-commit"); path != want {
+			t.Errorf("hook path = %s, want %s", path, want)
+		}
+	})
+}
+
+func TestPreCommitHookModuleFile(t *testing.T) {
+	if _, err := grayc.Find(); err != nil {
+		t.Skip("grayc not available")
+	}
+	dir := newTestGitRepo(t, map[string]string{
+		"main.gray":   "import \"./models\"\n\ndo main() {\n    println(models.count())\n}\n",
+		"models.gray": "do count() -> int {\n    return 1\n}\n",
+	})
+	runFromTempDir(t, dir, func() {
+		if _, err := installPreCommitHook(false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	commit := func(models string) (string, error) {
+		writeTestFile(t, filepath.Join(dir, "models.gray"), models)
+		cmd := exec.Command("sh", "-c", "git add models.gray && git -c user.name=test -c user.email=test@example.com commit -q -m change")
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GRAY="+os.Args[0], "GRAY_TEST_RUN_MAIN=1")
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+
+	// models.gray has no main(); it is checked through main.gray.
+	if out, err := commit("do count() -> int {\n    return 2\n}\n"); err != nil {
+		t.Fatalf("commit of a module file refused: %v\n%s", err, out)
+	}
+	// Renaming count breaks main.gray, which the hook reports.
+	out, err := commit("do total() -> int {\n    return 2\n}\n")
+	if err == nil || !strings.Contains(out, "main.gray") {
+		t.Errorf("commit breaking main.gray was accepted: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,346 @@
+// imports.go — Import organisation for "gray fmt". Rewrites each run of
+// top-level import lines as one import per line, merging duplicates and
+// grouping stdlib (@module) imports before local ("./path") imports and
+// C header imports, each group sorted. Aliases and `import and use` are
+// preserved, and imports the checker reports as unused (W1002) can
+// optionally be dropped.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+// importItem is one module named by an import statement.
+type importItem struct {
+	Alias   string // explicit alias, "" when none was written
+	Path    string // module name for stdlib, path or header text otherwise
+	Quoted  string // original quoted token for local and C imports
+	Stdlib  bool
+	CHeader bool
+	AutoUse bool
+}
+
+// name is the module name the item binds in the importing file.
+func (it importItem) name() string {
+	switch {
+	case it.Alias != "":
+		return it.Alias
+	case it.Stdlib:
+		return it.Path
+	case it.CHeader:
+		return "c"
+	}
+	return importModuleName(it.Path)
+}
+
+func (it importItem) key() string {
+	return strconv.FormatBool(it.Stdlib) + strconv.FormatBool(it.CHeader) + "\x00" + it.Alias + "\x00" + it.Path
+}
+
+// String renders the item as a single import statement.
+func (it importItem) String() string {
+	var sb strings.Builder
+	sb.WriteString("import ")
+	if it.AutoUse {
+		sb.WriteString("and use ")
+	}
+	switch {
+	case it.CHeader:
+		sb.WriteString("c " + it.Quoted)
+	case it.Stdlib:
+		if it.Alias != "" {
+			sb.WriteString(it.Alias + " ")
+		}
+		sb.WriteString("@" + it.Path)
+	default:
+		if it.Alias != "" {
+			sb.WriteString(it.Alias + " ")
+		}
+		sb.WriteString(it.Quoted)
+	}
+	return sb.String()
+}
+
+// importStmt is one top-level import line and the items it names.
+type importStmt struct {
+	Line  int
+	Index int // position among the statements found by findImportStmts
+	Items []importItem
+}
+
+// unusedImportFunc reports whether the import of module name by the
+// statement at index (see importStmt.Index) is unused and should be
+// dropped. Statements are counted rather than located by line because
+// formatting moves lines but keeps the imports in order.
+type unusedImportFunc func(index int, name string) bool
+
+// organizeImports rewrites the import runs in src. A run is a sequence
+// of top-level import lines separated only by blank lines; comments end
+// a run so hand-written grouping notes are never moved. Runs containing
+// anything the organiser does not understand are left untouched.
+func organizeImports(src string, unused unusedImportFunc) string {
+	stmts := findImportStmts(src)
+	if len(stmts) == 0 {
+		return src
+	}
+	lines := strings.SplitAfter(src, "\n")
+
+	// Group statements into runs.
+	var runs [][]importStmt
+	for i, st := range stmts {
+		if i > 0 && onlyBlankBetween(lines, stmts[i-1].Line, st.Line) {
+			runs[len(runs)-1] = append(runs[len(runs)-1], st)
+			continue
+		}
+		runs = append(runs, []importStmt{st})
+	}
+
+	// Rewrite runs from the bottom up so earlier line numbers stay valid.
+	for r := len(runs) - 1; r >= 0; r-- {
+		run := runs[r]
+		first, last := run[0].Line, run[len(run)-1].Line
+		rendered := renderImportRun(run, unused)
+		end := last
+		if rendered == "" {
+			// Swallow the blank lines that separated the run from the code.
+			for end < len(lines) && strings.TrimSpace(lines[end]) == "" && lines[end] != "" {
+				end++
+			}
+		}
+		var out []string
+		out = append(out, lines[:first-1]...)
+		if rendered != "" {
+			out = append(out, rendered)
+		}
+		out = append(out, lines[end:]...)
+		lines = out
+	}
+	return strings.Join(lines, "")
+}
+
+// onlyBlankBetween reports whether every line strictly between from and
+// to (1-based) is blank.
+func onlyBlankBetween(lines []string, from, to int) bool {
+	for l := from + 1; l < to; l++ {
+		if strings.TrimSpace(lines[l-1]) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// renderImportRun merges, filters, groups, and sorts the items of a run.
+func renderImportRun(run []importStmt, unused unusedImportFunc) string {
+	var stdlib, local, cHeaders []importItem
+	index := map[string]*importItem{}
+	var order []string
+	for _, st := range run {
+		for _, it := range st.Items {
+			if unused != nil && !it.CHeader && unused(st.Index, it.name()) {
+				continue
+			}
+			k := it.key()
+			if prev, ok := index[k]; ok {
+				// `import @m` + `import and use @m` is the same as the latter.
+				prev.AutoUse = prev.AutoUse || it.AutoUse
+				continue
+			}
+			item := it
+			index[k] = &item
+			order = append(order, k)
+		}
+	}
+	for _, k := range order {
+		it := *index[k]
+		switch {
+		case it.CHeader:
+			cHeaders = append(cHeaders, it)
+		case it.Stdlib:
+			stdlib = append(stdlib, it)
+		default:
+			local = append(local, it)
+		}
+	}
+	byPath := func(items []importItem) func(i, j int) bool {
+		return func(i, j int) bool {
+			if items[i].Path != items[j].Path {
+				return items[i].Path < items[j].Path
+			}
+			return items[i].Alias < items[j].Alias
+		}
+	}
+	sort.SliceStable(stdlib, byPath(stdlib))
+	sort.SliceStable(local, byPath(local))
+
+	var groups []string
+	for _, g := range [][]importItem{stdlib, local, cHeaders} {
+		if len(g) == 0 {
+			continue
+		}
+		var sb strings.Builder
+		for _, it := range g {
+			sb.WriteString(it.String())
+			sb.WriteByte('\n')
+		}
+		groups = append(groups, sb.String())
+	}
+	return strings.Join(groups, "\n")
+}
+
+// findImportStmts returns every top-level import statement that sits
+// alone on its line and uses only forms the organiser can re-render.
+func findImportStmts(src string) []importStmt {
+	toks := lexGraySource(src)
+	var stmts []importStmt
+	depth := 0
+	lineStart := true
+	for i := 0; i < len(toks); i++ {
+		t := toks[i]
+		switch {
+		case t.Kind == tokNewline:
+			lineStart = true
+			continue
+		case t.isPunct("{") || t.isPunct("(") || t.isPunct("["):
+			depth++
+		case t.isPunct("}") || t.isPunct(")") || t.isPunct("]"):
+			if depth > 0 {
+				depth--
+			}
+		case t.isKeyword("import") && depth == 0 && lineStart:
+			j := i
+			for j < len(toks) && toks[j].Kind != tokNewline && toks[j].Kind != tokEOF {
+				j++
+			}
+			if st, ok := parseImportStmt(src, toks[i:j]); ok {
+				st.Index = len(stmts)
+				stmts = append(stmts, st)
+			}
+			i = j - 1
+		}
+		lineStart = false
+	}
+	return stmts
+}
+
+// parseImportStmt parses the tokens of one import line. ok is false when
+// the line carries a comment or an unrecognised form.
+func parseImportStmt(src string, toks []grayToken) (importStmt, bool) {
+	first, last := toks[0], toks[len(toks)-1]
+	if last.Line != first.Line {
+		return importStmt{}, false
+	}
+	lineStart := strings.LastIndexByte(src[:first.Offset], '\n') + 1
+	lineEnd := len(src)
+	if n := strings.IndexByte(src[last.End:], '\n'); n >= 0 {
+		lineEnd = last.End + n
+	}
+	if strings.TrimSpace(src[lineStart:first.Offset]) != "" || strings.TrimSpace(src[last.End:lineEnd]) != "" {
+		return importStmt{}, false // trailing comment
+	}
+
+	st := importStmt{Line: first.Line}
+	i := 1
+	at := func(k int) grayToken {
+		if k < len(toks) {
+			return toks[k]
+		}
+		return grayToken{Kind: tokEOF}
+	}
+	autoUse := false
+	if at(i).Kind == tokIdent && at(i).Text == "and" && at(i+1).isKeyword("use") {
+		autoUse = true
+		i += 2
+	}
+	for {
+		it := importItem{AutoUse: autoUse}
+		t := at(i)
+		switch {
+		case t.Kind == tokIdent && t.Text == "c" && at(i+1).Kind == tokString:
+			it.CHeader, it.Path, it.Quoted = true, at(i+1).stringValue(), at(i+1).Text
+			i += 2
+		case t.Kind == tokIdent && at(i+1).isPunct("@") && at(i+2).Kind == tokIdent:
+			it.Alias, it.Stdlib, it.Path = t.Text, true, at(i+2).Text
+			i += 3
+		case t.isPunct("@") && at(i+1).Kind == tokIdent:
+			it.Stdlib, it.Path = true, at(i+1).Text
+			i += 2
+		case t.Kind == tokIdent && at(i+1).Kind == tokString:
+			it.Alias, it.Path, it.Quoted = t.Text, at(i+1).stringValue(), at(i+1).Text
+			i += 2
+		case t.Kind == tokString:
+			it.Path, it.Quoted = t.stringValue(), t.Text
+			i++
+		default:
+			return importStmt{}, false
+		}
+		st.Items = append(st.Items, it)
+		if i >= len(toks) {
+			return st, true
+		}
+		if !at(i).isPunct(",") {
+			return importStmt{}, false
+		}
+		i++
+	}
+}
+
+// unusedImportRe matches the W1002 warning emitted by grayc check.
+var unusedImportRe = regexp.MustCompile(`warning\[W1002\]: module '([^']+)' is imported but never used`)
+
+// locationRe matches the "--> file:line:col" line that follows a diagnostic.
+var locationRe = regexp.MustCompile(`-->\s+.*:(\d+):\d+`)
+
+// parseUnusedImports extracts (line, module) pairs from grayc check output.
+func parseUnusedImports(out string) map[int]map[string]bool {
+	unused := map[int]map[string]bool{}
+	lines := strings.Split(out, "\n")
+	for i, line := range lines {
+		m := unusedImportRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for j := i + 1; j < len(lines) && j <= i+2; j++ {
+			if loc := locationRe.FindStringSubmatch(lines[j]); loc != nil {
+				n, _ := strconv.Atoi(loc[1])
+				if unused[n] == nil {
+					unused[n] = map[string]bool{}
+				}
+				unused[n][m[1]] = true
+				break
+			}
+		}
+	}
+	return unused
+}
+
+// unusedImportsFor runs the checker on path, whose contents are src, and
+// returns a lookup of the imports it reports as unused. The checker's line
+// numbers are matched against the statements of src itself, before it is
+// formatted. Nothing is reported unused when the file has errors, since a
+// broken file can hide real uses.
+func unusedImportsFor(path string, src []byte) unusedImportFunc {
+	out, code, err := grayc.CheckOutput(path, []string{"--no-color"})
+	if err != nil || code != 0 {
+		return nil
+	}
+	byLine := parseUnusedImports(string(out))
+	unused := map[int]map[string]bool{}
+	for _, st := range findImportStmts(string(src)) {
+		if names := byLine[st.Line]; names != nil {
+			unused[st.Index] = names
+		}
+	}
+	return func(index int, name string) bool {
+		return unused[index][name]
+	}
+}
@@ -0,0 +1,127 @@
+// imports_test.go — Tests for formatter import organisation: grouping
+// and sorting, duplicate merging, alias and `import and use` handling,
+// comment preservation, and dropping imports reported unused.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+func TestOrganizeImportsGroupsAndSorts(t *testing.T) {
+	in := `import "./utils.gray"
+import str@strings, @math
+
+import @math
+import lib"./lib.gray"
+import c "stdio.h"
+
+do main() {
+    println(math.PI)
+}
+`
+	want := `import @math
+import str @strings
+
+import lib "./lib.gray"
+import "./utils.gray"
+
+import c "stdio.h"
+
+do main() {
+    println(math.PI)
+}
+`
+	if got := organizeImports(in, nil); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if got := organizeImports(want, nil); got != want {
+		t.Errorf("not idempotent:\n%s", got)
+	}
+}
+
+func TestOrganizeImportsMergesAutoUse(t *testing.T) {
+	in := "import @strings\nimport and use @strings, @arrays\n\ndo main() {}\n"
+	want := "import and use @arrays\nimport and use @strings\n\ndo main() {}\n"
+	if got := organizeImports(in, nil); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestOrganizeImportsLeavesCommentsAlone(t *testing.T) {
+	in := "import @strings // text helpers\nimport @arrays\n// local\nimport \"./b.gray\"\n"
+	// The commented line is skipped; the comment line splits the runs.
+	want := "import @strings // text helpers\nimport @arrays\n// local\nimport \"./b.gray\"\n"
+	if got := organizeImports(in, nil); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestOrganizeImportsIgnoresNestedAndStrings(t *testing.T) {
+	in := "do main() {\n    mut s = \"import @b, @a\"\n}\n"
+	if got := organizeImports(in, nil); got != in {
+		t.Errorf("non-import code changed:\n%s", got)
+	}
+}
+
+func TestOrganizeImportsDropsUnused(t *testing.T) {
+	in := "import @strings, @math\nimport u \"./u\"\n\ndo main() {}\n"
+	unused := func(index int, name string) bool {
+		return (index == 0 && name == "math") || (index == 1 && name == "u")
+	}
+	want := "import @strings\n\ndo main() {}\n"
+	if got := organizeImports(in, unused); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	all := func(int, string) bool { return true }
+	if got := organizeImports(in, all); got != "do main() {}\n" {
+		t.Errorf("dropping every import left:\n%q", got)
+	}
+}
+
+func TestParseUnusedImports(t *testing.T) {
+	out := `warning[W1002]: module 'math' is imported but never used; remove the import or use the module
+  --> app.gray:3:1
+   |
+warning[W1003]: function 'f' is declared but never called
+  --> app.gray:9:1
+`
+	got := parseUnusedImports(out)
+	if !got[3]["math"] || len(got) != 1 {
+		t.Errorf("parseUnusedImports = %v", got)
+	}
+}
+
+func TestFmtDropUnusedImportsNextLine(t *testing.T) {
+	if _, err := grayc.Find(); err != nil {
+		t.Skip("grayc not available")
+	}
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "gray.toml"), "[fmt]\nbrace_style = \"next_line\"\n")
+	file := filepath.Join(dir, "app.gray")
+	// The checker reports @math on line 7; moving the first '{' down puts
+	// it on line 8 of the formatted source.
+	writeTestFile(t, file, "import @strings\n\ndo helper() -> string {\n    return strings.to_upper(\"a\")\n}\n\n"+
+		"import @math\n\ndo main() {\n    println(helper())\n}\n")
+	if code := runFmt([]string{file}, fmtOptions{DropUnusedImports: true}); code != 0 {
+		t.Fatalf("runFmt = %d", code)
+	}
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(got), "@math") || !strings.Contains(string(got), "import @strings\n") ||
+		!strings.Contains(string(got), "do main()\n{\n") {
+		t.Errorf("formatted:\n%s", got)
+	}
+}
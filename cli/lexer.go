@@ -0,0 +1,278 @@
+// lexer.go — Lightweight token scanner for .gray sources used by the CLI
+// tooling (symbols, doc). Mirrors the grayc lexer closely enough to skip
+// comments, strings, raw strings, and interpolations correctly, without
+// needing the compiler binary.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import "strings"
+
+// grayTokenKind classifies a grayToken.
+type grayTokenKind int
+
+const (
+	tokEOF grayTokenKind = iota
+	tokNewline
+	tokIdent
+	tokKeyword
+	tokNumber
+	tokString
+	tokRawString
+	tokChar
+	tokAttr  // #doc, #json, #flags, #strict, ...
+	tokPunct // operators and delimiters
+)
+
+// grayToken is one lexical token. Offset/End are byte offsets into the
+// source; Line and Col are 1-based (Col counts bytes, like grayc).
+type grayToken struct {
+	Kind   grayTokenKind
+	Text   string
+	Line   int
+	Col    int
+	Offset int
+	End    int
+	// Interp holds the tokens lexed from ${...} segments of a
+	// double-quoted string so identifier references inside
+	// interpolations are not lost.
+	Interp []grayToken
+}
+
+// grayKeywords mirrors the keyword table in grayc/src/lexer/token.c.
+var grayKeywords = map[string]bool{
+	"as_long_as": true, "bit_and": true, "bit_not": true, "bit_or": true,
+	"bit_shift_left": true, "bit_shift_right": true, "bit_xor": true,
+	"break": true, "cast": true, "const": true, "continue": true,
+	"default": true, "do": true, "else": true, "ensure": true, "enum": true,
+	"false": true, "for": true, "for_each": true, "if": true, "import": true,
+	"in": true, "is": true, "loop": true, "module": true, "mut": true,
+	"new": true, "nil": true, "not_in": true, "or": true, "or_return": true,
+	"otherwise": true, "private": true, "range": true, "return": true,
+	"struct": true, "true": true, "use": true, "using": true, "when": true,
+	"while": true,
+}
+
+// grayPunct lists multi-character operators, longest first.
+var grayPunct = []string{
+	"->", "==", "!=", "<=", ">=", "+=", "-=", "*=", "/=", "%=",
+	"++", "--", "&&", "||",
+}
+
+// grayLexer walks a source string producing grayTokens.
+type grayLexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+// lexGraySource tokenizes src. Comments are dropped; newlines are kept
+// as tokNewline tokens since declarations in Grayscale are line-based.
+// The lexer never fails: unterminated strings or comments simply run to
+// the end of the input.
+func lexGraySource(src string) []grayToken {
+	lx := &grayLexer{src: src, line: 1, col: 1}
+	return lx.run(len(src))
+}
+
+func (lx *grayLexer) advance() {
+	if lx.src[lx.pos] == '\n' {
+		lx.line++
+		lx.col = 1
+	} else {
+		lx.col++
+	}
+	lx.pos++
+}
+
+func (lx *grayLexer) peek(n int) byte {
+	if lx.pos+n < len(lx.src) {
+		return lx.src[lx.pos+n]
+	}
+	return 0
+}
+
+// run lexes until limit (exclusive byte offset) and returns the tokens.
+func (lx *grayLexer) run(limit int) []grayToken {
+	var toks []grayToken
+	for lx.pos < limit {
+		c := lx.src[lx.pos]
+		startLine, startCol, start := lx.line, lx.col, lx.pos
+		emit := func(kind grayTokenKind) {
+			toks = append(toks, grayToken{
+				Kind: kind, Text: lx.src[start:lx.pos],
+				Line: startLine, Col: startCol, Offset: start, End: lx.pos,
+			})
+		}
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			lx.advance()
+		case c == '\n':
+			lx.advance()
+			emit(tokNewline)
+		case c == '/' && lx.peek(1) == '/':
+			for lx.pos < limit && lx.src[lx.pos] != '\n' {
+				lx.advance()
+			}
+		case c == '/' && lx.peek(1) == '*':
+			lx.advance()
+			lx.advance()
+			for lx.pos < limit && !(lx.src[lx.pos] == '*' && lx.peek(1) == '/') {
+				lx.advance()
+			}
+			if lx.pos < limit {
+				lx.advance()
+				lx.advance()
+			}
+		case isIdentStart(c):
+			for lx.pos < limit && isIdentChar(lx.src[lx.pos]) {
+				lx.advance()
+			}
+			if grayKeywords[lx.src[start:lx.pos]] {
+				emit(tokKeyword)
+			} else {
+				emit(tokIdent)
+			}
+		case c >= '0' && c <= '9':
+			for lx.pos < limit && (isIdentChar(lx.src[lx.pos]) ||
+				(lx.src[lx.pos] == '.' && lx.peek(1) >= '0' && lx.peek(1) <= '9')) {
+				lx.advance()
+			}
+			emit(tokNumber)
+		case c == '#' && isIdentStart(lx.peek(1)):
+			lx.advance()
+			for lx.pos < limit && isIdentChar(lx.src[lx.pos]) {
+				lx.advance()
+			}
+			emit(tokAttr)
+		case c == '`':
+			lx.advance()
+			for lx.pos < limit && lx.src[lx.pos] != '`' {
+				lx.advance()
+			}
+			if lx.pos < limit {
+				lx.advance()
+			}
+			emit(tokRawString)
+		case c == '\'':
+			lx.advance()
+			for lx.pos < limit && lx.src[lx.pos] != '\'' && lx.src[lx.pos] != '\n' {
+				if lx.src[lx.pos] == '\\' && lx.pos+1 < limit {
+					lx.advance()
+				}
+				lx.advance()
+			}
+			if lx.pos < limit && lx.src[lx.pos] == '\'' {
+				lx.advance()
+			}
+			emit(tokChar)
+		case c == '"':
+			interp := lx.lexString(limit)
+			emit(tokString)
+			toks[len(toks)-1].Interp = interp
+		default:
+			matched := false
+			for _, p := range grayPunct {
+				if strings.HasPrefix(lx.src[lx.pos:limit], p) {
+					for range p {
+						lx.advance()
+					}
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				lx.advance()
+			}
+			emit(tokPunct)
+		}
+	}
+	return toks
+}
+
+// lexString consumes a double-quoted string starting at the opening quote
+// and returns the tokens found inside any ${...} interpolation segments.
+func (lx *grayLexer) lexString(limit int) []grayToken {
+	var interp []grayToken
+	lx.advance() // opening quote
+	for lx.pos < limit {
+		c := lx.src[lx.pos]
+		if c == '\\' && lx.pos+1 < limit {
+			lx.advance()
+			lx.advance()
+			continue
+		}
+		if c == '"' {
+			lx.advance()
+			return interp
+		}
+		if c == '$' && lx.peek(1) == '{' {
+			lx.advance()
+			lx.advance()
+			end := matchInterpEnd(lx.src, lx.pos, limit)
+			interp = append(interp, lx.run(end)...)
+			if lx.pos < limit {
+				lx.advance() // closing brace
+			}
+			continue
+		}
+		lx.advance()
+	}
+	return interp
+}
+
+// matchInterpEnd returns the offset of the '}' closing an interpolation
+// whose body starts at pos, skipping nested braces and string literals.
+func matchInterpEnd(src string, pos, limit int) int {
+	depth := 0
+	for i := pos; i < limit; i++ {
+		switch src[i] {
+		case '"':
+			for i++; i < limit && src[i] != '"'; i++ {
+				if src[i] == '\\' {
+					i++
+				}
+			}
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return limit
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// isPunct reports whether tok is the punctuation text p.
+func (t grayToken) isPunct(p string) bool {
+	return t.Kind == tokPunct && t.Text == p
+}
+
+// isKeyword reports whether tok is the keyword kw.
+func (t grayToken) isKeyword(kw string) bool {
+	return t.Kind == tokKeyword && t.Text == kw
+}
+
+// stringValue returns the contents of a string or raw string token with
+// the quotes removed. Escape sequences are left as written.
+func (t grayToken) stringValue() string {
+	if (t.Kind == tokString || t.Kind == tokRawString) && len(t.Text) >= 2 {
+		return t.Text[1 : len(t.Text)-1]
+	}
+	return t.Text
+}
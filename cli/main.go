@@ -38,6 +38,9 @@ func main() {
 		if errors.As(err, &exitErr) {
 			os.Exit(exitErr.Code)
 		}
+		// Errors are silenced in cobra so they print once, here, without
+		// the usage text.
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
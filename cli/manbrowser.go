@@ -0,0 +1,1027 @@
+// manbrowser.go — "gray man --tui": a full-screen browser for the
+// reference data. The left pane is a tree of the builtins, the language
+// reference categories, and the stdlib modules; the right pane shows the
+// selected entry as gray man prints it. "/" searches names, then the full
+// text, as you type, and names on a page (a signature's HttpResponse, the
+// entries of a module index) are links to their own entries.
+//
+// The browser is a state machine (handleKey, render) that runManBrowser
+// draws on the terminal, so it is tested without one.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI styles used by the browser on top of the man page styles.
+const (
+	browserReverse    = "\033[7m"
+	browserReverseOff = "\033[27m"
+	browserUnderline  = "\033[4m"
+	browserLinkOff    = "\033[24;27m"
+)
+
+const (
+	browserMinWidth    = 40
+	browserMinHeight   = 6
+	browserSearchLimit = 200
+)
+
+// browserNode is a row of the tree. Key names the page it shows:
+//
+//	builtins, builtin:<name>         the builtins index and entries
+//	lang, category:<c>, lang:<key>   the language reference
+//	modules, module:<m>, stdlib:<m.n> the stdlib
+type browserNode struct {
+	Label    string
+	Key      string
+	Name     string // what gray man takes, e.g. "strings.split"
+	Tag      string // shown after the name in search results
+	Children []*browserNode
+	Open     bool
+	parent   *browserNode
+	depth    int
+}
+
+func (n *browserNode) isFolder() bool {
+	return len(n.Children) > 0
+}
+
+// browserLink is a name on a page that links to another page. Start and
+// End are columns of the line with styles removed.
+type browserLink struct {
+	Line, Start, End int
+	Key              string
+}
+
+type manBrowser struct {
+	color bool
+
+	tree   []*browserNode
+	nodes  map[string]*browserNode // by page key
+	named  []*browserNode          // every node with a Name, in tree order
+	byDoc  map[string]*browserNode // by manDoc source and name, e.g. "lang:if"
+	langs  map[string]string       // language reference name → page key
+	types  map[string][]string     // type name → page keys
+	docs   []manDoc
+	cache  map[string]string // page key → printed page
+	width  int
+	height int
+
+	// The left pane: the open rows of the tree, or the search results.
+	rows      []*browserNode
+	sel, top  int
+	searching bool
+	query     string
+	// The selection and page from before the search.
+	savedSel  int
+	savedPage string
+
+	// The right pane.
+	focusPage bool
+	page      string
+	lines     []string // formatted
+	plain     []string // formatted, styles removed
+	links     []browserLink
+	link      int // selected link, or -1
+	scroll    int
+	history   []string
+}
+
+// newManBrowser builds the tree and opens the stdlib modules.
+func newManBrowser(color bool) *manBrowser {
+	b := &manBrowser{
+		color: color,
+		nodes: map[string]*browserNode{},
+		byDoc: map[string]*browserNode{},
+		langs: map[string]string{},
+		types: map[string][]string{},
+		docs:  allManDocs(),
+		cache: map[string]string{},
+		width: defaultManWidth, height: 24,
+		link: -1,
+	}
+
+	builtins := b.add(nil, "Builtins", "builtins", "", "")
+	for _, g := range builtinGroups {
+		for _, n := range g.names {
+			b.add(builtins, n, "builtin:"+n, n, "builtin")
+			b.byDoc["builtin:"+n] = b.nodes["builtin:"+n]
+			if builtinManDocs[n].Kind == "type" {
+				b.types[n] = append(b.types[n], "builtin:"+n)
+			}
+		}
+	}
+
+	lang := b.add(nil, "Language", "lang", "", "")
+	for _, cat := range []string{"keywords", "types", "symbols", "attributes"} {
+		c := b.add(lang, cat, "category:"+cat, "", "")
+		for _, g := range langCategories[cat] {
+			for _, n := range g.Names {
+				key := n
+				if _, ok := langManDocs[n+"_type"]; ok {
+					key = n + "_type"
+				}
+				// Attributes are looked up without their '#'.
+				name := langDisplayName(strings.TrimPrefix(key, "#"))
+				node := b.add(c, n, "lang:"+key, name, "lang")
+				b.byDoc["lang:"+name] = node
+				b.langs[n] = node.Key
+			}
+		}
+	}
+
+	modules := b.add(nil, "Standard library", "modules", "", "")
+	for _, m := range sortedStdlibModules() {
+		mod := b.add(modules, m, "module:"+m, m, "module")
+		for _, g := range stdlibModuleGroups[m] {
+			for _, n := range g.Names {
+				key := m + "." + n
+				b.add(mod, n, "stdlib:"+key, key, "")
+				b.byDoc["stdlib:"+key] = b.nodes["stdlib:"+key]
+				if stdlibManDocs[key].Kind == "type" {
+					b.types[n] = append(b.types[n], "stdlib:"+key)
+				}
+			}
+		}
+	}
+
+	modules.Open = true
+	b.reveal("modules")
+	return b
+}
+
+func (b *manBrowser) add(parent *browserNode, label, key, name, tag string) *browserNode {
+	n := &browserNode{Label: label, Key: key, Name: name, Tag: tag, parent: parent}
+	if parent != nil {
+		n.depth = parent.depth + 1
+		parent.Children = append(parent.Children, n)
+	} else {
+		b.tree = append(b.tree, n)
+	}
+	b.nodes[key] = n
+	if name != "" {
+		b.named = append(b.named, n)
+	}
+	return n
+}
+
+// open starts the browser at name, as gray man would look it up, or
+// searches for it.
+func (b *manBrowser) open(name string) {
+	name = strings.TrimSuffix(name, "()")
+	for _, key := range []string{"module:" + name, "stdlib:" + name, "builtin:" + name, "category:" + name, b.langs[name]} {
+		if _, ok := b.nodes[key]; ok {
+			b.reveal(key)
+			return
+		}
+	}
+	if name == "builtins" || name == "lang" || name == "modules" {
+		b.reveal(name)
+		return
+	}
+	b.startSearch()
+	b.query = name
+	b.updateSearch()
+}
+
+// visible lists the rows of the tree under open folders.
+func (b *manBrowser) visible() []*browserNode {
+	var rows []*browserNode
+	var walk func([]*browserNode)
+	walk = func(nodes []*browserNode) {
+		for _, n := range nodes {
+			rows = append(rows, n)
+			if n.Open {
+				walk(n.Children)
+			}
+		}
+	}
+	walk(b.tree)
+	return rows
+}
+
+func (b *manBrowser) rowIndex(n *browserNode) int {
+	for i, r := range b.rows {
+		if r == n {
+			return i
+		}
+	}
+	return 0
+}
+
+// reveal selects the tree row for key, opening its folders, and shows
+// its page. Pages without a row (help) are only shown.
+func (b *manBrowser) reveal(key string) {
+	b.searching = false
+	n := b.nodes[key]
+	if n != nil {
+		for p := n.parent; p != nil; p = p.parent {
+			p.Open = true
+		}
+	}
+	b.rows = b.visible()
+	if n != nil {
+		b.sel = b.rowIndex(n)
+	}
+	b.show(key)
+}
+
+// show puts the page for key in the right pane.
+func (b *manBrowser) show(key string) {
+	b.page = key
+	b.scroll = 0
+	b.link = -1
+	b.format()
+}
+
+// follow shows key, remembering the current page for back.
+func (b *manBrowser) follow(key string) {
+	b.history = append(b.history, b.page)
+	b.reveal(key)
+}
+
+func (b *manBrowser) back() {
+	if len(b.history) == 0 {
+		return
+	}
+	key := b.history[len(b.history)-1]
+	b.history = b.history[:len(b.history)-1]
+	b.reveal(key)
+}
+
+// pageText is the page for key as the man printers write it.
+func (b *manBrowser) pageText(key string) string {
+	if text, ok := b.cache[key]; ok {
+		return text
+	}
+	kind, name, _ := strings.Cut(key, ":")
+	text, err := captureManOutput(func() error {
+		switch kind {
+		case "builtins":
+			printBuiltinsIndex()
+		case "builtin":
+			printBuiltinEntry(name, builtinManDocs[name])
+		case "lang":
+			if name == "" {
+				printLangIndex()
+			} else {
+				printLangEntry(langDisplayName(name), langManDocs[name])
+			}
+		case "category":
+			return printLangCategoryIndex(name)
+		case "modules":
+			printBrowserModules()
+		case "module":
+			return printStdlibModuleIndex(name)
+		case "stdlib":
+			_, entry, _ := strings.Cut(name, ".")
+			printStdlibEntry(entry, stdlibManDocs[name])
+		case "help":
+			printBrowserHelp()
+		}
+		return nil
+	})
+	if err != nil {
+		text += err.Error() + "\n"
+	}
+	text = strings.ReplaceAll(text, "\t", "    ")
+	b.cache[key] = text
+	return text
+}
+
+func printBrowserModules() {
+	fmt.Println("Standard library  (select a module)")
+	fmt.Println(strings.Repeat("─", 50))
+	for _, m := range sortedStdlibModules() {
+		fmt.Printf("  %-14s %d entries\n", m, len(stdlibModules[m]))
+	}
+}
+
+func printBrowserHelp() {
+	fmt.Println("gray man --tui  (keys)")
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Println("Tree:")
+	fmt.Println("  ↑ ↓  j k        move")
+	fmt.Println("  → l  Enter      open a folder, or go to the page")
+	fmt.Println("  ← h            close a folder, or go to its parent")
+	fmt.Println("  Tab            go to the page's first link")
+	fmt.Println()
+	fmt.Println("Page:")
+	fmt.Println("  ↑ ↓  j k        scroll")
+	fmt.Println("  Tab  Shift-Tab  next or previous link")
+	fmt.Println("  Enter          follow the link")
+	fmt.Println("  ← h  Esc        back to the tree")
+	fmt.Println()
+	fmt.Println("Anywhere:")
+	fmt.Println("  PgUp PgDn      scroll the page")
+	fmt.Println("  b  Backspace   back to the previous page")
+	fmt.Println("  /              search names, then descriptions; Enter opens,")
+	fmt.Println("                 Esc cancels")
+	fmt.Println("  ?              this page")
+	fmt.Println("  q  Ctrl-C      quit")
+}
+
+var browserANSI = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// format lays out the current page for the pane width and finds its links.
+func (b *manBrowser) format() {
+	text := formatManText(b.pageText(b.page), b.pageWidth(), b.color)
+	b.lines = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	b.plain = make([]string, len(b.lines))
+	for i, l := range b.lines {
+		b.plain[i] = browserANSI.ReplaceAllString(l, "")
+	}
+	b.links = b.findLinks()
+	if b.link >= len(b.links) {
+		b.link = -1
+	}
+	b.scrollPage(0)
+}
+
+var browserWord = regexp.MustCompile(`#?[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*`)
+
+// findLinks finds the names on the page that have pages of their own,
+// skipping the title and its rule.
+func (b *manBrowser) findLinks() []browserLink {
+	var links []browserLink
+	for i := 2; i < len(b.plain); i++ {
+		line := b.plain[i]
+		for _, loc := range browserWord.FindAllStringIndex(line, -1) {
+			key := b.linkTarget(line[loc[0]:loc[1]], line[:loc[0]])
+			if key == "" || key == b.page {
+				continue
+			}
+			start := utf8.RuneCountInString(line[:loc[0]])
+			links = append(links, browserLink{Line: i, Start: start, End: start + utf8.RuneCountInString(line[loc[0]:loc[1]]), Key: key})
+		}
+	}
+	return links
+}
+
+// linkTarget is the page word links to, or "". Qualified stdlib names,
+// type names, and a "Module:" value link anywhere; the names listed on an
+// index page link to their entries.
+func (b *manBrowser) linkTarget(word, before string) string {
+	if _, ok := stdlibManDocs[word]; ok {
+		return "stdlib:" + word
+	}
+	if strings.TrimSpace(before) == "Module:" {
+		if word == "builtin" {
+			return "builtins"
+		}
+		if _, ok := stdlibModules[word]; ok {
+			return "module:" + word
+		}
+	}
+	kind, name, _ := strings.Cut(b.page, ":")
+	switch kind {
+	case "builtins":
+		if _, ok := builtinManDocs[word]; ok {
+			return "builtin:" + word
+		}
+	case "modules":
+		if _, ok := stdlibModules[word]; ok {
+			return "module:" + word
+		}
+	case "module":
+		if _, ok := stdlibManDocs[name+"."+word]; ok {
+			return "stdlib:" + name + "." + word
+		}
+	case "lang", "category":
+		// A language entry's own prose is full of keywords; only the
+		// indexes link them.
+		if kind == "lang" && name != "" {
+			break
+		}
+		if _, ok := langCategories[word]; ok && kind == "lang" {
+			return "category:" + word
+		}
+		if key, ok := b.langs[word]; ok {
+			return key
+		}
+	}
+	keys := b.types[word]
+	if len(keys) == 0 {
+		return ""
+	}
+	// A type of the page's own module wins over one of the same name
+	// elsewhere (server.HttpResponse on a server page).
+	module := name
+	if kind == "stdlib" {
+		module = stdlibManDocs[name].Module
+	}
+	for _, k := range keys {
+		if k == "stdlib:"+module+"."+word {
+			return k
+		}
+	}
+	return keys[0]
+}
+
+// Layout. The left pane is a third of the screen, between 18 and 34
+// columns; a rule and a space separate it from the page.
+
+func (b *manBrowser) treeWidth() int {
+	return min(max(b.width/3, 18), 34)
+}
+
+func (b *manBrowser) pageWidth() int {
+	return b.width - b.treeWidth() - 2
+}
+
+func (b *manBrowser) paneHeight() int {
+	return b.height - 2
+}
+
+// resize lays the page out again when the width changes.
+func (b *manBrowser) resize(width, height int) {
+	if width == b.width && height == b.height {
+		return
+	}
+	reformat := width != b.width
+	b.width, b.height = width, height
+	if reformat {
+		link := b.link
+		b.format()
+		if link < len(b.links) {
+			b.link = link
+		}
+	}
+	b.scrollPage(0)
+}
+
+func (b *manBrowser) scrollPage(delta int) {
+	b.scroll = min(max(b.scroll+delta, 0), max(len(b.lines)-b.paneHeight(), 0))
+}
+
+// handleKey applies a key from parseBrowserKeys and reports whether to
+// quit.
+func (b *manBrowser) handleKey(key string) bool {
+	if key == "ctrl-c" {
+		return true
+	}
+	if b.searching {
+		b.searchKey(key)
+		return false
+	}
+	switch key {
+	case "q":
+		return true
+	case "/":
+		b.startSearch()
+	case "?":
+		if b.page != "help" {
+			b.follow("help")
+		}
+	case "b", "backspace":
+		b.back()
+	case "pgdn", " ":
+		b.scrollPage(b.paneHeight())
+	case "pgup":
+		b.scrollPage(-b.paneHeight())
+	default:
+		if b.focusPage {
+			b.pageKey(key)
+		} else {
+			b.treeKey(key)
+		}
+	}
+	return false
+}
+
+func (b *manBrowser) moveSel(delta int) {
+	if len(b.rows) == 0 {
+		return
+	}
+	sel := min(max(b.sel+delta, 0), len(b.rows)-1)
+	if sel != b.sel {
+		b.sel = sel
+		b.show(b.rows[sel].Key)
+	}
+}
+
+func (b *manBrowser) toggle(n *browserNode) {
+	n.Open = !n.Open
+	b.rows = b.visible()
+	b.sel = b.rowIndex(n)
+}
+
+func (b *manBrowser) treeKey(key string) {
+	if len(b.rows) == 0 {
+		return
+	}
+	n := b.rows[b.sel]
+	switch key {
+	case "up", "k":
+		b.moveSel(-1)
+	case "down", "j":
+		b.moveSel(1)
+	case "home", "g":
+		b.moveSel(-len(b.rows))
+	case "end", "G":
+		b.moveSel(len(b.rows))
+	case "right", "l":
+		switch {
+		case n.isFolder() && !n.Open:
+			b.toggle(n)
+		case n.isFolder():
+			b.moveSel(1)
+		default:
+			b.focusPage = true
+		}
+	case "enter":
+		if n.isFolder() {
+			b.toggle(n)
+		} else {
+			b.focusPage = true
+		}
+	case "left", "h":
+		if n.isFolder() && n.Open {
+			b.toggle(n)
+		} else if n.parent != nil {
+			b.sel = b.rowIndex(n.parent)
+			b.show(n.parent.Key)
+		}
+	case "tab":
+		b.focusPage = true
+		b.nextLink(1)
+	}
+}
+
+func (b *manBrowser) pageKey(key string) {
+	switch key {
+	case "up", "k":
+		b.scrollPage(-1)
+	case "down", "j":
+		b.scrollPage(1)
+	case "home", "g":
+		b.scrollPage(-len(b.lines))
+	case "end", "G":
+		b.scrollPage(len(b.lines))
+	case "tab", "n":
+		b.nextLink(1)
+	case "backtab", "N":
+		b.nextLink(-1)
+	case "enter":
+		if b.link >= 0 {
+			b.follow(b.links[b.link].Key)
+		}
+	case "left", "h", "esc":
+		b.focusPage = false
+	}
+}
+
+// nextLink selects the next (or previous) link, starting from the top (or
+// bottom) of the screen when none is selected, and scrolls to it.
+func (b *manBrowser) nextLink(delta int) {
+	n := len(b.links)
+	if n == 0 {
+		return
+	}
+	if b.link < 0 {
+		b.link = 0
+		for i, l := range b.links {
+			if delta > 0 && l.Line >= b.scroll {
+				b.link = i
+				break
+			}
+			if delta < 0 && l.Line < b.scroll+b.paneHeight() {
+				b.link = i
+			}
+		}
+	} else {
+		b.link = (b.link + delta + n) % n
+	}
+	line := b.links[b.link].Line
+	if line < b.scroll {
+		b.scroll = line
+	} else if line >= b.scroll+b.paneHeight() {
+		b.scroll = line - b.paneHeight() + 1
+	}
+}
+
+// Search.
+
+func (b *manBrowser) startSearch() {
+	if !b.searching {
+		b.savedSel, b.savedPage = b.sel, b.page
+	}
+	b.searching = true
+	b.focusPage = false
+	b.query = ""
+	b.updateSearch()
+}
+
+func (b *manBrowser) searchKey(key string) {
+	switch key {
+	case "esc":
+		b.searching = false
+		b.rows = b.visible()
+		b.sel = min(b.savedSel, len(b.rows)-1)
+		b.show(b.savedPage)
+	case "enter":
+		if len(b.rows) == 0 {
+			return
+		}
+		b.history = append(b.history, b.savedPage)
+		b.reveal(b.rows[b.sel].Key)
+		b.focusPage = true
+	case "backspace":
+		if b.query != "" {
+			_, size := utf8.DecodeLastRuneInString(b.query)
+			b.query = b.query[:len(b.query)-size]
+			b.updateSearch()
+		}
+	case "up":
+		b.moveSel(-1)
+	case "down", "tab":
+		b.moveSel(1)
+	default:
+		if utf8.RuneCountInString(key) == 1 {
+			b.query += key
+			b.updateSearch()
+		}
+	}
+}
+
+// updateSearch lists the results for the query and shows the first.
+func (b *manBrowser) updateSearch() {
+	b.rows = b.search(b.query)
+	b.sel, b.top = 0, 0
+	if len(b.rows) > 0 {
+		b.show(b.rows[0].Key)
+	}
+}
+
+// search lists the entries whose name is query, then those whose name
+// starts with it, then those whose name contains it, then gray man -k
+// matches for its words.
+func (b *manBrowser) search(query string) []*browserNode {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return nil
+	}
+	var exact, prefix, contains []*browserNode
+	for _, n := range b.named {
+		name := strings.ToLower(n.Name)
+		short := strings.ToLower(n.Label)
+		switch {
+		case name == q || short == q:
+			exact = append(exact, n)
+		case strings.HasPrefix(name, q) || strings.HasPrefix(short, q):
+			prefix = append(prefix, n)
+		case strings.Contains(name, q):
+			contains = append(contains, n)
+		}
+	}
+	results := append(append(exact, prefix...), contains...)
+	seen := map[*browserNode]bool{}
+	for _, n := range results {
+		seen[n] = true
+	}
+	if terms := manQueryTerms(strings.Fields(query)); len(terms) > 0 {
+		for _, h := range searchManDocs(b.docs, terms) {
+			if n := b.byDoc[h.Doc.Source+":"+h.Doc.Name]; n != nil && !seen[n] {
+				seen[n] = true
+				results = append(results, n)
+			}
+		}
+	}
+	if len(results) > browserSearchLimit {
+		results = results[:browserSearchLimit]
+	}
+	return results
+}
+
+// Drawing.
+
+// render draws the screen as height lines of width columns: a title bar,
+// the tree and page side by side, and a status line.
+func (b *manBrowser) render(width, height int) []string {
+	if width < browserMinWidth || height < browserMinHeight {
+		lines := make([]string, height)
+		lines[0] = fitANSI("gray man: terminal too small", width)
+		return lines
+	}
+	b.resize(width, height)
+	pane := b.paneHeight()
+	if b.sel < b.top {
+		b.top = b.sel
+	} else if b.sel >= b.top+pane {
+		b.top = b.sel - pane + 1
+	}
+
+	title := " gray man"
+	if len(b.plain) > 0 {
+		title += " — " + b.plain[0]
+	}
+	lines := []string{browserReverse + fitANSI(title, width) + browserReverseOff}
+
+	tw, pw := b.treeWidth(), b.pageWidth()
+	sep := "│"
+	if b.color {
+		sep = manDim + sep + manReset
+	}
+	for r := 0; r < pane; r++ {
+		left := strings.Repeat(" ", tw)
+		if i := b.top + r; i < len(b.rows) {
+			left = b.treeRow(i, tw)
+		}
+		right := ""
+		if j := b.scroll + r; j < len(b.lines) {
+			right = b.pageLine(j)
+		}
+		lines = append(lines, left+sep+" "+fitANSI(right, pw))
+	}
+	return append(lines, b.statusLine(width-1))
+}
+
+func (b *manBrowser) treeRow(i, width int) string {
+	n := b.rows[i]
+	var row string
+	if b.searching {
+		row = " " + n.Name
+		if n.Name == "" || n.Tag == "lang" {
+			row = " " + n.Label
+		}
+		if n.Tag != "" {
+			row += "  (" + n.Tag + ")"
+		}
+	} else {
+		marker := "  "
+		if n.isFolder() && n.Open {
+			marker = "▾ "
+		} else if n.isFolder() {
+			marker = "▸ "
+		}
+		row = " " + strings.Repeat("  ", n.depth) + marker + n.Label
+	}
+	row = fitANSI(row, width)
+	switch {
+	case i == b.sel && !b.focusPage:
+		return browserReverse + row + browserReverseOff
+	case i == b.sel && b.color:
+		return manBold + row + manReset
+	}
+	return row
+}
+
+// pageLine is line j of the page with its links underlined and the
+// selected link reversed.
+func (b *manBrowser) pageLine(j int) string {
+	line := b.lines[j]
+	// Style from the right so earlier columns stay put.
+	for i := len(b.links) - 1; i >= 0; i-- {
+		l := b.links[i]
+		if l.Line != j {
+			continue
+		}
+		style := browserUnderline
+		if i == b.link && b.focusPage {
+			style = browserReverse
+		}
+		line = styleColumns(line, l.Start, l.End, style)
+	}
+	return line
+}
+
+func (b *manBrowser) statusLine(width int) string {
+	var s string
+	switch {
+	case b.searching:
+		s = fmt.Sprintf(" /%s▏  %d matches   ↑↓ choose  Enter open  Esc cancel", b.query, len(b.rows))
+		if b.query == "" {
+			s = " /▏  type a name or words to search   Esc cancel"
+		}
+	case b.focusPage:
+		s = " ↑↓ scroll  Tab next link  Enter follow  b back  ← tree  / search  ? help  q quit"
+		if b.link >= 0 {
+			s = " → " + b.pageName(b.links[b.link].Key) + "   Enter follow  Tab next  b back  ← tree  q quit"
+		}
+	default:
+		s = " ↑↓ move  → open  Tab links  b back  / search  ? help  q quit"
+	}
+	s = fitANSI(s, width)
+	if b.color && !b.searching {
+		s = manDim + s + manReset
+	}
+	return s
+}
+
+// pageName is how the status line names the page for key.
+func (b *manBrowser) pageName(key string) string {
+	n := b.nodes[key]
+	switch {
+	case n == nil:
+		return key
+	case strings.HasPrefix(key, "module:"):
+		return "module " + n.Name
+	case n.Name != "" && n.Tag != "lang":
+		return n.Name
+	}
+	return n.Label
+}
+
+// fitANSI cuts or pads s to width columns, keeping its styles.
+func fitANSI(s string, width int) string {
+	var sb strings.Builder
+	col, styled := 0, false
+	for i := 0; i < len(s); {
+		if loc := browserANSI.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			sb.WriteString(s[i : i+loc[1]])
+			i += loc[1]
+			styled = true
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		if col == width {
+			break
+		}
+		sb.WriteString(s[i : i+size])
+		i += size
+		col++
+	}
+	if styled {
+		sb.WriteString(manReset)
+	}
+	sb.WriteString(strings.Repeat(" ", width-col))
+	return sb.String()
+}
+
+// styleColumns applies style to columns [start, end) of s, turning it on
+// again after any style change inside the range.
+func styleColumns(s string, start, end int, style string) string {
+	var sb strings.Builder
+	col := 0
+	for i := 0; i < len(s); {
+		if loc := browserANSI.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			sb.WriteString(s[i : i+loc[1]])
+			i += loc[1]
+			if col > start && col < end {
+				sb.WriteString(style)
+			}
+			continue
+		}
+		if col == start {
+			sb.WriteString(style)
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		sb.WriteString(s[i : i+size])
+		i += size
+		col++
+		if col == end {
+			sb.WriteString(browserLinkOff)
+		}
+	}
+	return sb.String()
+}
+
+// browserEscapes names the escape sequences of the keys the browser uses,
+// without the leading ESC [ or ESC O.
+var browserEscapes = map[string]string{
+	"A": "up", "B": "down", "C": "right", "D": "left", "H": "home", "F": "end", "Z": "backtab",
+	"1~": "home", "7~": "home", "4~": "end", "8~": "end", "5~": "pgup", "6~": "pgdn",
+}
+
+// parseBrowserKeys splits terminal input into keys: a named key such as
+// "up", "enter", or "ctrl-c", or a single typed character.
+func parseBrowserKeys(in []byte) []string {
+	var keys []string
+	for i := 0; i < len(in); {
+		c := in[i]
+		switch {
+		case c == 0x1b:
+			if i+1 < len(in) && (in[i+1] == '[' || in[i+1] == 'O') {
+				j := i + 2
+				for j < len(in) && (in[j] < 0x40 || in[j] > 0x7e) {
+					j++
+				}
+				if j < len(in) {
+					if k := browserEscapes[string(in[i+2:j+1])]; k != "" {
+						keys = append(keys, k)
+					}
+					i = j + 1
+					continue
+				}
+			}
+			keys = append(keys, "esc")
+		case c == '\r' || c == '\n':
+			keys = append(keys, "enter")
+		case c == '\t':
+			keys = append(keys, "tab")
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, "backspace")
+		case c == 0x03:
+			keys = append(keys, "ctrl-c")
+		case c >= 0x20:
+			r, size := utf8.DecodeRune(in[i:])
+			keys = append(keys, string(r))
+			i += size
+			continue
+		}
+		i++
+	}
+	return keys
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal.
+func stdinIsTerminal() bool {
+	stat, err := os.Stdin.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+// browserSize is the terminal's size, else $COLUMNS and $LINES, else 80x24.
+func browserSize() (int, int) {
+	width, height := terminalSize()
+	if width <= 0 {
+		width = manWidth()
+	}
+	if height <= 0 {
+		if h, err := strconv.Atoi(os.Getenv("LINES")); err == nil && h > 0 {
+			height = h
+		} else {
+			height = 24
+		}
+	}
+	return width, height
+}
+
+// runManBrowser is gray man --tui. It starts at args[0] when given.
+func runManBrowser(args []string, noColor bool) error {
+	if !stdinIsTerminal() || !stdoutIsTerminal() {
+		return fmt.Errorf("gray: gray man --tui needs an interactive terminal\n    try: gray man -k <words> to search from a script")
+	}
+	b := newManBrowser(!noColor && manColorAllowed())
+	if len(args) > 0 {
+		b.open(args[0])
+	}
+	restore, err := makeRawTerminal(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("gray: %v", err)
+	}
+	defer restore()
+
+	out := os.Stdout
+	// The alternate screen keeps the shell's scrollback as it was.
+	fmt.Fprint(out, "\033[?1049h\033[?25l")
+	defer fmt.Fprint(out, "\033[?25h\033[?1049l")
+
+	keys := make(chan []string)
+	go readBrowserKeys(os.Stdin, keys)
+	resized := make(chan os.Signal, 1)
+	if len(resizeSignals) > 0 {
+		signal.Notify(resized, resizeSignals...)
+		defer signal.Stop(resized)
+	}
+	for {
+		drawManBrowser(out, b.render(browserSize()))
+		select {
+		case ks, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, k := range ks {
+				if b.handleKey(k) {
+					return nil
+				}
+			}
+		case <-resized:
+		}
+	}
+}
+
+func readBrowserKeys(r io.Reader, keys chan<- []string) {
+	buf := make([]byte, 256)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			keys <- parseBrowserKeys(buf[:n])
+		}
+		if err != nil {
+			close(keys)
+			return
+		}
+	}
+}
+
+// drawManBrowser writes the screen in one write, so it does not flicker.
+func drawManBrowser(w io.Writer, lines []string) {
+	var sb strings.Builder
+	for i, l := range lines {
+		fmt.Fprintf(&sb, "\033[%d;1H%s\033[K", i+1, l)
+	}
+	io.WriteString(w, sb.String())
+}
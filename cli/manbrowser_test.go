@@ -0,0 +1,192 @@
+// manbrowser_test.go — Tests for gray man --tui: key parsing, the tree,
+// links, search, and the drawn screen.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseBrowserKeys(t *testing.T) {
+	got := parseBrowserKeys([]byte("\x1b[A\x1bOB\x1b[5~\x1b[Z\x1b[1;5C\r\t\x7f\x03/é\x1b"))
+	want := []string{"up", "down", "pgup", "backtab", "enter", "tab", "backspace", "ctrl-c", "/", "é", "esc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBrowserKeys = %q, want %q", got, want)
+	}
+}
+
+func TestManBrowserTree(t *testing.T) {
+	b := newManBrowser(false)
+	if b.page != "modules" || b.rows[b.sel].Label != "Standard library" {
+		t.Fatalf("start page = %q, row %q", b.page, b.rows[b.sel].Label)
+	}
+	if n := len(b.rows); n != 3+len(stdlibModules) {
+		t.Errorf("%d rows with the stdlib open", n)
+	}
+	b.handleKey("down")
+	if b.page != "module:arrays" {
+		t.Errorf("down shows %q", b.page)
+	}
+	b.handleKey("right")
+	if !b.nodes["module:arrays"].Open || b.page != "module:arrays" {
+		t.Errorf("right did not open arrays")
+	}
+	b.handleKey("right")
+	if !strings.HasPrefix(b.page, "stdlib:arrays.") {
+		t.Errorf("right on an open folder shows %q", b.page)
+	}
+	b.handleKey("left")
+	if b.page != "module:arrays" {
+		t.Errorf("left on an entry shows %q", b.page)
+	}
+	b.handleKey("left")
+	if b.nodes["module:arrays"].Open {
+		t.Errorf("left did not close arrays")
+	}
+
+	b.open("PI")
+	if !b.searching || b.rows[0].Key != "stdlib:math.PI" {
+		t.Errorf("open(PI) searched %v", b.rows)
+	}
+	b.open("#doc")
+	if b.page != "lang:#doc" || !b.nodes["category:attributes"].Open {
+		t.Errorf("open(#doc) shows %q", b.page)
+	}
+	b.open("int")
+	if b.page != "builtin:int" {
+		t.Errorf("open(int) shows %q", b.page)
+	}
+}
+
+func TestManBrowserLinks(t *testing.T) {
+	b := newManBrowser(false)
+	b.open("http.get")
+	keys := map[string]bool{}
+	for _, l := range b.links {
+		keys[l.Key] = true
+		if got := b.plain[l.Line][l.Start:l.End]; !strings.HasSuffix(l.Key, got) {
+			t.Errorf("link %q at %q", l.Key, got)
+		}
+	}
+	for _, want := range []string{"module:http", "stdlib:http.HttpResponse", "builtin:Error"} {
+		if !keys[want] {
+			t.Errorf("http.get has no link to %s: %v", want, b.links)
+		}
+	}
+
+	b.handleKey("tab")
+	for b.links[b.link].Key != "stdlib:http.HttpResponse" {
+		b.handleKey("tab")
+	}
+	b.handleKey("enter")
+	if b.page != "stdlib:http.HttpResponse" || !b.focusPage || b.rows[b.sel].Key != b.page {
+		t.Errorf("following HttpResponse shows %q", b.page)
+	}
+	b.handleKey("b")
+	if b.page != "stdlib:http.get" {
+		t.Errorf("back shows %q", b.page)
+	}
+
+	b.page = "stdlib:server.HttpRequest"
+	if got := b.linkTarget("HttpResponse", ""); got != "stdlib:server.HttpResponse" {
+		t.Errorf("HttpResponse on a server page links to %q", got)
+	}
+	b.open("strings")
+	if got := b.linkTarget("split", "  Splitting  "); got != "stdlib:strings.split" {
+		t.Errorf("split on the strings index links to %q", got)
+	}
+	b.open("if")
+	if got := b.linkTarget("else", ""); got != "" {
+		t.Errorf("else in the if entry links to %q", got)
+	}
+}
+
+func TestManBrowserSearch(t *testing.T) {
+	b := newManBrowser(false)
+	b.handleKey("/")
+	for _, k := range "split" {
+		b.handleKey(string(k))
+	}
+	if !b.searching || len(b.rows) == 0 || b.rows[0].Key != "stdlib:strings.split" || b.page != "stdlib:strings.split" {
+		t.Fatalf("search split = %v, page %q", b.rows, b.page)
+	}
+	b.handleKey("q") // typed, not quit
+	if b.query != "splitq" {
+		t.Errorf("query = %q", b.query)
+	}
+	b.handleKey("backspace")
+	b.handleKey("esc")
+	if b.searching || b.page != "modules" {
+		t.Errorf("esc left search %v on %q", b.searching, b.page)
+	}
+
+	b.handleKey("/")
+	for _, k := range "uppercase" {
+		b.handleKey(string(k))
+	}
+	if len(b.rows) == 0 {
+		t.Fatal("no full-text results for uppercase")
+	}
+	key := b.rows[0].Key
+	b.handleKey("enter")
+	if b.searching || b.page != key || b.rows[b.sel].Key != key || !reflect.DeepEqual(b.history, []string{"modules"}) {
+		t.Errorf("enter shows %q, history %v", b.page, b.history)
+	}
+	if !b.handleKey("q") {
+		t.Error("q did not quit")
+	}
+}
+
+func TestManBrowserRender(t *testing.T) {
+	for _, color := range []bool{false, true} {
+		b := newManBrowser(color)
+		b.open("http.get")
+		b.handleKey("tab")
+		lines := b.render(90, 20)
+		if len(lines) != 20 {
+			t.Fatalf("%d lines", len(lines))
+		}
+		for i, l := range lines {
+			want := 90
+			if i == len(lines)-1 {
+				want = 89
+			}
+			if n := utf8.RuneCountInString(browserANSI.ReplaceAllString(l, "")); n != want {
+				t.Errorf("line %d is %d columns: %q", i, n, l)
+			}
+		}
+		if !strings.Contains(lines[0], "http: get()") || !strings.Contains(lines[len(lines)-1], "→ module http") {
+			t.Errorf("title %q, status %q", lines[0], lines[len(lines)-1])
+		}
+		if !strings.Contains(strings.Join(lines, "\n"), browserReverse+"http"+browserLinkOff) {
+			t.Error("the selected link is not reversed")
+		}
+	}
+	if lines := newManBrowser(false).render(30, 10); len(lines) != 10 || !strings.Contains(lines[0], "too small") {
+		t.Errorf("small render = %q", lines)
+	}
+}
+
+func TestFitANSI(t *testing.T) {
+	if got := fitANSI("ab", 4); got != "ab  " {
+		t.Errorf("fitANSI pad = %q", got)
+	}
+	if got := fitANSI(manBold+"abcdef"+manReset, 3); got != manBold+"abc"+manReset {
+		t.Errorf("fitANSI cut = %q", got)
+	}
+}
+
+func TestStyleColumns(t *testing.T) {
+	got := styleColumns("a "+manType+"Type"+manReset+" b", 2, 6, browserUnderline)
+	want := "a " + manType + browserUnderline + "Type" + browserLinkOff + manReset + " b"
+	if got != want {
+		t.Errorf("styleColumns = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,467 @@
+// manexport.go — "gray man --export roff|markdown": writes the docs gray
+// man shows as installable pages. Commands get section 1 pages built from
+// the Cobra command tree (gray(1), gray-build(1), ...); builtins, stdlib
+// modules, and stdlib items get section 3gray pages from the man tables
+// (println(3gray), strings(3gray), strings.split(3gray)). The 3gray
+// section keeps names like abs or printf from colliding with libc pages.
+//
+// Pages are written under man1/ and man3/ in the output directory, so
+// share/man can be installed as is. Markdown pages use the same layout
+// plus an index.md.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const defaultManExportDir = "share/man"
+
+// manPage is one exported page, before it is rendered as roff or Markdown.
+type manPage struct {
+	Name     string // "gray-build", "strings.split"
+	Section  string // "1" or "3gray"
+	Summary  string // the NAME line after the dash
+	Synopsis string
+	Parts    []manPart
+	SeeAlso  []manRef
+}
+
+// manRef names another page, as in gray-man(1).
+type manRef struct {
+	Name, Section string
+}
+
+func (r manRef) String() string { return r.Name + "(" + r.Section + ")" }
+
+// manPart is one section of a page: paragraphs, a term list, and a
+// preformatted block, each optional, rendered in that order.
+type manPart struct {
+	Heading string
+	Text    string
+	Items   [][2]string
+	Code    string
+}
+
+// allManPages builds every exported page: the commands under root, then
+// the builtins and stdlib pages.
+func allManPages(root *cobra.Command) []manPage {
+	pages := commandManPages(root)
+	refs := []manRef{{"builtins", "3gray"}}
+	for _, m := range sortedStdlibModules() {
+		refs = append(refs, manRef{m, "3gray"})
+	}
+	for i := range pages {
+		if pages[i].Name == root.Name()+"-man" {
+			pages[i].SeeAlso = append(pages[i].SeeAlso, refs...)
+		}
+	}
+	return append(pages, referenceManPages()...)
+}
+
+func sortedStdlibModules() []string {
+	mods := make([]string, 0, len(stdlibModules))
+	for m := range stdlibModules {
+		mods = append(mods, m)
+	}
+	sort.Strings(mods)
+	return mods
+}
+
+// commandPageName is the page name for a command: "gray hooks install"
+// becomes gray-hooks-install.
+func commandPageName(c *cobra.Command) string {
+	return strings.ReplaceAll(c.CommandPath(), " ", "-")
+}
+
+// commandManPages returns a page for root and each available command under it.
+func commandManPages(root *cobra.Command) []manPage {
+	pages := []manPage{commandManPage(root)}
+	for _, sub := range root.Commands() {
+		if sub.IsAvailableCommand() && !sub.IsAdditionalHelpTopicCommand() {
+			pages = append(pages, commandManPages(sub)...)
+		}
+	}
+	return pages
+}
+
+func commandManPage(c *cobra.Command) manPage {
+	p := manPage{Name: commandPageName(c), Section: "1", Summary: c.Short, Synopsis: c.UseLine()}
+	desc := c.Long
+	if desc == "" {
+		desc = c.Short
+	}
+	p.Parts = append(p.Parts, manPart{Heading: "DESCRIPTION", Text: desc})
+	if c.HasParent() {
+		p.SeeAlso = append(p.SeeAlso, manRef{commandPageName(c.Parent()), "1"})
+	}
+	var subs [][2]string
+	for _, sub := range c.Commands() {
+		if sub.IsAvailableCommand() && !sub.IsAdditionalHelpTopicCommand() {
+			subs = append(subs, [2]string{sub.Name(), sub.Short})
+			p.SeeAlso = append(p.SeeAlso, manRef{commandPageName(sub), "1"})
+		}
+	}
+	if len(subs) > 0 {
+		p.Parts = append(p.Parts, manPart{Heading: "COMMANDS", Items: subs})
+	}
+	if items := manFlagItems(c.NonInheritedFlags()); len(items) > 0 {
+		p.Parts = append(p.Parts, manPart{Heading: "OPTIONS", Items: items})
+	}
+	if items := manFlagItems(c.InheritedFlags()); len(items) > 0 {
+		p.Parts = append(p.Parts, manPart{Heading: "OPTIONS INHERITED FROM PARENT COMMANDS", Items: items})
+	}
+	return p
+}
+
+// manFlagItems lists the visible flags of a set as "-o, --output string"
+// and their usage, leaving out --help.
+func manFlagItems(flags *pflag.FlagSet) [][2]string {
+	var items [][2]string
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Name == "help" {
+			return
+		}
+		varname, usage := pflag.UnquoteUsage(f)
+		term := "--" + f.Name
+		if f.Shorthand != "" {
+			term = "-" + f.Shorthand + ", " + term
+		}
+		if varname != "" {
+			term += " " + varname
+		}
+		switch def := f.DefValue; {
+		case f.Value.Type() == "string" && def != "":
+			usage += fmt.Sprintf(" (default %q)", def)
+		case def != "" && def != "false" && def != "0" && def != "[]" && f.Value.Type() != "string":
+			usage += " (default " + def + ")"
+		}
+		items = append(items, [2]string{term, usage})
+	})
+	return items
+}
+
+// referenceManPages returns the builtins index and a page per builtin,
+// then each stdlib module's index and a page per item.
+func referenceManPages() []manPage {
+	index := manPage{
+		Name:    "builtins",
+		Section: "3gray",
+		Summary: "Grayscale builtin functions and types",
+		Parts:   []manPart{{Heading: "DESCRIPTION", Text: "Builtins are available in every Grayscale program without an import."}},
+		SeeAlso: []manRef{{"gray-man", "1"}},
+	}
+	var items []manPage
+	for _, g := range builtinGroups {
+		var names []string
+		for _, n := range g.names {
+			e, ok := builtinManDocs[n]
+			if !ok {
+				continue
+			}
+			names = append(names, n)
+			items = append(items, entryManPage("", n, e.Kind, e.Sig, e.Fields, e.Desc, e.Example))
+		}
+		index.Parts[0].Items = append(index.Parts[0].Items, [2]string{strings.TrimSpace(g.label), strings.Join(names, ", ")})
+	}
+	pages := append([]manPage{index}, items...)
+
+	for _, m := range sortedStdlibModules() {
+		index := manPage{
+			Name:     m,
+			Section:  "3gray",
+			Summary:  "Grayscale standard library module " + m,
+			Synopsis: "import @" + m,
+			Parts:    []manPart{{Heading: "DESCRIPTION"}},
+			SeeAlso:  []manRef{{"gray-man", "1"}, {"builtins", "3gray"}},
+		}
+		var items []manPage
+		for _, g := range stdlibModuleGroups[m] {
+			var names []string
+			for _, n := range g.Names {
+				e, ok := stdlibManDocs[m+"."+n]
+				if !ok {
+					continue
+				}
+				names = append(names, m+"."+n)
+				items = append(items, entryManPage(m, n, e.Kind, e.Sig, e.Fields, e.Desc, e.Example))
+			}
+			index.Parts[0].Items = append(index.Parts[0].Items, [2]string{strings.TrimSpace(g.Label), strings.Join(names, ", ")})
+		}
+		pages = append(pages, index)
+		pages = append(pages, items...)
+	}
+	return pages
+}
+
+// entryManPage is the page for one builtin (module "") or stdlib item,
+// with the same content printManEntry shows.
+func entryManPage(module, name, kind, sig, fields, desc, example string) manPage {
+	p := manPage{Name: name, Section: "3gray", Summary: manSummary(desc)}
+	var synopsis []string
+	if module != "" {
+		p.Name = module + "." + name
+		synopsis = append(synopsis, "import @"+module, "")
+		p.SeeAlso = append(p.SeeAlso, manRef{module, "3gray"})
+	} else {
+		p.SeeAlso = append(p.SeeAlso, manRef{"builtins", "3gray"})
+	}
+	p.SeeAlso = append(p.SeeAlso, manRef{"gray-man", "1"})
+	switch {
+	case kind == "type" && sig != "":
+		synopsis = append(synopsis, sig)
+	case kind == "type" && fields != "":
+		synopsis = append(synopsis, "const "+name+" struct {")
+		for _, f := range strings.Split(fields, "\n") {
+			synopsis = append(synopsis, "    "+strings.Join(strings.Fields(f), " "))
+		}
+		synopsis = append(synopsis, "}")
+	case kind == "const" && sig != "":
+		synopsis = append(synopsis, p.Name+" = "+sig)
+	case sig != "":
+		synopsis = append(synopsis, sig)
+	default:
+		synopsis = append(synopsis, p.Name)
+	}
+	p.Synopsis = strings.Join(synopsis, "\n")
+	p.Parts = append(p.Parts, manPart{Heading: "DESCRIPTION", Text: desc})
+	if kind == "type" && fields != "" {
+		var items [][2]string
+		for _, f := range strings.Split(fields, "\n") {
+			if parts := strings.Fields(f); len(parts) >= 2 {
+				items = append(items, [2]string{parts[0], strings.Join(parts[1:], " ")})
+			}
+		}
+		p.Parts = append(p.Parts, manPart{Heading: "FIELDS", Items: items})
+	}
+	if example != "" {
+		p.Parts = append(p.Parts, manPart{Heading: "EXAMPLE", Code: example})
+	}
+	return p
+}
+
+// manTextBlocks splits free text into paragraphs and preformatted
+// blocks (runs of indented lines, as in Cobra's Long examples), reporting
+// which is which.
+func manTextBlocks(text string) (blocks []string, pre []bool) {
+	var cur []string
+	curPre := false
+	flush := func() {
+		if len(cur) > 0 {
+			if curPre {
+				cur = dedentLines(cur)
+			}
+			blocks = append(blocks, strings.Join(cur, "\n"))
+			pre = append(pre, curPre)
+			cur = nil
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if indented != curPre {
+			flush()
+			curPre = indented
+		}
+		cur = append(cur, strings.TrimRight(line, " \t"))
+	}
+	flush()
+	return blocks, pre
+}
+
+// dedentLines removes the indentation all lines share.
+func dedentLines(lines []string) []string {
+	indent := lines[0][:len(lines[0])-len(strings.TrimLeft(lines[0], " \t"))]
+	for _, line := range lines[1:] {
+		for !strings.HasPrefix(line, indent) {
+			indent = indent[:len(indent)-1]
+		}
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = line[len(indent):]
+	}
+	return out
+}
+
+// roffEscape escapes text for a roff line: backslashes, hyphens (so
+// options are not hyphenated), and a leading control character.
+func roffEscape(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// roffLines escapes each line of a preformatted block.
+func roffLines(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(roffEscape(line) + "\n")
+	}
+	return b.String()
+}
+
+func renderRoff(p manPage) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ".TH \"%s\" \"%s\" \"\" \"gray %s\" \"Grayscale Manual\"\n", roffEscape(strings.ToUpper(p.Name)), p.Section, Version)
+	fmt.Fprintf(&b, ".SH NAME\n%s \\- %s\n", roffEscape(p.Name), roffEscape(p.Summary))
+	if p.Synopsis != "" {
+		b.WriteString(".SH SYNOPSIS\n.nf\n" + roffLines(p.Synopsis) + ".fi\n")
+	}
+	for _, part := range p.Parts {
+		b.WriteString(".SH " + part.Heading + "\n")
+		blocks, pre := manTextBlocks(part.Text)
+		for i, block := range blocks {
+			if pre[i] {
+				b.WriteString(".PP\n.RS 4\n.nf\n" + roffLines(block) + ".fi\n.RE\n")
+			} else {
+				b.WriteString(".PP\n" + roffLines(block))
+			}
+		}
+		for _, item := range part.Items {
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fR\n%s\n", roffEscape(item[0]), roffEscape(item[1]))
+		}
+		if part.Code != "" {
+			b.WriteString(".PP\n.RS 4\n.nf\n" + roffLines(part.Code) + ".fi\n.RE\n")
+		}
+	}
+	if len(p.SeeAlso) > 0 {
+		refs := make([]string, len(p.SeeAlso))
+		for i, r := range p.SeeAlso {
+			refs[i] = `\fB` + roffEscape(r.Name) + `\fR(` + r.Section + ")"
+		}
+		b.WriteString(".SH SEE ALSO\n" + strings.Join(refs, ",\n") + "\n")
+	}
+	return b.String()
+}
+
+// manPageDir is the directory a page is written to: man1 or man3.
+func manPageDir(section string) string {
+	return "man" + section[:1]
+}
+
+// markdownEscape keeps prose from being read as Markdown or HTML.
+func markdownEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "<", `\<`).Replace(s)
+}
+
+// markdownFence returns a code fence longer than any backtick run in code.
+func markdownFence(code string) string {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence
+}
+
+// markdownPageLink links to page r from a page in section from.
+func markdownPageLink(from string, r manRef) string {
+	href := r.Name + ".md"
+	if manPageDir(from) != manPageDir(r.Section) {
+		href = "../" + manPageDir(r.Section) + "/" + href
+	}
+	return "[" + r.String() + "](" + href + ")"
+}
+
+func renderManMarkdown(p manPage) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s(%s)\n\n## NAME\n\n%s — %s\n", p.Name, p.Section, markdownEscape(p.Name), markdownEscape(p.Summary))
+	if p.Synopsis != "" {
+		fence := markdownFence(p.Synopsis)
+		fmt.Fprintf(&b, "\n## SYNOPSIS\n\n%s\n%s\n%s\n", fence, p.Synopsis, fence)
+	}
+	for _, part := range p.Parts {
+		fmt.Fprintf(&b, "\n## %s\n", part.Heading)
+		blocks, pre := manTextBlocks(part.Text)
+		for i, block := range blocks {
+			if pre[i] {
+				fence := markdownFence(block)
+				fmt.Fprintf(&b, "\n%s\n%s\n%s\n", fence, block, fence)
+			} else {
+				fmt.Fprintf(&b, "\n%s\n", markdownEscape(block))
+			}
+		}
+		if len(part.Items) > 0 {
+			b.WriteString("\n")
+			for _, item := range part.Items {
+				fmt.Fprintf(&b, "- `%s` — %s\n", item[0], markdownEscape(item[1]))
+			}
+		}
+		if part.Code != "" {
+			fence := markdownFence(part.Code)
+			fmt.Fprintf(&b, "\n%sgray\n%s\n%s\n", fence, part.Code, fence)
+		}
+	}
+	if len(p.SeeAlso) > 0 {
+		links := make([]string, len(p.SeeAlso))
+		for i, r := range p.SeeAlso {
+			links[i] = markdownPageLink(p.Section, r)
+		}
+		fmt.Fprintf(&b, "\n## SEE ALSO\n\n%s\n", strings.Join(links, ", "))
+	}
+	return b.String()
+}
+
+// renderManMarkdownIndex lists every page, commands first.
+func renderManMarkdownIndex(pages []manPage) string {
+	var b strings.Builder
+	b.WriteString("# Grayscale Manual\n")
+	for _, sec := range []struct{ section, title string }{
+		{"1", "Commands"},
+		{"3gray", "Builtins and Standard Library"},
+	} {
+		fmt.Fprintf(&b, "\n## %s\n\n", sec.title)
+		for _, p := range pages {
+			if p.Section == sec.section {
+				fmt.Fprintf(&b, "- [%s](%s/%s.md) — %s\n", p.Name, manPageDir(p.Section), p.Name, markdownEscape(p.Summary))
+			}
+		}
+	}
+	return b.String()
+}
+
+// exportManPages writes every page under outDir in the given format.
+func exportManPages(root *cobra.Command, format, outDir string) error {
+	pages := allManPages(root)
+	files := map[string]string{}
+	switch format {
+	case "roff":
+		for _, p := range pages {
+			files[filepath.Join(manPageDir(p.Section), p.Name+"."+p.Section)] = renderRoff(p)
+		}
+	case "markdown", "md":
+		for _, p := range pages {
+			files[filepath.Join(manPageDir(p.Section), p.Name+".md")] = renderManMarkdown(p)
+		}
+		files["index.md"] = renderManMarkdownIndex(pages)
+	default:
+		return fmt.Errorf("gray: unknown export format '%s' (use roff or markdown)", format)
+	}
+	for name, content := range files {
+		path := filepath.Join(outDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("gray: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("gray: %v", err)
+		}
+	}
+	fmt.Printf("gray man: wrote %d pages to %s\n", len(pages), outDir)
+	return nil
+}
@@ -0,0 +1,143 @@
+// manexport_test.go — Tests for gray man --export: pages from the command
+// tree and man tables, text blocks, and the roff and Markdown output.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestManTextBlocks(t *testing.T) {
+	blocks, pre := manTextBlocks("Run it.\nTwice.\n\nExamples:\n  gray x .     here\n    gray x ./...\n\nDone.")
+	want := []string{"Run it.\nTwice.", "Examples:", "gray x .     here\n  gray x ./...", "Done."}
+	if !reflect.DeepEqual(blocks, want) || !reflect.DeepEqual(pre, []bool{false, false, true, false}) {
+		t.Errorf("manTextBlocks = %q %v", blocks, pre)
+	}
+}
+
+func TestRoffEscape(t *testing.T) {
+	for in, want := range map[string]string{
+		"--output":   `\-\-output`,
+		`a\nb`:       `a\enb`,
+		".hidden":    `\&.hidden`,
+		"'quoted'":   `\&'quoted'`,
+		"plain text": "plain text",
+	} {
+		if got := roffEscape(in); got != want {
+			t.Errorf("roffEscape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCommandManPages(t *testing.T) {
+	root := &cobra.Command{Use: "gray", Short: "Grayscale"}
+	sub := &cobra.Command{Use: "fmt <path>", Short: "Format files", Long: "Format files.\n\nExamples:\n  gray fmt .", Run: func(*cobra.Command, []string) {}}
+	sub.Flags().StringP("range", "r", "", "Only re-indent `lines` start:end")
+	sub.Flags().Bool("check", false, "Exit non-zero if any file would change")
+	sub.Flags().Int("jobs", 4, "Parallel jobs")
+	hidden := &cobra.Command{Use: "secret", Hidden: true, Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(sub, hidden)
+
+	pages := commandManPages(root)
+	if len(pages) != 2 || pages[0].Name != "gray" || pages[1].Name != "gray-fmt" {
+		t.Fatalf("commandManPages = %+v", pages)
+	}
+	if got := pages[0].Parts[1]; got.Heading != "COMMANDS" || !reflect.DeepEqual(got.Items, [][2]string{{"fmt", "Format files"}}) {
+		t.Errorf("root COMMANDS = %+v", got)
+	}
+	p := pages[1]
+	if p.Synopsis != "gray fmt <path> [flags]" || !reflect.DeepEqual(p.SeeAlso, []manRef{{"gray", "1"}}) {
+		t.Errorf("gray-fmt page = %+v", p)
+	}
+	opts := p.Parts[len(p.Parts)-1]
+	want := [][2]string{
+		{"--check", "Exit non-zero if any file would change"},
+		{"--jobs int", "Parallel jobs (default 4)"},
+		{"-r, --range lines", "Only re-indent lines start:end"},
+	}
+	if opts.Heading != "OPTIONS" || !reflect.DeepEqual(opts.Items, want) {
+		t.Errorf("OPTIONS = %+v", opts)
+	}
+}
+
+func TestEntryManPage(t *testing.T) {
+	p := entryManPage("http", "HttpResponse", "type", "", "status int\nbody string", "The response. More text.", "")
+	if p.Name != "http.HttpResponse" || p.Section != "3gray" || p.Summary != "The response." {
+		t.Errorf("page = %+v", p)
+	}
+	if want := "import @http\n\nconst HttpResponse struct {\n    status int\n    body string\n}"; p.Synopsis != want {
+		t.Errorf("Synopsis = %q, want %q", p.Synopsis, want)
+	}
+	if !reflect.DeepEqual(p.SeeAlso, []manRef{{"http", "3gray"}, {"gray-man", "1"}}) {
+		t.Errorf("SeeAlso = %v", p.SeeAlso)
+	}
+
+	p = entryManPage("", "println", "func", "println([value])", "", "Prints a value.", "println(1)")
+	out := renderRoff(p)
+	for _, want := range []string{
+		`.TH "PRINTLN" "3gray"`, ".SH NAME\nprintln \\- Prints a value.\n", ".SH SYNOPSIS\n.nf\nprintln([value])\n.fi\n",
+		".SH EXAMPLE\n.PP\n.RS 4\n.nf\nprintln(1)\n.fi\n.RE\n", ".SH SEE ALSO\n\\fBbuiltins\\fR(3gray),\n\\fBgray\\-man\\fR(1)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("roff missing %q:\n%s", want, out)
+		}
+	}
+	md := renderManMarkdown(p)
+	for _, want := range []string{
+		"# println(3gray)", "```gray\nprintln(1)\n```", "[builtins(3gray)](builtins.md), [gray-man(1)](../man1/gray-man.md)",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestMarkdownFence(t *testing.T) {
+	if got := markdownFence("Extract ```gray blocks"); got != "````" {
+		t.Errorf("markdownFence = %q", got)
+	}
+}
+
+func TestExportManPages(t *testing.T) {
+	names := map[string]bool{}
+	for _, p := range allManPages(rootCmd) {
+		key := p.Name + "." + p.Section
+		if names[key] {
+			t.Errorf("duplicate page %s", key)
+		}
+		names[key] = true
+	}
+	for _, want := range []string{"gray.1", "gray-man.1", "gray-explain.1", "builtins.3gray", "println.3gray", "strings.3gray", "strings.split.3gray"} {
+		if !names[want] {
+			t.Errorf("no %s page", want)
+		}
+	}
+
+	dir := t.TempDir()
+	captureStdout(t, func() {
+		if err := exportManPages(rootCmd, "roff", dir); err != nil {
+			t.Fatal(err)
+		}
+		if err := exportManPages(rootCmd, "markdown", dir); err != nil {
+			t.Fatal(err)
+		}
+	})
+	for _, path := range []string{"man1/gray.1", "man1/gray-man.1", "man3/strings.split.3gray", "man1/gray-man.md", "man3/println.md", "index.md"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+			t.Errorf("missing %s: %v", path, err)
+		}
+	}
+	if err := exportManPages(rootCmd, "pdf", dir); err == nil || !strings.Contains(err.Error(), "unknown export format") {
+		t.Errorf("pdf export error = %v", err)
+	}
+}
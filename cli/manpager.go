@@ -0,0 +1,372 @@
+// manpager.go — Terminal output for "gray man" and "gray explain". When
+// stdout is a terminal, the page is captured, wrapped to the terminal
+// width, coloured (titles and labels in bold, signatures and examples
+// highlighted as Grayscale), and shown through $PAGER, "less -R" by
+// default. Piped output is left exactly as the printers wrote it.
+//
+// Colour is off with --no-color, a non-empty NO_COLOR, or TERM=dumb. An
+// empty PAGER (or PAGER=cat) prints straight to the terminal.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultManPager = "less -R"
+	defaultManWidth = 80
+)
+
+// ANSI styles used for man pages.
+const (
+	manReset   = "\033[0m"
+	manBold    = "\033[1m"
+	manDim     = "\033[2m"
+	manKeyword = "\033[35m"
+	manType    = "\033[36m"
+	manString  = "\033[32m"
+	manNumber  = "\033[33m"
+	manAttr    = "\033[33m"
+	manComment = "\033[90m"
+)
+
+// stdoutIsTerminal reports whether stdout is an interactive terminal.
+func stdoutIsTerminal() bool {
+	stat, err := os.Stdout.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+// manColorAllowed reports whether the environment permits colour: NO_COLOR
+// (https://no-color.org) unset or empty, and a terminal other than dumb.
+func manColorAllowed() bool {
+	return os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+}
+
+// manWidth is the width to wrap to: the terminal's, else $COLUMNS, else 80.
+func manWidth() int {
+	if w := terminalWidth(); w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return defaultManWidth
+}
+
+// showManOutput runs show, which prints a man page to stdout. On a
+// terminal the output is captured, formatted, and paged; otherwise show
+// prints directly.
+func showManOutput(noColor bool, show func() error) error {
+	if !stdoutIsTerminal() {
+		return show()
+	}
+	width := manWidth()
+	color := !noColor && manColorAllowed()
+	text, err := captureManOutput(show)
+	if text != "" {
+		pageManText(formatManText(text, width, color))
+	}
+	return err
+}
+
+// captureManOutput returns what show prints to stdout. The man printers
+// write with fmt.Print, so stdout is swapped for a pipe while show runs.
+func captureManOutput(show func() error) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", show()
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var sb strings.Builder
+		io.Copy(&sb, r)
+		r.Close()
+		done <- sb.String()
+	}()
+	showErr := show()
+	w.Close()
+	os.Stdout = stdout
+	return <-done, showErr
+}
+
+// manPagerCommand is the pager to run, split into words, or nil to print
+// directly.
+func manPagerCommand() []string {
+	pager, ok := os.LookupEnv("PAGER")
+	if !ok {
+		pager = defaultManPager
+	}
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		return nil
+	}
+	return args
+}
+
+// pageManText shows text through the pager, or prints it when there is
+// no pager or it cannot be started. Unless $LESS and $LESSCHARSET say
+// otherwise, less exits at once when the page fits on one screen and reads
+// the page as UTF-8.
+func pageManText(text string) {
+	args := manPagerCommand()
+	if args == nil {
+		fmt.Print(text)
+		return
+	}
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		fmt.Print(text)
+		return
+	}
+	c := exec.Command(path, args[1:]...)
+	c.Stdin = strings.NewReader(text)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		c.Env = append(c.Env, "LESS=FRX")
+	}
+	if _, ok := os.LookupEnv("LESSCHARSET"); !ok {
+		c.Env = append(c.Env, "LESSCHARSET=utf-8")
+	}
+	if err := c.Start(); err != nil {
+		fmt.Print(text)
+		return
+	}
+	c.Wait()
+}
+
+var (
+	manLabelLine   = regexp.MustCompile(`^([A-Z][A-Za-z]*:)( +)(\S.*)$`)
+	manHeadingLine = regexp.MustCompile(`^[A-Z][A-Za-z ]*( \([^)]*\))?:$`)
+)
+
+// manCodeLabels are the "Label:  value" lines whose value is Grayscale.
+var manCodeLabels = map[string]bool{"Signature:": true, "Syntax:": true, "Value:": true}
+
+// manCodeHeadings introduce a block of indented Grayscale lines.
+func isManCodeHeading(line string) bool {
+	return strings.HasPrefix(line, "Example") || line == "Definition:"
+}
+
+func isManRule(line string) bool {
+	return line != "" && strings.Trim(line, "─") == ""
+}
+
+// formatManText wraps a page printed by the man printers to width and,
+// when color is set, styles it: the title and headings in bold, labels in
+// bold, rules dimmed, and code highlighted.
+func formatManText(text string, width int, color bool) string {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	style := func(s, code string) string {
+		if !color || s == "" {
+			return s
+		}
+		return code + s + manReset
+	}
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case i+1 < len(lines) && isManRule(lines[i+1]):
+			out = append(out, style(line, manBold))
+		case isManRule(line):
+			out = append(out, style(line, manDim))
+		case manHeadingLine.MatchString(line):
+			out = append(out, style(line, manBold))
+			if !isManCodeHeading(line) {
+				continue
+			}
+			var code []string
+			for i+1 < len(lines) && strings.HasPrefix(lines[i+1], "  ") {
+				i++
+				code = append(code, lines[i][2:])
+			}
+			if len(code) > 0 {
+				block := strings.Join(code, "\n")
+				if color {
+					block = highlightGray(block)
+				}
+				for _, l := range strings.Split(block, "\n") {
+					out = append(out, "  "+l)
+				}
+			}
+		default:
+			m := manLabelLine.FindStringSubmatch(line)
+			if m != nil && manCodeLabels[m[1]] {
+				// Long signatures hang under their first line; each
+				// piece is highlighted on its own.
+				for j, l := range wrapManLine(line, width) {
+					head := m[1] + m[2]
+					if j > 0 {
+						head = strings.Repeat(" ", utf8.RuneCountInString(head))
+					}
+					value := strings.TrimPrefix(l, head)
+					if color {
+						value = highlightGray(value)
+					}
+					if j == 0 {
+						head = style(m[1], manBold) + m[2]
+					}
+					out = append(out, head+value)
+				}
+				continue
+			}
+			wrapped := wrapManLine(line, width)
+			if m != nil {
+				wrapped[0] = style(m[1], manBold) + strings.TrimPrefix(wrapped[0], m[1])
+			}
+			out = append(out, wrapped...)
+		}
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+var (
+	manWordPattern = regexp.MustCompile(`\S+`)
+	manColumnGap   = regexp.MustCompile(`^ *\S(.*?\S)? {2,}`)
+	manKindColumn  = regexp.MustCompile(`^\([a-z]+\) {2,}`)
+	manLabelColumn = regexp.MustCompile(`^\S+: +`)
+)
+
+// wrapManLine breaks line into lines no wider than width, keeping the
+// spacing between words that stay on a line. Continuation lines hang at
+// the line's second column, after the first gap of two or more spaces
+// (index labels, parameter names, gray man -k names and their (kind)), or
+// after a "Label: "; otherwise they keep the line's indentation.
+func wrapManLine(line string, width int) []string {
+	if utf8.RuneCountInString(line) <= width {
+		return []string{line}
+	}
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+	hang := indent
+	if loc := manColumnGap.FindStringIndex(line); loc != nil {
+		hang = loc[1]
+		if k := manKindColumn.FindStringIndex(line[hang:]); k != nil {
+			hang += k[1]
+		}
+	} else if loc := manLabelColumn.FindStringIndex(line); loc != nil {
+		hang = loc[1]
+	}
+	if col := utf8.RuneCountInString(line[:hang]); col > 2*width/3 || width-col < 20 {
+		hang = indent
+	}
+	prefix, rest := line[:hang], line[hang:]
+	pad := strings.Repeat(" ", utf8.RuneCountInString(prefix))
+	var lines []string
+	cur, curLen := prefix, utf8.RuneCountInString(prefix)
+	empty := true
+	last := 0
+	for _, loc := range manWordPattern.FindAllStringIndex(rest, -1) {
+		sep, word := rest[last:loc[0]], rest[loc[0]:loc[1]]
+		last = loc[1]
+		n := utf8.RuneCountInString(word)
+		if !empty && curLen+utf8.RuneCountInString(sep)+n > width {
+			lines = append(lines, cur)
+			cur, curLen, empty = pad, len(pad), true
+		}
+		if !empty {
+			cur += sep
+			curLen += utf8.RuneCountInString(sep)
+		}
+		cur += word
+		curLen += n
+		empty = false
+	}
+	return append(lines, cur)
+}
+
+// manTypeNames are the builtin type names highlighted in code.
+var manTypeNames = func() map[string]bool {
+	types := map[string]bool{}
+	for _, g := range langCategories["types"] {
+		for _, n := range g.Names {
+			types[n] = true
+		}
+	}
+	return types
+}()
+
+// highlightGray colours Grayscale source for a terminal: keywords, type
+// names (builtin and capitalised), strings, numbers, attributes, and
+// comments. Each line is closed separately so pagers redraw it cleanly.
+func highlightGray(src string) string {
+	var b strings.Builder
+	paint := func(s, code string) {
+		if code == "" {
+			b.WriteString(s)
+			return
+		}
+		for i, part := range strings.Split(s, "\n") {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			if part != "" {
+				b.WriteString(code + part + manReset)
+			}
+		}
+	}
+	// gap writes the text between tokens, where the lexer skipped
+	// whitespace and comments.
+	gap := func(s string) {
+		for s != "" {
+			i := strings.Index(s, "//")
+			j := strings.Index(s, "/*")
+			if i < 0 || (j >= 0 && j < i) {
+				i = j
+			}
+			if i < 0 {
+				b.WriteString(s)
+				return
+			}
+			b.WriteString(s[:i])
+			end := len(s)
+			if strings.HasPrefix(s[i:], "//") {
+				if nl := strings.IndexByte(s[i:], '\n'); nl >= 0 {
+					end = i + nl
+				}
+			} else if close := strings.Index(s[i+2:], "*/"); close >= 0 {
+				end = i + 2 + close + 2
+			}
+			paint(s[i:end], manComment)
+			s = s[end:]
+		}
+	}
+	pos := 0
+	for _, tok := range lexGraySource(src) {
+		gap(src[pos:tok.Offset])
+		pos = tok.End
+		code := ""
+		switch tok.Kind {
+		case tokKeyword:
+			code = manKeyword
+		case tokString, tokRawString, tokChar:
+			code = manString
+		case tokNumber:
+			code = manNumber
+		case tokAttr:
+			code = manAttr
+		case tokIdent:
+			if manTypeNames[tok.Text] || (tok.Text[0] >= 'A' && tok.Text[0] <= 'Z') {
+				code = manType
+			}
+		}
+		paint(tok.Text, code)
+	}
+	gap(src[pos:])
+	return b.String()
+}
@@ -0,0 +1,153 @@
+// manpager_test.go — Tests for gray man's terminal output: wrapping,
+// colour, code highlighting, and choosing the pager.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func TestWrapManLine(t *testing.T) {
+	tests := []struct {
+		line  string
+		width int
+		want  []string
+	}{
+		{"short line", 40, []string{"short line"}},
+		{
+			"Splits s around each occurrence of sep and returns a string array.", 30,
+			[]string{"Splits s around each", "occurrence of sep and returns", "a string array."},
+		},
+		{
+			"  Path            path_join()  dirname()  basename()  extension()", 50,
+			[]string{"  Path            path_join()  dirname()", "                  basename()  extension()"},
+		},
+		{
+			"io.read_file    (stdlib)   Reads the entire file at path and returns it.", 50,
+			[]string{"io.read_file    (stdlib)   Reads the entire file", "                           at path and returns it."},
+		},
+		{
+			"Range:     E2xxx — Problems understanding your code (missing brackets)", 50,
+			[]string{"Range:     E2xxx — Problems understanding your", "           code (missing brackets)"},
+		},
+	}
+	for _, tt := range tests {
+		if got := wrapManLine(tt.line, tt.width); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("wrapManLine(%q, %d) =\n%q\nwant\n%q", tt.line, tt.width, got, tt.want)
+		}
+	}
+}
+
+const testManPage = `strings: split()
+────────────────────
+Module:  strings
+Kind:    function
+Signature:  split(s string, sep string) -> [string]
+
+Splits s around each occurrence of sep and returns a string array.
+
+Example:
+  mut parts = strings.split("a,b", ",")  // two parts
+`
+
+func TestFormatManTextPlain(t *testing.T) {
+	got := formatManText(testManPage, 40, false)
+	want := strings.NewReplacer(
+		"Splits s around each occurrence of sep and returns a string array.", "Splits s around each occurrence of sep\nand returns a string array.",
+		"split(s string, sep string) -> [string]", "split(s string, sep string)\n            -> [string]",
+	).Replace(testManPage)
+	if got != want {
+		t.Errorf("formatManText =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatManTextColor(t *testing.T) {
+	got := formatManText(testManPage, 80, true)
+	for _, want := range []string{
+		manBold + "strings: split()" + manReset,
+		manDim + "────────────────────" + manReset,
+		manBold + "Module:" + manReset + "  strings",
+		manBold + "Signature:" + manReset + "  split(s " + manType + "string" + manReset,
+		manBold + "Example:" + manReset,
+		"  " + manKeyword + "mut" + manReset + " parts",
+		manString + `"a,b"` + manReset,
+		manComment + "// two parts" + manReset,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%q", want, got)
+		}
+	}
+	if plain := ansiPattern.ReplaceAllString(got, ""); plain != testManPage {
+		t.Errorf("colour changed the text:\n%s", plain)
+	}
+}
+
+func TestHighlightGray(t *testing.T) {
+	src := "#json\nconst P struct {\n    n i64 /* count */\n}\nmut s = `raw\ntext`"
+	got := highlightGray(src)
+	for _, want := range []string{
+		manAttr + "#json" + manReset, manKeyword + "const" + manReset, manType + "P" + manReset,
+		manType + "i64" + manReset, manComment + "/* count */" + manReset,
+		// Strings spanning lines are closed at the end of each line.
+		manString + "`raw" + manReset + "\n" + manString + "text`" + manReset,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("highlightGray missing %q:\n%q", want, got)
+		}
+	}
+	if plain := ansiPattern.ReplaceAllString(got, ""); plain != src {
+		t.Errorf("highlightGray changed the text: %q", plain)
+	}
+}
+
+func TestManPagerCommand(t *testing.T) {
+	t.Setenv("PAGER", "more -s")
+	if got := manPagerCommand(); !reflect.DeepEqual(got, []string{"more", "-s"}) {
+		t.Errorf("manPagerCommand = %q", got)
+	}
+	for _, pager := range []string{"", "cat"} {
+		t.Setenv("PAGER", pager)
+		if got := manPagerCommand(); got != nil {
+			t.Errorf("PAGER=%q: manPagerCommand = %q", pager, got)
+		}
+	}
+}
+
+func TestManColorAllowed(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("NO_COLOR", "")
+	if !manColorAllowed() {
+		t.Error("colour disabled with NO_COLOR empty")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if manColorAllowed() {
+		t.Error("colour enabled with NO_COLOR=1")
+	}
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "dumb")
+	if manColorAllowed() {
+		t.Error("colour enabled with TERM=dumb")
+	}
+}
+
+func TestShowManOutputPiped(t *testing.T) {
+	// Output that is not a terminal is passed through untouched.
+	out := captureStdout(t, func() {
+		showManOutput(false, func() error {
+			printManEntry("strings", "split", "func", "split(s string) -> [string]", "", "Splits s.", "")
+			return nil
+		})
+	})
+	if strings.Contains(out, "\x1b[") || !strings.HasPrefix(out, "strings: split()\n") {
+		t.Errorf("piped output = %q", out)
+	}
+}
@@ -0,0 +1,175 @@
+// manproject.go — "gray man" lookups in the current project. When a name
+// is not a builtin, stdlib item, or language entry, gray man reads the
+// #doc attributes of the project's own declarations (as gray doc does),
+// and the @man blocks of its C headers (see stdlibman.go), and shows the
+// match with the same layout as the stdlib pages, plus where it is
+// defined.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// projectManRoot is the directory whose sources gray man searches: the
+// nearest directory at or above the working directory holding gray.toml.
+// Outside a project there is nothing to search.
+func projectManRoot() (string, bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	return manifestDir(cwd)
+}
+
+// projectManEntries reads the documented items of every file in the
+// project. Files grayc cannot read are skipped, and #doc tag warnings are
+// left to gray doc, so a broken file elsewhere does not stop a lookup.
+func projectManEntries(root string) []DocEntry {
+	var entries []DocEntry
+	imports := docImports{}
+	for _, file := range projectSourceFiles(root, ".gray") {
+		dump, src, err := readDeclDump(file)
+		if err != nil {
+			continue
+		}
+		found, _ := docEntriesFromDump(dump, file, src)
+		entries = append(entries, found...)
+		imports[file] = dump.Imports
+	}
+	assignDirModules(imports, entries)
+	return entries
+}
+
+// findProjectMan returns the items name refers to: "func", "Struct.func",
+// or either qualified with the module name ("shapes.area") or import path
+// ("src/shapes.area").
+func findProjectMan(entries []DocEntry, name string) []DocEntry {
+	var matches []DocEntry
+	for _, e := range entries {
+		for _, item := range append([]DocEntry{e}, e.Members...) {
+			if item.Name == name || item.Module+"."+item.Name == name || item.ModulePath+"."+item.Name == name {
+				matches = append(matches, item)
+			}
+		}
+	}
+	return matches
+}
+
+// projectHeaderMan is an @man block from a C header in the project, such
+// as a library used through import c"mylib.h".
+type projectHeaderMan struct {
+	File  string
+	Block manBlock
+}
+
+// projectHeaderMans reads the @man blocks of the project's C headers.
+func projectHeaderMans(root string) []projectHeaderMan {
+	var out []projectHeaderMan
+	for _, file := range projectSourceFiles(root, ".h") {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for _, b := range parseManBlocks(string(src)) {
+			out = append(out, projectHeaderMan{File: file, Block: b})
+		}
+	}
+	return out
+}
+
+// lookupProjectMan prints the project item name refers to, from #doc in
+// the project's .gray files or @man blocks in its C headers. It reports
+// false when there is none, and an error listing the qualified names when
+// name is ambiguous.
+func lookupProjectMan(name string) (bool, error) {
+	root, ok := projectManRoot()
+	if !ok {
+		return false, nil
+	}
+	var keys []string
+	var show func()
+	for _, e := range findProjectMan(projectManEntries(root), name) {
+		keys = append(keys, e.Module+"."+e.Name)
+		show = func() { printProjectEntry(e) }
+	}
+	for _, h := range projectHeaderMans(root) {
+		if b := h.Block; b.Name == name || b.Module+"."+b.Name == name {
+			keys = append(keys, b.Module+"."+b.Name)
+			show = func() { printProjectHeaderMan(h) }
+		}
+	}
+	switch len(keys) {
+	case 0:
+		return false, nil
+	case 1:
+		show()
+		return true, nil
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "gray: '%s' exists in multiple modules. Use a qualified name:", name)
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "\n    gray man %s", k)
+	}
+	return true, fmt.Errorf("%s", sb.String())
+}
+
+// printProjectEntry shows a project item as printManEntry does stdlib
+// items. Parameters, returns, and deprecation are folded into the
+// description, and the source location follows.
+func printProjectEntry(e DocEntry) {
+	kind := "func"
+	switch e.Kind {
+	case "struct", "enum":
+		kind = "type"
+	case "const":
+		kind = "const"
+	}
+	printManEntry(e.Module, e.Name, kind, e.Signature, "", projectManDesc(e), strings.Join(e.Examples, "\n\n"))
+	fmt.Printf("\nSource:  %s:%d\n", filepath.ToSlash(relPath(e.File)), e.Line)
+}
+
+// printProjectHeaderMan shows an @man block from a project header.
+func printProjectHeaderMan(h projectHeaderMan) {
+	b := h.Block
+	printManEntry(b.Module, b.Name, b.Kind, b.Sig, strings.Join(b.Fields, "\n"), b.Desc, b.Example)
+	fmt.Printf("\nSource:  %s:%d\n", filepath.ToSlash(relPath(h.File)), b.Line)
+}
+
+func projectManDesc(e DocEntry) string {
+	var parts []string
+	if e.Deprecated {
+		note := "Deprecated."
+		if e.DeprecatedNote != "" {
+			note = "Deprecated: " + e.DeprecatedNote
+		}
+		parts = append(parts, note)
+	}
+	if e.Description != "" {
+		parts = append(parts, e.Description)
+	}
+	if len(e.Params) > 0 {
+		width := 0
+		for _, p := range e.Params {
+			width = max(width, len(p.Name))
+		}
+		lines := []string{"Parameters:"}
+		for _, p := range e.Params {
+			lines = append(lines, strings.TrimRight(fmt.Sprintf("  %-*s  %s", width, p.Name, p.Text), " "))
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	if len(e.Returns) > 0 {
+		parts = append(parts, "Returns: "+strings.Join(e.Returns, "; "))
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,148 @@
+// manproject_test.go — Tests for gray man lookups in the current
+// project: finding the project root and sources, qualified and plain
+// names, ambiguity, and the rendered page.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProjectManRootAndFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, manifestFileName), "")
+	writeTestFile(t, filepath.Join(root, "main.gray"), "")
+	writeTestFile(t, filepath.Join(root, "src", "shapes.gray"), "")
+	writeTestFile(t, filepath.Join(root, "src", ".gray-doctest-1.gray"), "")
+	writeTestFile(t, filepath.Join(root, ".cache", "old.gray"), "")
+	runFromTempDir(t, filepath.Join(root, "src"), func() {
+		got, ok := projectManRoot()
+		if !ok {
+			t.Fatal("projectManRoot found no gray.toml")
+		}
+		if resolved, _ := filepath.EvalSymlinks(got); resolved != mustEvalSymlinks(t, root) {
+			t.Errorf("projectManRoot = %q, want %q", got, root)
+		}
+		files := projectSourceFiles(got, ".gray")
+		if len(files) != 2 || filepath.Base(files[0]) != "main.gray" || filepath.Base(files[1]) != "shapes.gray" {
+			t.Errorf("projectSourceFiles = %v", files)
+		}
+	})
+}
+
+func TestProjectManOutsideProject(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "mathx.gray"), "")
+	useDeclDump(t, `{"decls":[{"kind":"function","name":"add","line":1,"doc":"Adds."}]}`)
+	runFromTempDir(t, dir, func() {
+		if root, ok := projectManRoot(); ok {
+			t.Errorf("projectManRoot = %q without gray.toml", root)
+		}
+		if found, _ := lookupProjectMan("add"); found {
+			t.Error("searched sources outside a gray.toml project")
+		}
+	})
+}
+
+func TestManTypoSkipsProject(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, manifestFileName), "")
+	writeTestFile(t, filepath.Join(root, "main.gray"), "")
+	dumped := false
+	prev := dumpDecls
+	dumpDecls = func(string) ([]byte, error) { dumped = true; return []byte(`{"decls":[]}`), nil }
+	t.Cleanup(func() { dumpDecls = prev })
+	runFromTempDir(t, root, func() {
+		err := runMan(manCmd, []string{"prinln"})
+		if err == nil || !strings.Contains(err.Error(), "did you mean: println") {
+			t.Errorf("runMan(prinln) = %v", err)
+		}
+		if dumped {
+			t.Error("walked the project for a misspelt builtin")
+		}
+	})
+}
+
+func mustEvalSymlinks(t *testing.T, path string) string {
+	t.Helper()
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resolved
+}
+
+func TestFindProjectMan(t *testing.T) {
+	entries := []DocEntry{
+		{Name: "init", Module: "db", ModulePath: "src/db"},
+		{Name: "init", Module: "models", ModulePath: "src/models"},
+		{Name: "Point", Module: "shapes", ModulePath: "src/shapes", Members: []DocEntry{
+			{Name: "Point.make", Module: "shapes", ModulePath: "src/shapes"},
+		}},
+	}
+	for name, want := range map[string]int{
+		"init": 2, "db.init": 1, "src/models.init": 1, "Point": 1,
+		"Point.make": 1, "shapes.Point.make": 1, "make": 0, "other.init": 0,
+	} {
+		if got := len(findProjectMan(entries, name)); got != want {
+			t.Errorf("findProjectMan(%q) found %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestLookupProjectMan(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, manifestFileName), "")
+	writeTestFile(t, filepath.Join(root, "src", "mathx.gray"), "")
+	useDeclDump(t, `{"decls":[{"kind":"function","name":"add","line":5,`+
+		`"doc":"Adds two numbers.\n@param a first\n@param b second\n@return the sum\n@example\n    println(add(1, 2))",`+
+		`"params":[{"name":"a","type":"int"},{"name":"b","type":"int"}],"returns":[{"type":"int"}]}]}`)
+	runFromTempDir(t, root, func() {
+		var found bool
+		var err error
+		out := captureStdout(t, func() { found, err = lookupProjectMan("mathx.add") })
+		if !found || err != nil {
+			t.Fatalf("lookupProjectMan = %v, %v", found, err)
+		}
+		for _, want := range []string{
+			"mathx: add()", "Module:  mathx", "Signature:  do add(a int, b int) -> int",
+			"Adds two numbers.\n\nParameters:\n  a  first\n  b  second\n\nReturns: the sum",
+			"Example:\n  println(add(1, 2))", "Source:  src/mathx.gray:5",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+		if found, _ := lookupProjectMan("missing"); found {
+			t.Error("found an undocumented name")
+		}
+	})
+}
+
+func TestLookupProjectHeaderMan(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, manifestFileName), "")
+	writeTestFile(t, filepath.Join(root, "include", "mylib.h"), "#pragma once\n\n"+
+		"/*@man greet\n *@module mylib\n *@sig greet(name string) -> string\n *@desc Says hello.\n */\n"+
+		"const char *mylib_greet(const char *name);\n")
+	useDeclDump(t, `{"decls":[]}`)
+	runFromTempDir(t, root, func() {
+		var found bool
+		var err error
+		out := captureStdout(t, func() { found, err = lookupProjectMan("mylib.greet") })
+		if !found || err != nil {
+			t.Fatalf("lookupProjectMan = %v, %v", found, err)
+		}
+		for _, want := range []string{"mylib: greet()", "Signature:  greet(name string) -> string", "Says hello.", "Source:  include/mylib.h:3"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+	})
+}
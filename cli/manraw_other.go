@@ -0,0 +1,23 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+// manraw_other.go — gray man --tui is not available where raw terminal
+// input is not supported.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// resizeSignals is empty: resizes are picked up on the next key.
+var resizeSignals []os.Signal
+
+// makeRawTerminal reports that raw input is unsupported here.
+func makeRawTerminal(f *os.File) (func(), error) {
+	return nil, errors.New("gray man --tui needs a Unix terminal")
+}
@@ -0,0 +1,217 @@
+// mansearch.go — Full-text search over gray man's documentation tables
+// ("gray man -k"), in the manner of apropos. Each query term is matched
+// against entry names, modules, signatures, descriptions, and examples;
+// results are ranked by how many terms matched and where.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// manDoc is one entry of any of the man tables, flattened for searching.
+type manDoc struct {
+	Name    string // what to pass to gray man, e.g. "strings.split"
+	Source  string // "builtin", "stdlib", or "lang"
+	Module  string // stdlib module, if any
+	Kind    string
+	Sig     string
+	Desc    string
+	Example string
+}
+
+// allManDocs flattens builtinManDocs, stdlibManDocs, and langManDocs,
+// sorted by name.
+func allManDocs() []manDoc {
+	var docs []manDoc
+	for name, e := range builtinManDocs {
+		docs = append(docs, manDoc{Name: name, Source: "builtin", Kind: e.Kind, Sig: e.Sig, Desc: e.Desc, Example: e.Example})
+	}
+	for key, e := range stdlibManDocs {
+		docs = append(docs, manDoc{Name: key, Source: "stdlib", Module: e.Module, Kind: e.Kind, Sig: e.Sig, Desc: e.Desc, Example: e.Example})
+	}
+	for key, e := range langManDocs {
+		// Attributes are looked up without their '#'.
+		name := langDisplayName(strings.TrimPrefix(key, "#"))
+		docs = append(docs, manDoc{Name: name, Source: "lang", Kind: e.Kind, Sig: e.Syntax, Desc: e.Desc, Example: e.Example})
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if docs[i].Name != docs[j].Name {
+			return docs[i].Name < docs[j].Name
+		}
+		return docs[i].Source < docs[j].Source
+	})
+	return docs
+}
+
+// manStopWords are dropped from a query that has other terms, so "how do
+// I split a string" searches for split and string. A query of only stop
+// words (gray man -k do) is kept as is.
+var manStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "how": true, "do": true, "does": true, "i": true,
+	"to": true, "of": true, "in": true, "into": true, "on": true, "for": true, "with": true,
+	"and": true, "or": true, "is": true, "it": true, "my": true, "can": true, "what": true,
+	"from": true, "by": true,
+}
+
+// manWords splits text into lowercase words at anything that is not a
+// letter or digit, so "read_lines" is "read" and "lines".
+func manWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// manStem reduces a word to a rough stem so plurals and verb forms match:
+// "lines" and "line", "strings" and "string", "splitting" and "split".
+func manStem(w string) string {
+	switch {
+	case len(w) >= 7 && strings.HasSuffix(w, "ing"):
+		w = strings.TrimSuffix(w, "ing")
+		// "splitt" from "splitting"
+		if n := len(w); w[n-1] == w[n-2] {
+			w = w[:n-1]
+		}
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		w = strings.TrimSuffix(w, "s")
+	}
+	return w
+}
+
+// manQueryTerms turns a query into stems, dropping stop words unless
+// nothing else is left.
+func manQueryTerms(query []string) []string {
+	var all, kept []string
+	seen := map[string]bool{}
+	for _, w := range manWords(strings.Join(query, " ")) {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		all = append(all, manStem(w))
+		if !manStopWords[w] {
+			kept = append(kept, manStem(w))
+		}
+	}
+	if len(kept) == 0 {
+		return all
+	}
+	return kept
+}
+
+// Weights of a term found in each part of an entry.
+const (
+	manWeightName    = 10
+	manWeightModule  = 6
+	manWeightSig     = 3
+	manWeightDesc    = 2
+	manWeightExample = 1
+)
+
+// manHit is a ranked search result.
+type manHit struct {
+	Doc     manDoc
+	Matched int // query terms found anywhere in the entry
+	Score   int
+}
+
+func manStems(text string) map[string]bool {
+	stems := map[string]bool{}
+	for _, w := range manWords(text) {
+		stems[manStem(w)] = true
+	}
+	return stems
+}
+
+// searchManDocs ranks docs against the query terms: entries matching more
+// terms first, then by score, then shorter names. Entries matching no
+// term are left out.
+func searchManDocs(docs []manDoc, terms []string) []manHit {
+	var hits []manHit
+	for _, d := range docs {
+		name := d.Name
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		fields := []struct {
+			stems  map[string]bool
+			weight int
+		}{
+			{manStems(name), manWeightName},
+			{manStems(d.Module), manWeightModule},
+			{manStems(d.Sig), manWeightSig},
+			{manStems(d.Desc), manWeightDesc},
+			{manStems(d.Example), manWeightExample},
+		}
+		hit := manHit{Doc: d}
+		for _, t := range terms {
+			found := false
+			for _, f := range fields {
+				if f.stems[t] {
+					hit.Score += f.weight
+					found = true
+				}
+			}
+			if found {
+				hit.Matched++
+			}
+		}
+		if hit.Matched > 0 {
+			hits = append(hits, hit)
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.Matched != b.Matched {
+			return a.Matched > b.Matched
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return len(a.Doc.Name) < len(b.Doc.Name)
+	})
+	return hits
+}
+
+// manSearchLimit caps how many results gray man -k lists.
+const manSearchLimit = 20
+
+// manSummary is the first sentence of a description.
+func manSummary(desc string) string {
+	desc = strings.Join(strings.Fields(desc), " ")
+	if i := strings.Index(desc, ". "); i >= 0 {
+		desc = desc[:i+1]
+	}
+	return desc
+}
+
+// runManSearch is gray man -k: it prints the best matches for query, one
+// per line, or an error when nothing matches.
+func runManSearch(query []string) error {
+	terms := manQueryTerms(query)
+	if len(terms) == 0 {
+		return fmt.Errorf("gray: gray man -k needs search terms\n    e.g. gray man -k split string")
+	}
+	hits := searchManDocs(allManDocs(), terms)
+	if len(hits) == 0 {
+		return fmt.Errorf("gray: no documentation matches '%s'\n    try: gray man builtins  or  gray man lang", strings.Join(query, " "))
+	}
+	if len(hits) > manSearchLimit {
+		hits = hits[:manSearchLimit]
+	}
+	width := 0
+	for _, h := range hits {
+		width = max(width, len(h.Doc.Name))
+	}
+	for _, h := range hits {
+		fmt.Printf("%-*s  %-9s  %s\n", width, h.Doc.Name, "("+h.Doc.Source+")", manSummary(h.Doc.Desc))
+	}
+	return nil
+}
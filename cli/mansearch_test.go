@@ -0,0 +1,86 @@
+// mansearch_test.go — Tests for gray man -k: query terms, stemming, and
+// ranking across the builtin, stdlib, and language tables.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestManQueryTerms(t *testing.T) {
+	tests := []struct {
+		query []string
+		want  []string
+	}{
+		{[]string{"How do I split a string"}, []string{"split", "string"}},
+		{[]string{"read", "file", "lines"}, []string{"read", "file", "line"}},
+		{[]string{"splitting", "strings"}, []string{"split", "string"}},
+		{[]string{"do"}, []string{"do"}},
+		{[]string{"read_lines"}, []string{"read", "line"}},
+	}
+	for _, tt := range tests {
+		if got := manQueryTerms(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("manQueryTerms(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchManDocs(t *testing.T) {
+	docs := allManDocs()
+	for query, want := range map[string]string{
+		"How do I split a string": "strings.split",
+		"read file lines":         "io.read_lines",
+		"square root":             "math.sqrt",
+	} {
+		hits := searchManDocs(docs, manQueryTerms([]string{query}))
+		if len(hits) == 0 || hits[0].Doc.Name != want {
+			var top []string
+			for _, h := range hits[:min(len(hits), 5)] {
+				top = append(top, h.Doc.Name)
+			}
+			t.Errorf("-k %q: top hits %v, want %s first", query, top, want)
+		}
+	}
+	if hits := searchManDocs(docs, []string{"zzzz"}); len(hits) != 0 {
+		t.Errorf("nonsense query matched %d entries", len(hits))
+	}
+}
+
+func TestAllManDocsLangNames(t *testing.T) {
+	names := map[string]bool{}
+	for _, d := range allManDocs() {
+		if d.Source == "lang" {
+			names[d.Name] = true
+		}
+	}
+	for _, want := range []string{"flags", "i8", "struct"} {
+		if !names[want] {
+			t.Errorf("language entry %q missing", want)
+		}
+	}
+	for name := range names {
+		if strings.HasPrefix(name, "#") || strings.HasSuffix(name, "_type") {
+			t.Errorf("language entry listed as %q, which gray man does not look up", name)
+		}
+	}
+}
+
+func TestRunManSearch(t *testing.T) {
+	out := captureStdout(t, func() {
+		if err := runManSearch([]string{"read", "file", "lines"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.HasPrefix(out, "io.read_lines") || !strings.Contains(out, "(stdlib)") {
+		t.Errorf("output:\n%s", out)
+	}
+	if err := runManSearch([]string{"zzzz"}); err == nil || !strings.Contains(err.Error(), "no documentation matches 'zzzz'") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+// mansuggest.go — "Did you mean" suggestions when gray man finds nothing
+// for a name: the closest names by edit distance across the builtin,
+// stdlib, and language tables and the stdlib module list, followed by
+// names the query is a prefix or substring of.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// manSuggestLimit caps how many names a failed lookup suggests.
+const manSuggestLimit = 5
+
+// manNames is every name gray man answers for.
+func manNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(n string) {
+		if !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+	for _, d := range allManDocs() {
+		add(d.Name)
+	}
+	for m := range stdlibModules {
+		add(m)
+	}
+	for _, n := range []string{"builtins", "lang", "keywords", "types", "symbols", "attributes"} {
+		add(n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// suggestManNames returns up to manSuggestLimit names close to query (see
+// manNameDistance), nearest first. Names the query starts or is contained
+// in come after those, for queries of three or more characters.
+func suggestManNames(query string, names []string) []string {
+	q := strings.ToLower(query)
+	limit := maxManEdits(q)
+	type candidate struct {
+		name string
+		rank int // edit distance, or limit+1 for prefix and limit+2 for substring matches
+	}
+	var found []candidate
+	for _, name := range names {
+		if name == query {
+			continue
+		}
+		n := strings.ToLower(name)
+		d, ok := manNameDistance(q, n)
+		switch {
+		case ok:
+			found = append(found, candidate{name, d})
+		case len(q) >= 3 && strings.HasPrefix(n, q):
+			found = append(found, candidate{name, limit + 1})
+		case len(q) >= 3 && strings.Contains(n, q):
+			found = append(found, candidate{name, limit + 2})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].rank < found[j].rank })
+	var out []string
+	for _, c := range found[:min(len(found), manSuggestLimit)] {
+		out = append(out, c.name)
+	}
+	return out
+}
+
+// isManTypo reports whether query is within maxManEdits of name, as a
+// misspelling of it would be, rather than a prefix or substring of it.
+func isManTypo(query, name string) bool {
+	_, ok := manNameDistance(strings.ToLower(query), strings.ToLower(name))
+	return ok
+}
+
+// maxManEdits is how many edits a name may be from s and still be
+// suggested: a third of its length, at least one.
+func maxManEdits(s string) int {
+	return max(1, len([]rune(s))/3)
+}
+
+// manNameDistance reports the edit distance between a query and a name
+// and whether the name is close enough to suggest. A qualified query is
+// compared part by part with a qualified name, so "string.contains"
+// finds strings.contains but not arrays.contains; a plain query is also
+// compared with the unqualified part of a name.
+func manNameDistance(q, n string) (int, bool) {
+	qMod, qName, qQualified := strings.Cut(q, ".")
+	nMod, nName, nQualified := strings.Cut(n, ".")
+	switch {
+	case qQualified && nQualified:
+		dm, dn := editDistance(qMod, nMod), editDistance(qName, nName)
+		return dm + dn, dm <= maxManEdits(qMod) && dn <= maxManEdits(qName)
+	case nQualified:
+		d := min(editDistance(q, n), editDistance(q, nName))
+		return d, d <= maxManEdits(q)
+	}
+	d := editDistance(q, n)
+	return d, d <= maxManEdits(q)
+}
@@ -0,0 +1,84 @@
+// mansuggest_test.go — Tests for gray man's "did you mean" suggestions:
+// edit distance, qualified names, and prefix and substring matches.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"prinln", "println", 1},
+		{"kitten", "sitting", 3},
+		{"abc", "", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestManNames(t *testing.T) {
+	names := manNames()
+	tests := []struct {
+		query string
+		first string
+	}{
+		{"prinln", "println"},
+		{"string.contains", "strings.contains"},
+		{"mth.sqrt", "math.sqrt"},
+		{"sqr", "math.sqrt"},
+		{"keyword", "keywords"},
+		{"Println", "println"},
+	}
+	for _, tt := range tests {
+		got := suggestManNames(tt.query, names)
+		if len(got) == 0 || got[0] != tt.first {
+			t.Errorf("suggestManNames(%q) = %v, want %s first", tt.query, got, tt.first)
+		}
+		if len(got) > manSuggestLimit {
+			t.Errorf("suggestManNames(%q) returned %d names", tt.query, len(got))
+		}
+	}
+	// Qualified lookups are compared part by part.
+	if got := suggestManNames("string.contains", names); !reflect.DeepEqual(got, []string{"strings.contains"}) {
+		t.Errorf("suggestManNames(string.contains) = %v", got)
+	}
+	if got := suggestManNames("xyzzy", names); len(got) != 0 {
+		t.Errorf("suggestManNames(xyzzy) = %v", got)
+	}
+}
+
+func TestSuggestManNamesSubstring(t *testing.T) {
+	names := []string{"io.read_lines", "io.read_file", "strings.split"}
+	if got := suggestManNames("read", names); !reflect.DeepEqual(got, []string{"io.read_lines", "io.read_file"}) {
+		t.Errorf("suggestManNames(read) = %v", got)
+	}
+}
+
+func TestIsManTypo(t *testing.T) {
+	for _, tc := range []struct {
+		query, name string
+		want        bool
+	}{
+		{"prinln", "println", true},
+		{"string.contains", "strings.contains", true},
+		{"read", "io.read_lines", false},
+		{"area", "println", false},
+	} {
+		if got := isManTypo(tc.query, tc.name); got != tc.want {
+			t.Errorf("isManTypo(%q, %q) = %v, want %v", tc.query, tc.name, got, tc.want)
+		}
+	}
+}
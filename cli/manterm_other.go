@@ -0,0 +1,20 @@
+//go:build !unix
+
+// manterm_other.go — Terminal size for gray man where it cannot be
+// queried; $COLUMNS or the default width is used instead.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+// terminalWidth returns 0: the width is unknown.
+func terminalWidth() int {
+	return 0
+}
+
+// terminalSize returns zeros: the size is unknown.
+func terminalSize() (int, int) {
+	return 0, 0
+}
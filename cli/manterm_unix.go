@@ -0,0 +1,31 @@
+//go:build unix
+
+// manterm_unix.go — Terminal size for gray man on Unix systems.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalWidth returns the width of the terminal on stdout, or 0.
+func terminalWidth() int {
+	width, _ := terminalSize()
+	return width
+}
+
+// terminalSize returns the columns and rows of the terminal on stdout, or
+// zeros.
+func terminalSize() (int, int) {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0
+	}
+	return int(ws.Col), int(ws.Row)
+}
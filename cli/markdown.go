@@ -0,0 +1,244 @@
+// markdown.go — Fenced code block extraction for Markdown files. Finds
+// ```gray blocks (with optional comma-separated annotations in the info
+// string, e.g. ```gray,fail=E2001) so "gray fmt" can format them in place
+// and diagnostics can be reported against Markdown line numbers.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+// mdCodeBlock is one fenced code block in a Markdown document.
+type mdCodeBlock struct {
+	Lang  string            // first word of the info string, lower-cased
+	Attrs map[string]string // remaining comma-separated annotations
+	Line  int               // 1-based line of the opening fence
+	Code  string            // block content with the fence indentation removed
+
+	indent     int // columns of indentation on the opening fence
+	start, end int // byte range of the content lines in the document
+}
+
+// FirstLine is the Markdown line number of the block's first code line.
+func (b mdCodeBlock) FirstLine() int { return b.Line + 1 }
+
+// isMarkdownFile reports whether path names a Markdown document.
+func isMarkdownFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// findMarkdownBlocks returns the fenced code blocks of src in order.
+// Fences follow CommonMark: three or more backticks or tildes indented by
+// at most three spaces, closed by a fence of the same character that is
+// at least as long. An unclosed block runs to the end of the document.
+func findMarkdownBlocks(src string) []mdCodeBlock {
+	var blocks []mdCodeBlock
+	var cur *mdCodeBlock
+	var fence string
+
+	offset := 0
+	for n, line := range strings.SplitAfter(src, "\n") {
+		if line == "" {
+			break
+		}
+		text := strings.TrimRight(line, "\r\n")
+		if cur == nil {
+			if f, indent, info, ok := parseFenceLine(text); ok {
+				lang, attrs := parseInfoString(info)
+				cur = &mdCodeBlock{Lang: lang, Attrs: attrs, Line: n + 1, indent: indent, start: offset + len(line)}
+				fence = f
+			}
+		} else if f, _, info, ok := parseFenceLine(text); ok && info == "" && f[0] == fence[0] && len(f) >= len(fence) {
+			cur.end = offset
+			blocks = append(blocks, *cur)
+			cur = nil
+		}
+		offset += len(line)
+	}
+	if cur != nil {
+		cur.end = len(src)
+		blocks = append(blocks, *cur)
+	}
+	for i := range blocks {
+		blocks[i].Code = dedentBlock(src[blocks[i].start:blocks[i].end], blocks[i].indent)
+	}
+	return blocks
+}
+
+// parseFenceLine recognises a code fence line, returning the fence run,
+// its indentation, and the trimmed info string.
+func parseFenceLine(line string) (fence string, indent int, info string, ok bool) {
+	for indent < len(line) && line[indent] == ' ' {
+		indent++
+	}
+	if indent > 3 || indent >= len(line) || (line[indent] != '`' && line[indent] != '~') {
+		return "", 0, "", false
+	}
+	c := line[indent]
+	end := indent
+	for end < len(line) && line[end] == c {
+		end++
+	}
+	if end-indent < 3 {
+		return "", 0, "", false
+	}
+	info = strings.TrimSpace(line[end:])
+	if c == '`' && strings.ContainsRune(info, '`') {
+		return "", 0, "", false
+	}
+	return line[indent:end], indent, info, true
+}
+
+// parseInfoString splits `gray,run,output="hi there\n"` into its language
+// and annotations. Annotations are separated by commas or whitespace;
+// values may be double-quoted Go strings to hold separators and escapes.
+// Annotations without a value map to "".
+func parseInfoString(info string) (string, map[string]string) {
+	attrs := map[string]string{}
+	var fields []string
+	var cur strings.Builder
+	inQuote := false
+	for i := 0; i < len(info); i++ {
+		c := info[i]
+		switch {
+		case inQuote && c == '\\' && i+1 < len(info):
+			cur.WriteByte(c)
+			i++
+			c = info[i]
+		case c == '"':
+			inQuote = !inQuote
+		case !inQuote && (c == ',' || c == ' ' || c == '\t'):
+			if cur.Len() > 0 {
+				fields = append(fields, cur.String())
+				cur.Reset()
+			}
+			continue
+		}
+		cur.WriteByte(c)
+	}
+	if cur.Len() > 0 {
+		fields = append(fields, cur.String())
+	}
+	if len(fields) == 0 {
+		return "", attrs
+	}
+	for _, f := range fields[1:] {
+		k, v, _ := strings.Cut(f, "=")
+		if strings.HasPrefix(v, `"`) {
+			if u, err := strconv.Unquote(v); err == nil {
+				v = u
+			}
+		}
+		attrs[strings.ToLower(k)] = v
+	}
+	return strings.ToLower(fields[0]), attrs
+}
+
+// dedentBlock removes up to indent leading spaces from each line, as
+// CommonMark does for fences nested in list items.
+func dedentBlock(s string, indent int) string {
+	if indent == 0 {
+		return s
+	}
+	lines := strings.SplitAfter(s, "\n")
+	for i, l := range lines {
+		n := 0
+		for n < indent && n < len(l) && l[n] == ' ' {
+			n++
+		}
+		lines[i] = l[n:]
+	}
+	return strings.Join(lines, "")
+}
+
+// indentBlock re-applies a fence's indentation to non-blank lines.
+func indentBlock(s string, indent int) string {
+	if indent == 0 {
+		return s
+	}
+	pad := strings.Repeat(" ", indent)
+	lines := strings.SplitAfter(s, "\n")
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			lines[i] = pad + l
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// isGrayBlock reports whether a block holds Grayscale source.
+func (b mdCodeBlock) isGrayBlock() bool {
+	return b.Lang == "gray" || b.Lang == "grayscale"
+}
+
+// shiftDiagnostics rewrites "name:line:col" and "name:line" positions
+// (the formatter's bracket errors have no column) in diagnostics for a
+// block so they point at lines of the Markdown document.
+func shiftDiagnostics(diags, name string, firstLine int) string {
+	re := regexp.MustCompile(regexp.QuoteMeta(name) + `:(\d+)(:\d+)?`)
+	return re.ReplaceAllStringFunc(diags, func(m string) string {
+		sub := re.FindStringSubmatch(m)
+		line, _ := strconv.Atoi(sub[1])
+		return fmt.Sprintf("%s:%d%s", name, line+firstLine-1, sub[2])
+	})
+}
+
+// formatMarkdown formats every ```gray block of a Markdown document with
+// grayc's formatter, leaving everything else byte-for-byte unchanged.
+// Blocks annotated `nofmt` are skipped, as are `fail=` blocks that do
+// not parse. Diagnostics use Markdown line numbers.
+func formatMarkdown(name string, src []byte, fopts grayc.FmtOpts) fmtFileResult {
+	r := fmtFileResult{Path: name, orig: src}
+	doc := string(src)
+	var out strings.Builder
+	var diags []string
+	last := 0
+	for _, b := range findMarkdownBlocks(doc) {
+		if !b.isGrayBlock() || strings.TrimSpace(b.Code) == "" {
+			continue
+		}
+		if _, skip := b.Attrs["nofmt"]; skip {
+			continue
+		}
+		res := formatOne(name, []byte(b.Code), fopts, nil)
+		switch res.Status {
+		case fmtError:
+			if _, expectFail := b.Attrs["fail"]; expectFail {
+				continue
+			}
+			msg := shiftDiagnostics(res.diags, name, b.FirstLine())
+			if msg == "" {
+				msg = fmt.Sprintf("%s:%d: %s\n", name, b.Line, res.Error)
+			}
+			diags = append(diags, msg)
+		case fmtChanged:
+			out.WriteString(doc[last:b.start])
+			out.WriteString(indentBlock(string(res.formatted), b.indent))
+			last = b.end
+		}
+	}
+	out.WriteString(doc[last:])
+
+	switch {
+	case len(diags) > 0:
+		r.diags = strings.Join(diags, "")
+		r.Status, r.Error = fmtError, firstLine(r.diags)
+	case out.String() == doc:
+		r.Status = fmtUnchanged
+	default:
+		r.Status, r.formatted = fmtChanged, []byte(out.String())
+	}
+	return r
+}
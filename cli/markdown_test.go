@@ -0,0 +1,147 @@
+// markdown_test.go — Tests for Markdown code block extraction: fence
+// recognition, info-string annotations, list-item indentation, and
+// remapping of diagnostics to Markdown line numbers.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+func TestFindMarkdownBlocks(t *testing.T) {
+	doc := "# Title\n" +
+		"\n" +
+		"```gray\n" +
+		"do main() {}\n" +
+		"```\n" +
+		"text\n" +
+		"~~~~ gray,fail=E2001\n" +
+		"```\n" +
+		"x := 1\n" +
+		"~~~~\n" +
+		"```sh\n" +
+		"gray run\n" +
+		"```\n"
+	blocks := findMarkdownBlocks(doc)
+	if len(blocks) != 3 {
+		t.Fatalf("got %d blocks, want 3", len(blocks))
+	}
+	if b := blocks[0]; b.Lang != "gray" || b.Line != 3 || b.Code != "do main() {}\n" {
+		t.Errorf("block 0 = %+v", b)
+	}
+	// A shorter or different fence does not close a tilde block.
+	if b := blocks[1]; b.Lang != "gray" || b.Attrs["fail"] != "E2001" || b.Code != "```\nx := 1\n" {
+		t.Errorf("block 1 = %+v", b)
+	}
+	if b := blocks[2]; b.Lang != "sh" || b.isGrayBlock() {
+		t.Errorf("block 2 = %+v", b)
+	}
+	if got := doc[blocks[0].start:blocks[0].end]; got != "do main() {}\n" {
+		t.Errorf("block 0 range = %q", got)
+	}
+}
+
+func TestFindMarkdownBlocksUnclosed(t *testing.T) {
+	blocks := findMarkdownBlocks("```gray\nx := 1\n")
+	if len(blocks) != 1 || blocks[0].Code != "x := 1\n" {
+		t.Fatalf("unclosed block = %+v", blocks)
+	}
+}
+
+func TestFindMarkdownBlocksIndented(t *testing.T) {
+	doc := "- item\n\n  ```gray\n  if x {\n      y()\n  }\n\n  ```\n"
+	blocks := findMarkdownBlocks(doc)
+	if len(blocks) != 1 {
+		t.Fatalf("got %d blocks, want 1", len(blocks))
+	}
+	want := "if x {\n    y()\n}\n\n"
+	if blocks[0].Code != want {
+		t.Errorf("dedented code = %q, want %q", blocks[0].Code, want)
+	}
+	if got := indentBlock(want, blocks[0].indent); got != doc[blocks[0].start:blocks[0].end] {
+		t.Errorf("indentBlock = %q", got)
+	}
+}
+
+func TestParseFenceLine(t *testing.T) {
+	tests := []struct {
+		line string
+		ok   bool
+		info string
+	}{
+		{"```gray", true, "gray"},
+		{"   ```", true, ""},
+		{"    ```gray", false, ""},
+		{"``", false, ""},
+		{"```gray `x`", false, ""},
+		{"~~~ gray, run", true, "gray, run"},
+	}
+	for _, tt := range tests {
+		_, _, info, ok := parseFenceLine(tt.line)
+		if ok != tt.ok || info != tt.info {
+			t.Errorf("parseFenceLine(%q) = %q, %v; want %q, %v", tt.line, info, ok, tt.info, tt.ok)
+		}
+	}
+}
+
+func TestParseInfoString(t *testing.T) {
+	lang, attrs := parseInfoString("Gray,run,output=hello world")
+	if lang != "gray" {
+		t.Errorf("lang = %q", lang)
+	}
+	if _, ok := attrs["run"]; !ok {
+		t.Errorf("missing run attr: %v", attrs)
+	}
+	if attrs["output"] != "hello" {
+		t.Errorf("output = %q (values end at whitespace)", attrs["output"])
+	}
+
+	_, attrs = parseInfoString(`gray, run, output="a, b\nc"`)
+	if attrs["output"] != "a, b\nc" {
+		t.Errorf("quoted output = %q", attrs["output"])
+	}
+	if _, attrs = parseInfoString("gray,fail=E2001"); attrs["fail"] != "E2001" {
+		t.Errorf("fail = %q", attrs["fail"])
+	}
+}
+
+func TestShiftDiagnostics(t *testing.T) {
+	in := "docs/a.md:2:5: error[E1017]: unclosed raw string literal\n"
+	want := "docs/a.md:11:5: error[E1017]: unclosed raw string literal\n"
+	if got := shiftDiagnostics(in, "docs/a.md", 10); got != want {
+		t.Errorf("shiftDiagnostics = %q, want %q", got, want)
+	}
+	in = "docs/a.md:3: error: unclosed bracket opened here\n"
+	want = "docs/a.md:12: error: unclosed bracket opened here\n"
+	if got := shiftDiagnostics(in, "docs/a.md", 10); got != want {
+		t.Errorf("shiftDiagnostics without a column = %q, want %q", got, want)
+	}
+}
+
+func TestFormatMarkdownBracketError(t *testing.T) {
+	if _, err := grayc.Find(); err != nil {
+		t.Skip("grayc not available")
+	}
+	doc := "# Title\n\nText.\n\n```gray\ndo main() {\n    if true {\n        println(1)\n}\n```\n"
+	r := formatMarkdown("doc.md", []byte(doc), grayc.FmtOpts{})
+	// The brace opened on Markdown line 6 is never closed.
+	if !strings.Contains(r.diags+r.Error, "doc.md:6:11: error: unclosed '{'") {
+		t.Errorf("bracket error not on the Markdown line: %+v", r)
+	}
+}
+
+func TestFmtConfigPathMarkdown(t *testing.T) {
+	if got := fmtConfigPath("/p/README.md"); got != "/p/README.gray" {
+		t.Errorf("fmtConfigPath = %q", got)
+	}
+	if got := fmtConfigPath("/p/a.gray"); got != "/p/a.gray" {
+		t.Errorf("fmtConfigPath = %q", got)
+	}
+}
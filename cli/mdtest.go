@@ -0,0 +1,391 @@
+// mdtest.go — Compile-checks the ```gray code samples in Markdown files
+// ("gray mdtest"). Each block is type-checked with grayc check; snippets
+// without `do main()` are wrapped in one first. Info-string annotations
+// assert expected failures (```gray,fail=E2001) or program output
+// (```gray,run,output="hi"), and diagnostics point at Markdown lines.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+	"github.com/spf13/cobra"
+)
+
+var mdtestCmd = &cobra.Command{
+	Use:   "mdtest [path...]",
+	Short: "Compile-check the gray code blocks in Markdown files",
+	Long: `Extract every ` + "```gray" + ` fenced block from Markdown files and type-check it
+with grayc. Snippets without a ` + "`do main()`" + ` are wrapped in one: imports and
+top-level declarations stay at the top and the remaining statements become
+the body of main.
+
+Annotations after the language in the info string (comma separated):
+  ignore          Skip the block
+  fail            The block must fail to compile
+  fail=E2001      The block must fail with error E2001
+  run             Compile and run the block; it must exit 0
+  output=TEXT     Run the block and compare its stdout with TEXT (implies run).
+                  Quote TEXT to use spaces, commas, or escapes: output="a\nb"
+
+Examples:
+  gray mdtest STANDARD.md          Check every sample in STANDARD.md
+  gray mdtest ./...                Check all Markdown files recursively
+  gray mdtest -v docs              List passing samples too`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jobs, _ := cmd.Flags().GetInt("jobs")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		if len(args) == 0 {
+			args = []string{"."}
+		}
+		if code := runMdTest(args, jobs, verbose); code != 0 {
+			return &ExitError{code}
+		}
+		return nil
+	},
+}
+
+// mdTestSpec is what an info string asks of a snippet.
+type mdTestSpec struct {
+	Skip      bool
+	Fail      bool   // compilation must fail
+	Code      string // expected error code when Fail is set; "" for any
+	Run       bool   // compile and execute
+	Output    string // expected stdout when HasOutput is set
+	HasOutput bool
+}
+
+var errorCodeRe = regexp.MustCompile(`^E\d{4}$`)
+
+// parseMdTestSpec interprets a block's annotations.
+func parseMdTestSpec(attrs map[string]string) (mdTestSpec, error) {
+	var s mdTestSpec
+	if _, ok := attrs["ignore"]; ok {
+		s.Skip = true
+		return s, nil
+	}
+	if code, ok := attrs["fail"]; ok {
+		if code != "" && !errorCodeRe.MatchString(code) {
+			return s, fmt.Errorf("invalid annotation fail=%s (expected an error code such as E2001)", code)
+		}
+		s.Fail, s.Code = true, code
+	}
+	_, s.Run = attrs["run"]
+	if out, ok := attrs["output"]; ok {
+		s.Run, s.Output, s.HasOutput = true, out, true
+	}
+	if s.Fail && s.Run {
+		return s, fmt.Errorf("fail cannot be combined with run or output")
+	}
+	return s, nil
+}
+
+// Markdown sample test outcomes.
+const (
+	mdTestPass = "pass"
+	mdTestFail = "fail"
+	mdTestSkip = "skip"
+)
+
+// mdTestCase is one ```gray block to test.
+type mdTestCase struct {
+	Path  string
+	Block mdCodeBlock
+}
+
+// mdTestResult is the outcome of testing one block.
+type mdTestResult struct {
+	Status  string
+	Message string // why the block failed
+	Output  string // compiler or program output, with Markdown positions
+}
+
+// runMdTest tests every ```gray block in the Markdown files named by
+// args and returns the exit code (1 when any block fails).
+func runMdTest(args []string, jobs int, verbose bool) int {
+	files := collectSourceFiles("gray mdtest", args, ".md")
+	var cases []mdTestCase
+	for _, f := range files {
+		src, err := os.ReadFile(f)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gray mdtest: %v\n", err)
+			return 1
+		}
+		for _, b := range findMarkdownBlocks(string(src)) {
+			if b.isGrayBlock() && strings.TrimSpace(b.Code) != "" {
+				cases = append(cases, mdTestCase{Path: relPath(f), Block: b})
+			}
+		}
+	}
+	if len(cases) == 0 {
+		fmt.Println("gray mdtest: no gray code blocks found")
+		return 0
+	}
+
+	results := parallelMap(len(cases), jobs, func(i int) mdTestResult {
+		return testMdBlock(cases[i])
+	})
+
+	passed, failed, skipped := 0, 0, 0
+	for i, r := range results {
+		loc := fmt.Sprintf("%s:%d", cases[i].Path, cases[i].Block.Line)
+		switch r.Status {
+		case mdTestPass:
+			passed++
+			if verbose {
+				fmt.Printf("ok    %s\n", loc)
+			}
+		case mdTestSkip:
+			skipped++
+			if verbose {
+				fmt.Printf("skip  %s\n", loc)
+			}
+		default:
+			failed++
+			fmt.Printf("FAIL  %s: %s\n", loc, r.Message)
+			if out := strings.TrimRight(r.Output, "\n"); out != "" {
+				for _, line := range strings.Split(out, "\n") {
+					fmt.Printf("    %s\n", line)
+				}
+			}
+		}
+	}
+	fmt.Printf("gray mdtest: %d passed, %d failed, %d skipped\n", passed, failed, skipped)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// relPath shortens path relative to the working directory when possible.
+func relPath(path string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
+
+// testMdBlock checks (and for run blocks executes) one snippet.
+func testMdBlock(tc mdTestCase) mdTestResult {
+	spec, err := parseMdTestSpec(tc.Block.Attrs)
+	if err != nil {
+		return mdTestResult{Status: mdTestFail, Message: err.Error()}
+	}
+	if spec.Skip {
+		return mdTestResult{Status: mdTestSkip}
+	}
+
+	src, lineMap := wrapSnippet(tc.Block.Code)
+	dir, err := os.MkdirTemp("", "gray-mdtest-*")
+	if err != nil {
+		return mdTestResult{Status: mdTestFail, Message: err.Error()}
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, fmt.Sprintf("snippet_%d.gray", tc.Block.Line))
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		return mdTestResult{Status: mdTestFail, Message: err.Error()}
+	}
+	remap := func(out []byte) string {
+		return remapSnippetOutput(string(out), file, tc.Path, tc.Block, lineMap)
+	}
+
+	if spec.Run {
+		stdout, stderr, code, err := grayc.RunOutput(file, []string{"--no-color", "--quiet"})
+		switch {
+		case err != nil:
+			return mdTestResult{Status: mdTestFail, Message: err.Error()}
+		case code != 0:
+			return mdTestResult{Status: mdTestFail, Message: fmt.Sprintf("exited with status %d", code), Output: remap(append(stderr, stdout...))}
+		case spec.HasOutput && strings.TrimRight(string(stdout), "\n") != strings.TrimRight(spec.Output, "\n"):
+			return mdTestResult{Status: mdTestFail, Message: fmt.Sprintf("output mismatch: want %q, got %q", spec.Output, string(stdout))}
+		}
+		return mdTestResult{Status: mdTestPass}
+	}
+
+	out, code, err := grayc.CheckOutput(file, []string{"--no-color", "--quiet"})
+	if err != nil {
+		return mdTestResult{Status: mdTestFail, Message: err.Error()}
+	}
+	switch {
+	case spec.Fail && code == 0:
+		want := "a compile error"
+		if spec.Code != "" {
+			want = "error " + spec.Code
+		}
+		return mdTestResult{Status: mdTestFail, Message: "expected " + want + ", but the snippet compiled"}
+	case spec.Fail && spec.Code != "" && !strings.Contains(string(out), "error["+spec.Code+"]"):
+		return mdTestResult{Status: mdTestFail, Message: "expected error " + spec.Code + ", got other errors", Output: remap(out)}
+	case !spec.Fail && code != 0:
+		return mdTestResult{Status: mdTestFail, Message: "does not compile", Output: remap(out)}
+	}
+	return mdTestResult{Status: mdTestPass}
+}
+
+// wrapSnippet returns a complete program for a code sample, and for each
+// line of the program the 0-based snippet line it came from (-1 for
+// generated lines). Samples that already define main are used as-is;
+// otherwise imports and declarations are kept at the top level and every
+// other statement moves into a generated `do main()`.
+func wrapSnippet(code string) (string, []int) {
+	lines := strings.Split(strings.TrimRight(code, "\n"), "\n")
+	toks := lexGraySource(code)
+
+	identity := func() (string, []int) {
+		m := make([]int, len(lines))
+		for i := range m {
+			m[i] = i
+		}
+		return strings.Join(lines, "\n") + "\n", m
+	}
+	for i := 0; i+1 < len(toks); i++ {
+		if toks[i].isKeyword("do") && toks[i+1].Kind == tokIdent && toks[i+1].Text == "main" {
+			return identity()
+		}
+	}
+
+	// Find the token that starts each line and the bracket depth there.
+	first := make([]int, len(lines)+1) // 1-based line -> token index, -1 if none
+	atDepth := make([]int, len(lines)+1)
+	for l := range first {
+		first[l] = -1
+	}
+	depth := 0
+	for i, t := range toks {
+		if t.Kind == tokEOF || t.Kind == tokNewline {
+			continue
+		}
+		if t.Line <= len(lines) && first[t.Line] < 0 {
+			first[t.Line], atDepth[t.Line] = i, depth
+		}
+		switch {
+		case t.isPunct("{") || t.isPunct("(") || t.isPunct("["):
+			depth++
+		case t.isPunct("}") || t.isPunct(")") || t.isPunct("]"):
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+
+	// An item starts at a line whose first token is at depth 0; every
+	// other line belongs to the item above it. Attributes (#doc, #json,
+	// ...) belong to the item they precede.
+	topLevel := make([]bool, len(lines))
+	current := false
+	attrFrom := -1
+	for l := 1; l <= len(lines); l++ {
+		if i := first[l]; i >= 0 && atDepth[l] == 0 {
+			if toks[i].Kind == tokAttr {
+				if attrFrom < 0 {
+					attrFrom = l
+				}
+				continue
+			}
+			current = isTopLevelDecl(toks, i)
+			if attrFrom >= 0 {
+				for a := attrFrom; a < l; a++ {
+					topLevel[a-1] = current
+				}
+				attrFrom = -1
+			}
+		}
+		if attrFrom < 0 {
+			topLevel[l-1] = current
+		}
+	}
+
+	var out []string
+	var lineMap []int
+	for i, l := range lines {
+		if topLevel[i] {
+			out = append(out, l)
+			lineMap = append(lineMap, i)
+		}
+	}
+	out = append(out, "do main() {")
+	lineMap = append(lineMap, -1)
+	for i, l := range lines {
+		if !topLevel[i] {
+			out = append(out, l)
+			lineMap = append(lineMap, i)
+		}
+	}
+	out = append(out, "}")
+	lineMap = append(lineMap, -1)
+	return strings.Join(out, "\n") + "\n", lineMap
+}
+
+// isTopLevelDecl reports whether the item starting at toks[i] must stay
+// outside a function body.
+func isTopLevelDecl(toks []grayToken, i int) bool {
+	t := toks[i]
+	if t.isKeyword("private") && i+1 < len(toks) {
+		t = toks[i+1]
+	}
+	for _, kw := range []string{"import", "do", "const", "module", "using", "struct", "enum"} {
+		if t.isKeyword(kw) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// gutterRe matches the line-number gutter of a grayc source excerpt.
+	gutterRe = regexp.MustCompile(`^(\s*)(\d+)( \|.*)$`)
+	// excerptRe matches the other lines of an excerpt: its bare gutter
+	// and help lines.
+	excerptRe = regexp.MustCompile(`^\s*[|=]`)
+	// excerptFileRe matches the "--> file:line:col" line above an excerpt.
+	excerptFileRe = regexp.MustCompile(`^\s*--> (.+?):(\d+)(?::\d+)?\s*$`)
+)
+
+// remapSnippetOutput rewrites positions in grayc output for a wrapped
+// snippet so they name the Markdown file and line. Only excerpts under a
+// location in the snippet file are renumbered; excerpts of other files
+// (such as a module the snippet imports) and the C compiler's output keep
+// their own line numbers.
+func remapSnippetOutput(out, file, mdPath string, b mdCodeBlock, lineMap []int) string {
+	mdLine := func(genLine int) int {
+		if genLine < 1 || genLine > len(lineMap) || lineMap[genLine-1] < 0 {
+			return b.Line
+		}
+		return b.FirstLine() + lineMap[genLine-1]
+	}
+	lines := strings.Split(out, "\n")
+	inSnippet := false
+	for i, line := range lines {
+		if loc := excerptFileRe.FindStringSubmatch(line); loc != nil {
+			inSnippet = loc[1] == file
+			continue
+		}
+		sub := gutterRe.FindStringSubmatch(line)
+		switch {
+		case sub != nil && inSnippet:
+			n, _ := strconv.Atoi(sub[2])
+			lines[i] = fmt.Sprintf("%s%d%s", sub[1], mdLine(n), sub[3])
+		case sub == nil && !excerptRe.MatchString(line):
+			inSnippet = false
+		}
+	}
+	out = strings.Join(lines, "\n")
+	posRe := regexp.MustCompile(regexp.QuoteMeta(file) + `:(\d+)(:\d+)?`)
+	return posRe.ReplaceAllStringFunc(out, func(m string) string {
+		sub := posRe.FindStringSubmatch(m)
+		n, _ := strconv.Atoi(sub[1])
+		return fmt.Sprintf("%s:%d%s", mdPath, mdLine(n), sub[2])
+	})
+}
@@ -0,0 +1,119 @@
+// mdtest_test.go — Tests for Markdown sample testing: annotation parsing,
+// wrapping snippets in `do main()`, and mapping compiler positions back
+// to Markdown lines.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMdTestSpec(t *testing.T) {
+	tests := []struct {
+		info    string
+		want    mdTestSpec
+		wantErr bool
+	}{
+		{"gray", mdTestSpec{}, false},
+		{"gray,ignore", mdTestSpec{Skip: true}, false},
+		{"gray,fail", mdTestSpec{Fail: true}, false},
+		{"gray,fail=E2001", mdTestSpec{Fail: true, Code: "E2001"}, false},
+		{"gray,run", mdTestSpec{Run: true}, false},
+		{`gray,run,output="hi, there"`, mdTestSpec{Run: true, Output: "hi, there", HasOutput: true}, false},
+		{"gray,output=42", mdTestSpec{Run: true, Output: "42", HasOutput: true}, false},
+		{"gray,fail=oops", mdTestSpec{}, true},
+		{"gray,fail,run", mdTestSpec{}, true},
+	}
+	for _, tt := range tests {
+		_, attrs := parseInfoString(tt.info)
+		got, err := parseMdTestSpec(attrs)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseMdTestSpec(%q) error = %v, wantErr %v", tt.info, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseMdTestSpec(%q) = %+v, want %+v", tt.info, got, tt.want)
+		}
+	}
+}
+
+func TestWrapSnippetWithMain(t *testing.T) {
+	code := "import @strings\n\ndo main() {\n    println(\"hi\")\n}\n"
+	src, lineMap := wrapSnippet(code)
+	if src != code {
+		t.Errorf("snippet with main was rewritten:\n%s", src)
+	}
+	if !reflect.DeepEqual(lineMap, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("lineMap = %v", lineMap)
+	}
+}
+
+func TestWrapSnippetStatements(t *testing.T) {
+	code := strings.Join([]string{
+		"import @strings",  // 0
+		"mut x int = 1",    // 1
+		"#doc(\"helper\")", // 2
+		"do helper(a int) -> int {",
+		"    return a",
+		"}",
+		"const Point struct {", // 6
+		"    x int",
+		"}",
+		"println(helper(x))", // 9
+		"if x > 0 {",
+		"    x = 2",
+		"}",
+	}, "\n") + "\n"
+	src, lineMap := wrapSnippet(code)
+	want := strings.Join([]string{
+		"import @strings",
+		"#doc(\"helper\")",
+		"do helper(a int) -> int {",
+		"    return a",
+		"}",
+		"const Point struct {",
+		"    x int",
+		"}",
+		"do main() {",
+		"mut x int = 1",
+		"println(helper(x))",
+		"if x > 0 {",
+		"    x = 2",
+		"}",
+		"}",
+	}, "\n") + "\n"
+	if src != want {
+		t.Errorf("wrapped source:\n%s\nwant:\n%s", src, want)
+	}
+	wantMap := []int{0, 2, 3, 4, 5, 6, 7, 8, -1, 1, 9, 10, 11, 12, -1}
+	if !reflect.DeepEqual(lineMap, wantMap) {
+		t.Errorf("lineMap = %v, want %v", lineMap, wantMap)
+	}
+}
+
+func TestRemapSnippetOutput(t *testing.T) {
+	b := mdCodeBlock{Line: 40}
+	lineMap := []int{0, -1, 1}
+	out := "error[E3001]: type mismatch\n  --> /tmp/x/snippet_40.gray:3:5\n   |\n  3 |     mut x int = \"s\"\n   |     ^\n" +
+		"error[E2001]: bad\n  --> /tmp/x/snippet_40.gray:2:1\n"
+	got := remapSnippetOutput(out, "/tmp/x/snippet_40.gray", "doc.md", b, lineMap)
+	for _, want := range []string{"--> doc.md:42:5", "  42 |     mut x", "--> doc.md:40:1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("remapped output missing %q:\n%s", want, got)
+		}
+	}
+
+	// Excerpts of an imported file, and other tools' gutters, keep their
+	// line numbers.
+	out = "error[E3001]: type mismatch\n  --> /tmp/x/shapes.gray:3:12\n   |\n  3 |     return \"s\"\n   |            ^\n\n" +
+		"cc: error\n    3 | int x = ;\n"
+	if got := remapSnippetOutput(out, "/tmp/x/snippet_40.gray", "doc.md", b, lineMap); got != out {
+		t.Errorf("remapped an excerpt outside the snippet:\n%s", got)
+	}
+}
@@ -0,0 +1,56 @@
+// parallel.go — Bounded worker pool shared by the multi-file commands
+// (gray fmt, gray check). Work items are processed concurrently but
+// results are stored by index so output order never depends on timing.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"runtime"
+	"sync"
+)
+
+// defaultJobs is the worker count used when -j is not given.
+func defaultJobs() int {
+	return runtime.NumCPU()
+}
+
+// parallelMap calls fn for every index in [0, n) using at most jobs
+// goroutines and returns the results in index order. jobs <= 0 means
+// defaultJobs().
+func parallelMap[T any](n, jobs int, fn func(i int) T) []T {
+	out := make([]T, n)
+	if jobs <= 0 {
+		jobs = defaultJobs()
+	}
+	if jobs > n {
+		jobs = n
+	}
+	if jobs <= 1 {
+		for i := 0; i < n; i++ {
+			out[i] = fn(i)
+		}
+		return out
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				out[i] = fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return out
+}
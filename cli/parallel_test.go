@@ -0,0 +1,56 @@
+// parallel_test.go — Tests for the bounded worker pool verifying result
+// ordering, the concurrency limit, and the serial fallback.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMapPreservesOrder(t *testing.T) {
+	got := parallelMap(50, 8, func(i int) int {
+		// Finish later items first to shake out ordering bugs.
+		time.Sleep(time.Duration(50-i) * 50 * time.Microsecond)
+		return i * i
+	})
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("result[%d] = %d, want %d", i, v, i*i)
+		}
+	}
+}
+
+func TestParallelMapRespectsJobLimit(t *testing.T) {
+	var running, peak int32
+	parallelMap(40, 3, func(i int) struct{} {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(200 * time.Microsecond)
+		atomic.AddInt32(&running, -1)
+		return struct{}{}
+	})
+	if peak > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", peak)
+	}
+}
+
+func TestParallelMapEmptyAndSerial(t *testing.T) {
+	if got := parallelMap(0, 4, func(i int) int { return i }); len(got) != 0 {
+		t.Errorf("empty input produced %v", got)
+	}
+	got := parallelMap(3, 1, func(i int) int { return i + 1 })
+	if len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Errorf("serial results = %v", got)
+	}
+}
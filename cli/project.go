@@ -0,0 +1,75 @@
+// project.go — Project layout helpers shared by commands that look past
+// the files they are given: finding the directory a file's project lives
+// in and listing the project's sources.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// manifestDir returns the nearest directory at or above dir holding a
+// gray.toml.
+func manifestDir(dir string) (string, bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, manifestFileName)); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// projectRoot returns the root of the project holding dir: the nearest
+// gray.toml directory, else the top of the git repository, else dir.
+func projectRoot(dir string) string {
+	if root, ok := manifestDir(dir); ok {
+		return root
+	}
+	if root, err := gitRepoRoot(dir); err == nil {
+		return root
+	}
+	return dir
+}
+
+// skippedProjectDirs are directories of vendored code and build output,
+// which are not the project's own sources.
+var skippedProjectDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"build":        true,
+}
+
+// projectSourceFiles lists the files under root with the given
+// extension, skipping hidden entries and skippedProjectDirs.
+func projectSourceFiles(root, ext string) []string {
+	var files []string
+	filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path != root && d.IsDir() && skippedProjectDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ext) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
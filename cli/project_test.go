@@ -0,0 +1,55 @@
+// project_test.go — Tests for the project layout helpers: the manifest
+// and project roots above a directory and the project's source files.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestManifestDirAndProjectRoot(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "src", "lib")
+	writeTestFile(t, filepath.Join(sub, "a.gray"), "")
+
+	if got, ok := manifestDir(sub); ok {
+		t.Errorf("manifestDir without gray.toml = %q", got)
+	}
+	if got := projectRoot(sub); got != sub {
+		t.Errorf("projectRoot without a project = %q", got)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "gray.toml"), "")
+	if got, ok := manifestDir(sub); !ok || got != dir {
+		t.Errorf("manifestDir = %q, %v, want %q", got, ok, dir)
+	}
+	if got := projectRoot(sub); got != dir {
+		t.Errorf("projectRoot = %q, want %q", got, dir)
+	}
+}
+
+func TestProjectRootFallsBackToGit(t *testing.T) {
+	dir := newTestGitRepo(t, map[string]string{"src/a.gray": ""})
+	got := realPath(projectRoot(filepath.Join(dir, "src")))
+	if want := realPath(dir); got != want {
+		t.Errorf("projectRoot = %q, want %q", got, want)
+	}
+}
+
+func TestProjectSourceFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.gray", "lib/a.gray", "lib/a.txt", ".hidden.gray", ".cache/b.gray", "vendor/c.gray", "build/d.gray", "node_modules/e.gray", "src/build/f.gray"} {
+		writeTestFile(t, filepath.Join(dir, name), "")
+	}
+	got := projectSourceFiles(dir, ".gray")
+	want := []string{filepath.Join(dir, "lib", "a.gray"), filepath.Join(dir, "main.gray")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("projectSourceFiles = %v, want %v", got, want)
+	}
+}
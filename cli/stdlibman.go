@@ -0,0 +1,222 @@
+// stdlibman.go — Reads gray man's stdlib documentation from the @man
+// blocks of the stdlib headers embedded in this binary, so the docs always
+// match the compiler that ships with it. Dev builds without staged
+// runtime assets keep the tables generated into stdlib_man_data.go.
+//
+// A block documents one item:
+//
+//	/*@man split
+//	 *@module strings
+//	 *@group Split/Join
+//	 *@kind func
+//	 *@sig split(s string, sep string) -> [string]
+//	 *@field name type
+//	 *@desc Splits s around each occurrence of sep.
+//	 *@example
+//	 *   println(strings.split("a,b", ","))
+//	 *@end
+//	 */
+//
+// @kind defaults to func; @field may repeat (for types); lines after @desc
+// continue it. Blocks in the older @function/@brief/@param/@returns style
+// are read too, with the signature built from the tags. Blocks without a
+// name or @module are skipped.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/grayscale-lang/grayscale/internal/grayc"
+)
+
+// manBlock is one parsed @man block.
+type manBlock struct {
+	Name    string
+	Module  string
+	Group   string
+	Kind    string
+	Sig     string
+	Fields  []string // "name type"
+	Desc    string
+	Example string
+	Line    int // line of the opening /*@man
+}
+
+// manBlockLine strips a block line's leading " *" and reports the tag it
+// starts with, if any.
+func manBlockLine(line string) (tag, rest string, body string) {
+	body = strings.TrimPrefix(strings.TrimLeft(line, " \t"), "*")
+	trimmed := strings.TrimSpace(body)
+	if !strings.HasPrefix(trimmed, "@") {
+		return "", "", body
+	}
+	tag, rest, _ = strings.Cut(trimmed[1:], " ")
+	return tag, strings.TrimSpace(rest), body
+}
+
+// parseManBlocks extracts the @man blocks of a C header.
+func parseManBlocks(src string) []manBlock {
+	var blocks []manBlock
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		head := strings.TrimSpace(lines[i])
+		if head != "/*@man" && !strings.HasPrefix(head, "/*@man ") {
+			continue
+		}
+		b := manBlock{Name: strings.TrimSpace(strings.TrimPrefix(head, "/*@man")), Kind: "func", Line: i + 1}
+		var example, params []string
+		var returns string
+		inExample, inDesc := false, false
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if strings.Contains(line, "*/") {
+				break
+			}
+			tag, rest, body := manBlockLine(line)
+			if inExample {
+				if tag == "end" {
+					inExample = false
+					continue
+				}
+				// Example lines are indented three spaces past the " *".
+				for n := 0; n < 3 && strings.HasPrefix(body, " "); n++ {
+					body = body[1:]
+				}
+				example = append(example, strings.TrimRight(body, " \t"))
+				continue
+			}
+			if tag == "" {
+				if text := strings.TrimSpace(body); inDesc && text != "" {
+					b.Desc += " " + text
+				}
+				continue
+			}
+			inDesc = false
+			switch tag {
+			case "module":
+				b.Module = rest
+			case "group":
+				b.Group = rest
+			case "kind":
+				b.Kind = rest
+			case "sig":
+				b.Sig = rest
+			case "field":
+				b.Fields = append(b.Fields, rest)
+			case "desc", "brief":
+				b.Desc, inDesc = rest, true
+			case "function":
+				if b.Name == "" {
+					b.Name = rest
+				}
+			case "param":
+				params = append(params, manParamSig(rest))
+			case "returns":
+				returns = manParamSig(rest)
+			case "example":
+				inExample = true
+			}
+		}
+		for len(example) > 0 && example[len(example)-1] == "" {
+			example = example[:len(example)-1]
+		}
+		b.Example = strings.Join(example, "\n")
+		if b.Sig == "" && (params != nil || returns != "") {
+			b.Sig = b.Name + "(" + strings.Join(params, ", ") + ")"
+			if returns != "" {
+				b.Sig += " -> " + returns
+			}
+		}
+		if b.Name != "" && b.Module != "" {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks
+}
+
+// manParamSig drops the text from an older-style "@param name Type - text"
+// or "@returns Type - text", leaving the part that goes in a signature.
+func manParamSig(param string) string {
+	decl, _, _ := strings.Cut(param, " - ")
+	return strings.TrimSpace(decl)
+}
+
+// stdlibManTables builds gray man's stdlib tables from @man blocks:
+// entries by "module.name", each module's names in order, and each
+// module's groups in order of first appearance. Later blocks for the same
+// name replace earlier ones.
+func stdlibManTables(blocks []manBlock) (map[string]StdlibManEntry, map[string][]string, map[string][]stdlibGroup) {
+	docs := map[string]StdlibManEntry{}
+	modules := map[string][]string{}
+	groups := map[string][]stdlibGroup{}
+	for _, b := range blocks {
+		key := b.Module + "." + b.Name
+		if _, dup := docs[key]; !dup {
+			modules[b.Module] = append(modules[b.Module], b.Name)
+			label := b.Group
+			if label == "" {
+				label = "Other"
+			}
+			gs := groups[b.Module]
+			i := 0
+			for i < len(gs) && strings.TrimSpace(gs[i].Label) != label {
+				i++
+			}
+			if i == len(gs) {
+				gs = append(gs, stdlibGroup{Label: label})
+			}
+			gs[i].Names = append(gs[i].Names, b.Name)
+			groups[b.Module] = gs
+		}
+		docs[key] = StdlibManEntry{
+			Module:  b.Module,
+			Group:   b.Group,
+			Kind:    b.Kind,
+			Sig:     b.Sig,
+			Fields:  strings.Join(b.Fields, "\n"),
+			Desc:    b.Desc,
+			Example: b.Example,
+		}
+	}
+	// Labels are padded so module indexes line up, as in the generated
+	// tables.
+	for _, gs := range groups {
+		for i := range gs {
+			gs[i].Label = padRight(gs[i].Label, 14)
+		}
+	}
+	return docs, modules, groups
+}
+
+func padRight(s string, width int) string {
+	if n := len([]rune(s)); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// loadEmbeddedStdlibMan replaces the generated stdlib tables with the
+// ones parsed from the embedded headers, when this build has them.
+func loadEmbeddedStdlibMan() {
+	headers, err := grayc.StdlibHeaders()
+	if err != nil {
+		return
+	}
+	var blocks []manBlock
+	for _, h := range headers {
+		blocks = append(blocks, parseManBlocks(string(h.Data))...)
+	}
+	if len(blocks) == 0 {
+		return
+	}
+	stdlibManDocs, stdlibModules, stdlibModuleGroups = stdlibManTables(blocks)
+}
+
+func init() {
+	loadEmbeddedStdlibMan()
+}
@@ -0,0 +1,129 @@
+// stdlibman_test.go — Tests for reading @man blocks from C headers and
+// building gray man's stdlib tables from them.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const manHeaderSource = `#ifndef STRINGS_H
+#define STRINGS_H
+
+/*@man split
+ *@module strings
+ *@group Split/Join
+ *@sig split(s string, sep string) -> [string]
+ *@desc Splits s around each occurrence of sep
+ *      and returns a string array.
+ *@example
+ *   import @strings
+ *   for p in strings.split("a,b", ",") {
+ *       println(p)
+ *   }
+ *@end
+ */
+GrayArray gray_strings_split(GrayString s, GrayString sep);
+
+/*@man Match
+ *@module strings
+ *@kind type
+ *@field start int
+ *@field end int
+ *@desc A match.
+ */
+
+/* not documentation */
+
+/*@man
+ *@module channels
+ *@function try_send
+ *@brief Attempt to send without blocking.
+ *@param ch Channel - The channel to send to.
+ *@param value int - The value to send.
+ *@returns bool - true if the value was sent.
+ *@end
+ */
+
+/*@man orphan
+ *@desc No module, so skipped.
+ */
+#endif
+`
+
+func TestParseManBlocks(t *testing.T) {
+	want := []manBlock{
+		{
+			Name: "split", Module: "strings", Group: "Split/Join", Kind: "func",
+			Sig:     "split(s string, sep string) -> [string]",
+			Desc:    "Splits s around each occurrence of sep and returns a string array.",
+			Example: "import @strings\nfor p in strings.split(\"a,b\", \",\") {\n    println(p)\n}",
+			Line:    4,
+		},
+		{Name: "Match", Module: "strings", Kind: "type", Fields: []string{"start int", "end int"}, Desc: "A match.", Line: 19},
+		{
+			Name: "try_send", Module: "channels", Kind: "func",
+			Sig:  "try_send(ch Channel, value int) -> bool",
+			Desc: "Attempt to send without blocking.", Line: 29,
+		},
+	}
+	if got := parseManBlocks(manHeaderSource); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseManBlocks =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestStdlibManTables(t *testing.T) {
+	docs, modules, groups := stdlibManTables(parseManBlocks(manHeaderSource))
+	if e := docs["strings.Match"]; e.Kind != "type" || e.Fields != "start int\nend int" || e.Group != "" {
+		t.Errorf("strings.Match = %+v", e)
+	}
+	if !reflect.DeepEqual(modules["strings"], []string{"split", "Match"}) {
+		t.Errorf("modules = %v", modules)
+	}
+	wantGroups := []stdlibGroup{{Label: "Split/Join    ", Names: []string{"split"}}, {Label: "Other         ", Names: []string{"Match"}}}
+	if !reflect.DeepEqual(groups["strings"], wantGroups) {
+		t.Errorf("groups = %q", groups["strings"])
+	}
+}
+
+// The generated tables are the fallback for dev builds; the parser must
+// read the same headers the same way, apart from examples, which the
+// generator drops.
+func TestStdlibManTablesMatchGenerated(t *testing.T) {
+	headers, _ := filepath.Glob(filepath.Join("..", "grayc", "src", "stdlib", "*.h"))
+	if len(headers) == 0 {
+		t.Skip("stdlib headers not available")
+	}
+	var blocks []manBlock
+	for _, h := range headers {
+		src, err := os.ReadFile(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, parseManBlocks(string(src))...)
+	}
+	docs, _, groups := stdlibManTables(blocks)
+	for key, want := range stdlibManDocs {
+		got, ok := docs[key]
+		if !ok {
+			t.Errorf("%s missing", key)
+			continue
+		}
+		got.Example, want.Example = "", ""
+		if got != want {
+			t.Errorf("%s =\n%+v\nwant\n%+v", key, got, want)
+		}
+	}
+	for module, want := range stdlibModuleGroups {
+		if got := groups[module]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s groups =\n%q\nwant\n%q", module, got, want)
+		}
+	}
+}
@@ -0,0 +1,431 @@
+// symbols.go — Project-wide symbol index ("gray symbols"). Records where
+// every function, struct, enum, constant, and struct-namespaced function
+// is declared and referenced across imported files, honouring import
+// aliases and using declarations. Backs editor go-to-definition and
+// find-references lookups.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// symbolLocation is a position in a source file. File is absolute.
+type symbolLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// graySymbol is one indexed declaration and every reference to it.
+type graySymbol struct {
+	Name       string           `json:"name"`
+	Kind       string           `json:"kind"` // "function", "struct", "enum", "const", "struct_function"
+	Module     string           `json:"module"`
+	Qualified  string           `json:"qualified"`
+	Private    bool             `json:"private"`
+	Definition symbolLocation   `json:"definition"`
+	References []symbolLocation `json:"references"`
+}
+
+// symbolImport is a local import resolved to the module it names.
+type symbolImport struct {
+	alias   string
+	module  string // module key
+	autoUse bool
+}
+
+// symbolFile holds the per-file state of the index.
+type symbolFile struct {
+	path    string
+	decls   *grayFileDecls
+	module  string // module key: absolute file path, or directory path for directory modules
+	imports []symbolImport
+	aliases map[string]string // alias -> module key
+	usings  []string          // module keys in scope via using / import and use
+	// hits maps a token offset to the symbol named there (declarations
+	// and references), for position lookups.
+	hits map[int]*graySymbol
+}
+
+// symbolIndex is the project-wide index built by buildSymbolIndex.
+type symbolIndex struct {
+	files   map[string]*symbolFile
+	order   []string
+	modules map[string]map[string]*graySymbol // module key -> name or Struct.func -> symbol
+	symbols []*graySymbol
+}
+
+// buildSymbolIndex loads roots plus every local file they import
+// (transitively) and indexes declarations and references.
+func buildSymbolIndex(roots []string) *symbolIndex {
+	idx := &symbolIndex{
+		files:   make(map[string]*symbolFile),
+		modules: make(map[string]map[string]*graySymbol),
+	}
+	if len(roots) == 0 {
+		return idx
+	}
+	dirModule := make(map[string]string)
+
+	queue := append([]string(nil), roots...)
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		if _, ok := idx.files[path]; ok {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gray symbols: %v\n", err)
+			continue
+		}
+		sf := &symbolFile{
+			path:    path,
+			decls:   scanDecls(string(src)),
+			aliases: make(map[string]string),
+			hits:    make(map[int]*graySymbol),
+		}
+		idx.files[path] = sf
+		idx.order = append(idx.order, path)
+
+		for _, imp := range sf.decls.Imports {
+			if imp.Stdlib {
+				continue
+			}
+			key, files := resolveImport(imp.Path, filepath.Dir(path))
+			if key == "" {
+				continue
+			}
+			if key != files[0] {
+				for _, f := range files {
+					dirModule[f] = key
+				}
+			}
+			sf.imports = append(sf.imports, symbolImport{alias: imp.Alias, module: key, autoUse: imp.AutoUse})
+			queue = append(queue, files...)
+		}
+	}
+
+	// Module identity is only known once every import has been seen: a
+	// file reached through a directory import belongs to that directory.
+	for _, path := range idx.order {
+		sf := idx.files[path]
+		sf.module = path
+		if dir, ok := dirModule[path]; ok {
+			sf.module = dir
+		}
+	}
+	for _, path := range idx.order {
+		sf := idx.files[path]
+		for _, imp := range sf.imports {
+			sf.aliases[imp.alias] = imp.module
+			if imp.autoUse {
+				sf.usings = append(sf.usings, imp.module)
+			}
+		}
+		for _, u := range sf.decls.Usings {
+			if key, ok := sf.aliases[u]; ok {
+				sf.usings = append(sf.usings, key)
+			}
+		}
+		idx.declare(sf)
+	}
+	for _, path := range idx.order {
+		sf := idx.files[path]
+		idx.resolveRefs(sf, sf.decls.Tokens)
+	}
+
+	sort.SliceStable(idx.symbols, func(i, j int) bool {
+		a, b := idx.symbols[i].Definition, idx.symbols[j].Definition
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return idx
+}
+
+// resolveImport resolves a local import the way grayc does: against the
+// directory of the file containing the import, whichever file that is, as
+// <path>.gray, <path> when it ends in .gray, or the .gray files directly
+// inside the directory <path>. It returns the module key (the file, or
+// the directory for a directory import) and the files of the module, or
+// "" when the import does not resolve.
+func resolveImport(importPath, fromDir string) (string, []string) {
+	p, err := filepath.Abs(filepath.Join(fromDir, importPath))
+	if err != nil {
+		return "", nil
+	}
+	if strings.HasSuffix(p, ".gray") {
+		if statRegular(p) {
+			return p, []string{p}
+		}
+		return "", nil
+	}
+	if statRegular(p + ".gray") {
+		return p + ".gray", []string{p + ".gray"}
+	}
+	entries, err := os.ReadDir(p)
+	if err != nil {
+		return "", nil
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && !strings.HasPrefix(e.Name(), ".") && strings.HasSuffix(e.Name(), ".gray") {
+			files = append(files, filepath.Join(p, e.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+	return p, files
+}
+
+// statRegular reports whether path exists and is a regular file.
+func statRegular(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// moduleDisplayName turns a module key into the name used in source.
+func moduleDisplayName(key string) string {
+	return strings.TrimSuffix(filepath.Base(key), ".gray")
+}
+
+// declare registers sf's declarations under its module.
+func (idx *symbolIndex) declare(sf *symbolFile) {
+	table := idx.modules[sf.module]
+	if table == nil {
+		table = make(map[string]*graySymbol)
+		idx.modules[sf.module] = table
+	}
+	modName := moduleDisplayName(sf.module)
+	for _, d := range sf.decls.Decls {
+		key := d.Name
+		if d.Parent != "" {
+			key = d.Parent + "." + d.Name
+		}
+		if _, dup := table[key]; dup {
+			continue
+		}
+		sym := &graySymbol{
+			Name:       d.Name,
+			Kind:       d.Kind,
+			Module:     modName,
+			Qualified:  modName + "." + key,
+			Private:    d.Private,
+			Definition: symbolLocation{File: sf.path, Line: d.Line, Column: d.Col},
+			References: []symbolLocation{},
+		}
+		table[key] = sym
+		idx.symbols = append(idx.symbols, sym)
+		sf.hits[d.NameOffset] = sym
+	}
+}
+
+func (idx *symbolIndex) lookup(module, key string) *graySymbol {
+	return idx.modules[module][key]
+}
+
+// resolveRefs records every identifier in toks that names an indexed
+// symbol. Qualified chains (alias.Name, alias.Struct.func, Struct.func)
+// are resolved from their head; members after a '.' are never treated
+// as standalone references.
+func (idx *symbolIndex) resolveRefs(sf *symbolFile, toks []grayToken) {
+	for i, t := range toks {
+		if t.Kind == tokString {
+			idx.resolveRefs(sf, t.Interp)
+			continue
+		}
+		if t.Kind != tokIdent || (i > 0 && toks[i-1].isPunct(".")) {
+			continue
+		}
+		if _, isDecl := sf.hits[t.Offset]; isDecl {
+			continue
+		}
+		chain := []grayToken{t}
+		for j := i; j+2 < len(toks) && toks[j+1].isPunct(".") && toks[j+2].Kind == tokIdent; j += 2 {
+			chain = append(chain, toks[j+2])
+		}
+
+		if mod, ok := sf.aliases[t.Text]; ok {
+			if len(chain) >= 2 {
+				idx.resolveChain(sf, mod, chain[1:])
+			}
+			continue
+		}
+		for _, mod := range append([]string{sf.module}, sf.usings...) {
+			if idx.resolveChain(sf, mod, chain) {
+				break
+			}
+		}
+	}
+}
+
+// resolveChain resolves chain[0] (and chain[1] when chain[0] is a struct)
+// inside module, recording references. Reports whether chain[0] matched.
+func (idx *symbolIndex) resolveChain(sf *symbolFile, module string, chain []grayToken) bool {
+	sym := idx.lookup(module, chain[0].Text)
+	if sym == nil {
+		return false
+	}
+	idx.addRef(sf, sym, chain[0])
+	if sym.Kind == "struct" && len(chain) >= 2 {
+		if fn := idx.lookup(module, sym.Name+"."+chain[1].Text); fn != nil {
+			idx.addRef(sf, fn, chain[1])
+		}
+	}
+	return true
+}
+
+func (idx *symbolIndex) addRef(sf *symbolFile, sym *graySymbol, t grayToken) {
+	sym.References = append(sym.References, symbolLocation{File: sf.path, Line: t.Line, Column: t.Col})
+	sf.hits[t.Offset] = sym
+}
+
+// symbolAt returns the symbol declared or referenced at file:line:col.
+func (idx *symbolIndex) symbolAt(file string, line, col int) *graySymbol {
+	sf := idx.files[file]
+	if sf == nil {
+		return nil
+	}
+	return symbolAtTokens(sf, sf.decls.Tokens, line, col)
+}
+
+func symbolAtTokens(sf *symbolFile, toks []grayToken, line, col int) *graySymbol {
+	for _, t := range toks {
+		if t.Kind == tokString && len(t.Interp) > 0 {
+			if sym := symbolAtTokens(sf, t.Interp, line, col); sym != nil {
+				return sym
+			}
+		}
+		if t.Kind == tokIdent && t.Line == line && col >= t.Col && col < t.Col+len(t.Text) {
+			return sf.hits[t.Offset]
+		}
+	}
+	return nil
+}
+
+// parseSymbolPosition parses an editor position of the form file:line:col.
+func parseSymbolPosition(pos string) (string, int, int, error) {
+	parts := strings.Split(pos, ":")
+	if len(parts) < 3 {
+		return "", 0, 0, fmt.Errorf("invalid position '%s' — expected file:line:column", pos)
+	}
+	n := len(parts)
+	line, err1 := strconv.Atoi(parts[n-2])
+	col, err2 := strconv.Atoi(parts[n-1])
+	if err1 != nil || err2 != nil {
+		return "", 0, 0, fmt.Errorf("invalid position '%s' — expected file:line:column", pos)
+	}
+	file, err := filepath.Abs(strings.Join(parts[:n-2], ":"))
+	if err != nil {
+		return "", 0, 0, err
+	}
+	return file, line, col, nil
+}
+
+func formatLocation(loc symbolLocation) string {
+	return fmt.Sprintf("%s:%d:%d", shortPath(loc.File), loc.Line, loc.Column)
+}
+
+func printSymbolsJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("gray symbols: %v", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+var symbolsCmd = &cobra.Command{
+	Use:   "symbols [path...]",
+	Short: "Index declarations and references across a project",
+	Long: `Index every function, struct, enum, constant, and struct-namespaced
+function declared in a project, together with each place it is referenced.
+Local imports are followed transitively and import aliases are honoured.
+
+Examples:
+  gray symbols                            List symbols for the current directory
+  gray symbols main.gray                  Index main.gray and everything it imports
+  gray symbols --json ./...               Emit the full index as JSON
+  gray symbols --definition app.gray:9:23 Print where the symbol at a position is declared
+  gray symbols --references utils.gray:3:4 Print every reference to the symbol at a position`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runSymbols,
+}
+
+func runSymbols(cmd *cobra.Command, args []string) error {
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	defPos, _ := cmd.Flags().GetString("definition")
+	refPos, _ := cmd.Flags().GetString("references")
+
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	var lookupFile string
+	var line, col int
+	if pos := defPos + refPos; pos != "" {
+		if defPos != "" && refPos != "" {
+			return fmt.Errorf("gray symbols: use only one of --definition and --references")
+		}
+		var err error
+		if lookupFile, line, col, err = parseSymbolPosition(pos); err != nil {
+			return fmt.Errorf("gray symbols: %v", err)
+		}
+	}
+
+	roots := collectGrayFiles("gray symbols", args)
+	if lookupFile != "" {
+		roots = append([]string{lookupFile}, roots...)
+	}
+	if len(roots) == 0 {
+		fmt.Println("gray symbols: no .gray files found")
+		return nil
+	}
+	idx := buildSymbolIndex(roots)
+
+	if lookupFile != "" {
+		sym := idx.symbolAt(lookupFile, line, col)
+		if sym == nil {
+			return fmt.Errorf("gray symbols: no symbol at %s:%d:%d", shortPath(lookupFile), line, col)
+		}
+		if defPos != "" {
+			if jsonOut {
+				return printSymbolsJSON(sym.Definition)
+			}
+			fmt.Println(formatLocation(sym.Definition))
+			return nil
+		}
+		if jsonOut {
+			return printSymbolsJSON(sym.References)
+		}
+		for _, ref := range sym.References {
+			fmt.Println(formatLocation(ref))
+		}
+		return nil
+	}
+
+	if jsonOut {
+		return printSymbolsJSON(struct {
+			Symbols []*graySymbol `json:"symbols"`
+		}{idx.symbols})
+	}
+	for _, sym := range idx.symbols {
+		fmt.Printf("%-16s %-36s %s  (%d refs)\n", sym.Kind, sym.Qualified, formatLocation(sym.Definition), len(sym.References))
+	}
+	return nil
+}
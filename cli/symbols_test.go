@@ -0,0 +1,210 @@
+// symbols_test.go — Tests for the project symbol index covering the token
+// scanner, declaration discovery, import alias resolution, directory
+// modules, struct-namespaced functions, and position lookups.
+//
+// Author:  Marshall A Burns (@SchoolyB)
+// Copyright (c) 2025-Present Marshall A Burns
+// Licensed under the MIT License. See LICENSE for details.
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLexGraySourceSkipsCommentsAndStrings(t *testing.T) {
+	src := "// do fake() {\nmut s = \"{ ${count} }\" /* } */\nmut r = `{`\n"
+	var idents []string
+	var braces int
+	for _, tok := range lexGraySource(src) {
+		if tok.Kind == tokIdent {
+			idents = append(idents, tok.Text)
+		}
+		if tok.isPunct("{") || tok.isPunct("}") {
+			braces++
+		}
+		for _, in := range tok.Interp {
+			if in.Kind == tokIdent {
+				idents = append(idents, "interp:"+in.Text)
+			}
+		}
+	}
+	if braces != 0 {
+		t.Errorf("braces inside strings/comments were tokenized: %d", braces)
+	}
+	want := []string{"s", "interp:count", "r"}
+	if len(idents) != len(want) {
+		t.Fatalf("idents = %v, want %v", idents, want)
+	}
+	for i := range want {
+		if idents[i] != want[i] {
+			t.Errorf("idents[%d] = %q, want %q", i, idents[i], want[i])
+		}
+	}
+}
+
+func TestScanDeclsFindsAllKinds(t *testing.T) {
+	src := `import m @math, mymod "./server"
+import and use "./helpers.gray"
+using m
+
+#doc("a { brace in a doc string")
+do add(a int, p Point = Point{}) -> int {
+    return a
+}
+
+const MAX int = 10
+
+const Point struct {
+    x int
+    y int = 0
+
+    do create(x int) -> Point { return Point{x: x} }
+    private do check(p Point) -> bool {
+        return true
+    }
+}
+
+private const Color enum {
+    RED
+    GREEN
+}
+`
+	fd := scanDecls(src)
+
+	type want struct{ name, kind, parent string }
+	wants := []want{
+		{"add", "function", ""},
+		{"MAX", "const", ""},
+		{"Point", "struct", ""},
+		{"create", "struct_function", "Point"},
+		{"check", "struct_function", "Point"},
+		{"Color", "enum", ""},
+	}
+	if len(fd.Decls) != len(wants) {
+		t.Fatalf("got %d decls, want %d: %+v", len(fd.Decls), len(wants), fd.Decls)
+	}
+	for i, w := range wants {
+		d := fd.Decls[i]
+		if d.Name != w.name || d.Kind != w.kind || d.Parent != w.parent {
+			t.Errorf("decl[%d] = %s %s (parent %q), want %s %s (parent %q)", i, d.Kind, d.Name, d.Parent, w.kind, w.name, w.parent)
+		}
+	}
+	if !fd.Decls[4].Private || !fd.Decls[5].Private || fd.Decls[3].Private {
+		t.Errorf("private flags wrong: %+v", fd.Decls)
+	}
+
+	if len(fd.Imports) != 3 {
+		t.Fatalf("got %d imports, want 3: %+v", len(fd.Imports), fd.Imports)
+	}
+	if imp := fd.Imports[0]; imp.Alias != "m" || imp.Path != "math" || !imp.Stdlib {
+		t.Errorf("aliased stdlib import = %+v", imp)
+	}
+	if imp := fd.Imports[1]; imp.Alias != "mymod" || imp.Path != "./server" || imp.Stdlib {
+		t.Errorf("aliased local import = %+v", imp)
+	}
+	if imp := fd.Imports[2]; imp.Alias != "helpers" || !imp.AutoUse {
+		t.Errorf("import and use = %+v", imp)
+	}
+	if len(fd.Usings) != 1 || fd.Usings[0] != "m" {
+		t.Errorf("usings = %v", fd.Usings)
+	}
+}
+
+func TestBuildSymbolIndexResolvesAliasesAndDirectories(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "main.gray")
+	writeTestFile(t, main, `import mymod "./server"
+import "./models"
+
+do main() {
+    mut n = mymod.handle(1)
+    mut p = models.Point.create(1, 2)
+    println("${mymod.handle(n)}")
+}
+`)
+	writeTestFile(t, filepath.Join(dir, "server.gray"), "do handle(n int) -> int {\n    return n\n}\n")
+	writeTestFile(t, filepath.Join(dir, "models", "point.gray"), `const Point struct {
+    x int
+
+    do create(x int, y int) -> Point {
+        return Point{x: x}
+    }
+}
+`)
+
+	idx := buildSymbolIndex([]string{main})
+
+	find := func(q string) *graySymbol {
+		for _, s := range idx.symbols {
+			if s.Qualified == q {
+				return s
+			}
+		}
+		t.Fatalf("symbol %s not indexed", q)
+		return nil
+	}
+
+	handle := find("server.handle")
+	if len(handle.References) != 2 {
+		t.Errorf("server.handle refs = %d, want 2 (call + interpolation): %+v", len(handle.References), handle.References)
+	}
+	create := find("models.Point.create")
+	if create.Kind != "struct_function" || len(create.References) != 1 {
+		t.Errorf("models.Point.create = %+v", create)
+	}
+	if got := len(find("models.Point").References); got != 3 {
+		t.Errorf("models.Point refs = %d, want 3", got)
+	}
+
+	// Go-to-definition from the call site of handle (line 5, "handle" at col 19).
+	sym := idx.symbolAt(main, 5, 20)
+	if sym != handle {
+		t.Fatalf("symbolAt(main:5:20) = %+v, want server.handle", sym)
+	}
+	if sym.Definition.Line != 1 || filepath.Base(sym.Definition.File) != "server.gray" {
+		t.Errorf("definition = %+v", sym.Definition)
+	}
+	if idx.symbolAt(main, 5, 9) != nil {
+		t.Errorf("local variable should not resolve to a symbol")
+	}
+}
+
+func TestBuildSymbolIndexImportsResolveFromImporter(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.gray"), "import \"./util\"\n\ndo main() {\n    println(util.twice(1))\n}\n")
+	util := filepath.Join(dir, "util.gray")
+	writeTestFile(t, util, "do twice(n int) -> int {\n    return n * 2\n}\n")
+	// grayc looks for lib/util.gray here, which does not exist, so this
+	// call is not a reference to the util.gray beside main.gray.
+	writeTestFile(t, filepath.Join(dir, "lib", "a.gray"), "import \"./util\"\n\ndo f() -> int {\n    return util.twice(3)\n}\n")
+
+	// The roots of gray symbols ./... and of --references util.gray:1:4.
+	all := collectGrayFiles("gray symbols", []string{filepath.Join(dir, "...")})
+	for _, roots := range [][]string{all, append([]string{util}, all...)} {
+		idx := buildSymbolIndex(roots)
+		sym := idx.symbolAt(util, 1, 4)
+		if sym == nil || sym.Qualified != "util.twice" {
+			t.Fatalf("roots %v: symbolAt = %+v", roots, sym)
+		}
+		if len(sym.References) != 1 || filepath.Base(sym.References[0].File) != "main.gray" {
+			t.Errorf("roots %v: references = %+v, want only main.gray", roots, sym.References)
+		}
+	}
+}
+
+func TestParseSymbolPosition(t *testing.T) {
+	file, line, col, err := parseSymbolPosition("src/app.gray:12:7")
+	if err != nil {
+		t.Fatalf("parseSymbolPosition: %v", err)
+	}
+	if filepath.Base(file) != "app.gray" || !filepath.IsAbs(file) || line != 12 || col != 7 {
+		t.Errorf("got %s:%d:%d", file, line, col)
+	}
+	for _, bad := range []string{"app.gray", "app.gray:1", "app.gray:x:2"} {
+		if _, _, _, err := parseSymbolPosition(bad); err == nil {
+			t.Errorf("parseSymbolPosition(%q) should fail", bad)
+		}
+	}
+}
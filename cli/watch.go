@@ -65,13 +65,16 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	return watchFile(absTarget, compilerArgs)
 }
 
-// watchLoop runs the debounced event loop shared by file and directory watch modes.
-// filterEvent decides whether a filesystem event should trigger a rebuild.
-// refreshFiles returns the current set of files to watch (called on each rebuild).
-func watchLoop(watcher *fsnotify.Watcher, mainFile string, compilerArgs []string,
-	filterEvent func(fsnotify.Event) bool, refreshFiles func() []string) {
+// watchOps are the filesystem operations that trigger a rebuild in gray watch.
+const watchOps = fsnotify.Write | fsnotify.Create
 
-	executeFile(mainFile, compilerArgs)
+// watchLoop runs the debounced event loop shared by file and directory watch
+// modes and gray doc serve. Only events with one of ops, that filterEvent
+// accepts, trigger a rebuild. refreshFiles returns the current set of paths
+// to watch and onChange does the rebuild; both are called after each burst
+// of events. Callers do their own initial build.
+func watchLoop(watcher *fsnotify.Watcher, ops fsnotify.Op, filterEvent func(fsnotify.Event) bool,
+	refreshFiles func() []string, onChange func()) {
 
 	var mu sync.Mutex
 	var timer *time.Timer
@@ -83,7 +86,7 @@ func watchLoop(watcher *fsnotify.Watcher, mainFile string, compilerArgs []string
 			if !ok {
 				return
 			}
-			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 || !filterEvent(event) {
+			if event.Op&ops == 0 || !filterEvent(event) {
 				continue
 			}
 			mu.Lock()
@@ -94,7 +97,7 @@ func watchLoop(watcher *fsnotify.Watcher, mainFile string, compilerArgs []string
 				for _, f := range refreshFiles() {
 					watcher.Add(f)
 				}
-				executeFile(mainFile, compilerArgs)
+				onChange()
 			})
 			mu.Unlock()
 		case err, ok := <-watcher.Errors:
@@ -129,9 +132,11 @@ func watchFile(file string, compilerArgs []string) error {
 	fmt.Println("Press Ctrl+C to stop")
 	fmt.Println()
 
-	watchLoop(watcher, file, compilerArgs,
+	executeFile(file, compilerArgs)
+	watchLoop(watcher, watchOps,
 		func(_ fsnotify.Event) bool { return true },
-		func() []string { return collectFilesToWatch(file) })
+		func() []string { return collectFilesToWatch(file) },
+		func() { executeFile(file, compilerArgs) })
 	return nil
 }
 
@@ -159,9 +164,11 @@ func watchDirectory(dirPath string, compilerArgs []string) error {
 	fmt.Println("Press Ctrl+C to stop")
 	fmt.Println()
 
-	watchLoop(watcher, mainFile, compilerArgs,
+	executeFile(mainFile, compilerArgs)
+	watchLoop(watcher, watchOps,
 		func(e fsnotify.Event) bool { return strings.HasSuffix(e.Name, ".gray") },
-		func() []string { return collectGrayFilesInDir(dirPath) })
+		func() []string { return collectGrayFilesInDir(dirPath) },
+		func() { executeFile(mainFile, compilerArgs) })
 	return nil
 }
 
@@ -1,6 +1,6 @@
 // watch_test.go — Tests for the file watcher covering main-function
 // detection, import scanning, directory file collection, main-file
-// discovery, and path shortening utilities.
+// discovery, path shortening utilities, and which events rebuild.
 //
 // Author:  Marshall A Burns (@SchoolyB)
 // Copyright (c) 2025-Present Marshall A Burns
@@ -13,6 +13,9 @@ import (
 	"path/filepath"
 	"sort"
 	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
 )
 
 func TestHasMainFunction(t *testing.T) {
@@ -211,3 +214,51 @@ func TestShortPath(t *testing.T) {
 		t.Error("shortPath returned empty string for outside-cwd path")
 	}
 }
+
+func TestWatchLoopOps(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		ops         fsnotify.Op
+		removeBuild bool
+	}{
+		{"watch", watchOps, false},
+		{"doc serve", watchOps | fsnotify.Remove | fsnotify.Rename, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFile(t, filepath.Join(dir, "a.gray"), "")
+			watcher, err := fsnotify.NewWatcher()
+			if err != nil {
+				t.Skip("fsnotify unavailable:", err)
+			}
+			defer watcher.Close()
+			if err := watcher.Add(dir); err != nil {
+				t.Fatal(err)
+			}
+			built := make(chan bool, 8)
+			go watchLoop(watcher, tt.ops, func(fsnotify.Event) bool { return true },
+				func() []string { return nil }, func() { built <- true })
+
+			if err := os.Remove(filepath.Join(dir, "a.gray")); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-built:
+				if !tt.removeBuild {
+					t.Error("removing a file triggered a rebuild")
+				}
+			case <-time.After(time.Second):
+				if tt.removeBuild {
+					t.Error("removing a file did not trigger a rebuild")
+				}
+			}
+
+			writeTestFile(t, filepath.Join(dir, "b.gray"), "")
+			select {
+			case <-built:
+			case <-time.After(2 * time.Second):
+				t.Error("creating a file did not trigger a rebuild")
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+/*
+ * decls.c — Declaration dump for "gray doc". Walks the top-level
+ * statements of a parsed (not type-checked) program and writes its
+ * imports and the declarations' names, types, #doc text, and source
+ * positions as JSON on one line.
+ *
+ * Author:  Marshall A Burns (@SchoolyB)
+ * Copyright (c) 2025-Present Marshall A Burns
+ * Licensed under the MIT License. See LICENSE for details.
+ */
+
+#include "decls.h"
+#include "../lexer/lexer.h"
+#include <string.h>
+
+/* The file being dumped, for turning token positions into byte offsets */
+typedef struct {
+    const char *source;
+    Arena *arena;
+} DumpSource;
+
+static DumpSource dump_src;
+
+static void put_string(FILE *out, const char *s) {
+    if (!s) {
+        fputs("null", out);
+        return;
+    }
+    fputc('"', out);
+    for (const unsigned char *p = (const unsigned char *)s; *p; p++) {
+        switch (*p) {
+        case '"':  fputs("\\\"", out); break;
+        case '\\': fputs("\\\\", out); break;
+        case '\n': fputs("\\n", out); break;
+        case '\r': fputs("\\r", out); break;
+        case '\t': fputs("\\t", out); break;
+        default:
+            if (*p < 0x20) fprintf(out, "\\u%04x", *p);
+            else fputc(*p, out);
+        }
+    }
+    fputc('"', out);
+}
+
+static void put_bool(FILE *out, bool b) {
+    fputs(b ? "true" : "false", out);
+}
+
+/* Byte offset of a 1-based line and byte column, or -1 past the end */
+static int source_offset(int line, int column) {
+    const char *p = dump_src.source;
+    for (int l = 1; l < line; l++) {
+        p = strchr(p, '\n');
+        if (!p) return -1;
+        p++;
+    }
+    for (int c = 1; c < column; c++, p++) {
+        if (*p == '\0' || *p == '\n') return -1;
+    }
+    return (int)(p - dump_src.source);
+}
+
+/* Byte offset just past the token starting at offset, or -1 */
+static int token_end(int offset) {
+    if (offset < 0) return -1;
+    Lexer *lexer = lexer_create(dump_src.arena, dump_src.source + offset, NULL);
+    Token tok = lexer_next_token(lexer);
+    if (tok.type == TOK_EOF || tok.type == TOK_ILLEGAL) return -1;
+    return offset + lexer->position;
+}
+
+/* A span, or null when nothing was written there. start and end are the
+ * byte offsets of the source it covers (-1 when they cannot be found). */
+static void put_span(FILE *out, bool present, SrcSpan span) {
+    if (!present) {
+        fputs("null", out);
+        return;
+    }
+    int start = source_offset(span.line, span.column);
+    int end = token_end(source_offset(span.end_line, span.end_column));
+    if (start < 0 || end < start) start = end = -1;
+    fprintf(out, "{\"line\":%d,\"column\":%d,\"end_line\":%d,\"end_column\":%d,\"start\":%d,\"end\":%d}",
+        span.line, span.column, span.end_line, span.end_column, start, end);
+}
+
+/* Fields shared by every declaration object */
+static void put_header(FILE *out, const char *kind, const char *name, const AstNode *node, bool is_private) {
+    fputs("{\"kind\":", out);
+    put_string(out, kind);
+    fputs(",\"name\":", out);
+    put_string(out, name);
+    fprintf(out, ",\"line\":%d,\"column\":%d,\"private\":", node->token.line, node->token.column);
+    put_bool(out, is_private);
+    fputs(",\"doc\":", out);
+    put_string(out, node->doc);
+}
+
+static void dump_func(FILE *out, const AstNode *fn) {
+    put_header(out, "function", fn->data.func_decl.name, fn, fn->data.func_decl.is_private);
+    fputs(",\"params\":[", out);
+    for (int i = 0; i < fn->data.func_decl.param_count; i++) {
+        const Param *p = &fn->data.func_decl.params[i];
+        if (i > 0) fputc(',', out);
+        fputs("{\"name\":", out);
+        put_string(out, p->name);
+        fputs(",\"type\":", out);
+        put_string(out, p->type_name);
+        fputs(",\"type_span\":", out);
+        put_span(out, p->type_name && !p->is_type_param, p->type_span);
+        fputs(",\"mutable\":", out);
+        put_bool(out, p->mutable);
+        fputs(",\"type_param\":", out);
+        put_bool(out, p->is_type_param);
+        fputs(",\"default\":", out);
+        put_span(out, p->default_value != NULL, p->default_span);
+        fputc('}', out);
+    }
+    fputs("],\"returns\":[", out);
+    for (int i = 0; i < fn->data.func_decl.return_type_count; i++) {
+        if (i > 0) fputc(',', out);
+        fputs("{\"name\":", out);
+        put_string(out, fn->data.func_decl.return_names ? fn->data.func_decl.return_names[i] : NULL);
+        fputs(",\"type\":", out);
+        put_string(out, fn->data.func_decl.return_types[i]);
+        fputs(",\"type_span\":", out);
+        put_span(out, fn->data.func_decl.return_spans != NULL,
+            fn->data.func_decl.return_spans ? fn->data.func_decl.return_spans[i] : (SrcSpan){0});
+        fputc('}', out);
+    }
+    fputs("]}", out);
+}
+
+static void dump_struct(FILE *out, const AstNode *st) {
+    put_header(out, "struct", st->data.struct_decl.name, st, false);
+    fputs(",\"json\":", out);
+    put_bool(out, st->data.struct_decl.is_json);
+    fputs(",\"fields\":[", out);
+    for (int i = 0; i < st->data.struct_decl.field_count; i++) {
+        const StructField *f = &st->data.struct_decl.fields[i];
+        if (i > 0) fputc(',', out);
+        fputs("{\"name\":", out);
+        put_string(out, f->name);
+        fputs(",\"type\":", out);
+        put_string(out, f->type_name);
+        fputs(",\"type_span\":", out);
+        put_span(out, f->type_name != NULL, f->type_span);
+        fputs(",\"default\":", out);
+        put_span(out, f->default_value != NULL, f->default_span);
+        fputc('}', out);
+    }
+    fputs("],\"functions\":[", out);
+    for (int i = 0; i < st->data.struct_decl.func_count; i++) {
+        if (i > 0) fputc(',', out);
+        dump_func(out, st->data.struct_decl.funcs[i].func_decl);
+    }
+    fputs("]}", out);
+}
+
+static void dump_enum(FILE *out, const AstNode *en) {
+    put_header(out, "enum", en->data.enum_decl.name, en, false);
+    fputs(",\"flags\":", out);
+    put_bool(out, en->data.enum_decl.is_flags);
+    fputs(",\"variants\":[", out);
+    for (int i = 0; i < en->data.enum_decl.value_count; i++) {
+        const EnumVal *v = &en->data.enum_decl.values[i];
+        if (i > 0) fputc(',', out);
+        fputs("{\"name\":", out);
+        put_string(out, v->name);
+        fputs(",\"value\":", out);
+        put_span(out, v->value != NULL, v->value_span);
+        fputs(",\"payload\":[", out);
+        for (int j = 0; j < v->payload_count; j++) {
+            if (j > 0) fputc(',', out);
+            put_string(out, v->payload_types[j]);
+        }
+        fputs("]}", out);
+    }
+    fputs("]}", out);
+}
+
+static void dump_var(FILE *out, const AstNode *var) {
+    put_header(out, var->data.var_decl.mutable ? "var" : "const",
+        var->data.var_decl.name, var, var->data.var_decl.is_private);
+    fputs(",\"type\":", out);
+    put_string(out, var->data.var_decl.type_name);
+    fputs(",\"type_span\":", out);
+    put_span(out, var->data.var_decl.type_name != NULL, var->data.var_decl.type_span);
+    fputs(",\"value\":", out);
+    put_span(out, var->data.var_decl.value != NULL, var->data.var_decl.value_span);
+    fputc('}', out);
+}
+
+/* The items of an import statement; C header imports are left out */
+static void dump_imports(FILE *out, const AstNode *imp, bool *first) {
+    for (int i = 0; i < imp->data.import_stmt.count; i++) {
+        const ImportItem *item = &imp->data.import_stmt.items[i];
+        if (item->is_c_import) continue;
+        if (!*first) fputc(',', out);
+        *first = false;
+        fputs("{\"alias\":", out);
+        put_string(out, item->alias);
+        fputs(",\"path\":", out);
+        put_string(out, item->is_stdlib ? item->module : item->path);
+        fputs(",\"stdlib\":", out);
+        put_bool(out, item->is_stdlib);
+        fputs(",\"auto_use\":", out);
+        put_bool(out, imp->data.import_stmt.auto_use);
+        fprintf(out, ",\"line\":%d}", imp->token.line);
+    }
+}
+
+void gray_dump_decls(FILE *out, const AstNode *program, const char *file, const char *source, Arena *arena) {
+    dump_src = (DumpSource){source, arena};
+    fputs("{\"file\":", out);
+    put_string(out, file);
+    fputs(",\"imports\":[", out);
+    bool first = true;
+    for (int i = 0; i < program->data.program.stmt_count; i++) {
+        const AstNode *stmt = program->data.program.stmts[i];
+        if (stmt && stmt->kind == NODE_IMPORT_STMT) dump_imports(out, stmt, &first);
+    }
+    fputs("],\"decls\":[", out);
+    first = true;
+    for (int i = 0; i < program->data.program.stmt_count; i++) {
+        const AstNode *stmt = program->data.program.stmts[i];
+        if (!stmt) continue;
+        switch (stmt->kind) {
+        case NODE_FUNC_DECL:
+        case NODE_STRUCT_DECL:
+        case NODE_ENUM_DECL:
+        case NODE_VAR_DECL:
+            break;
+        default:
+            continue;
+        }
+        if (!first) fputc(',', out);
+        first = false;
+        switch (stmt->kind) {
+        case NODE_FUNC_DECL:   dump_func(out, stmt); break;
+        case NODE_STRUCT_DECL: dump_struct(out, stmt); break;
+        case NODE_ENUM_DECL:   dump_enum(out, stmt); break;
+        default:               dump_var(out, stmt); break;
+        }
+    }
+    fputs("]}\n", out);
+}
@@ -0,0 +1,27 @@
+/*
+ * decls.h — Public interface for the declaration dump used by "gray doc".
+ * Declares gray_dump_decls, which writes the top-level declarations of a
+ * parsed file as JSON.
+ *
+ * Author:  Marshall A Burns (@SchoolyB)
+ * Copyright (c) 2025-Present Marshall A Burns
+ * Licensed under the MIT License. See LICENSE for details.
+ */
+
+#ifndef GRAYC_DECLS_H
+#define GRAYC_DECLS_H
+
+#include "../parser/ast.h"
+#include <stdio.h>
+
+/*
+ * gray_dump_decls writes one JSON object describing the imports and the
+ * functions, structs (fields, defaults, struct functions), enums, and
+ * constants declared at the top level of program. Default values and
+ * initialisers are reported as source spans (first/last token positions
+ * and the byte offsets of the text between them, found by re-lexing
+ * source) so callers can quote them exactly as written.
+ */
+void gray_dump_decls(FILE *out, const AstNode *program, const char *file, const char *source, Arena *arena);
+
+#endif
@@ -1,7 +1,9 @@
 /*
  * fmt.c — Grayscale source formatter implementation. Lexes the source to
  * build a per-line indentation depth table based on brace nesting, then
- * re-emits each original line with corrected leading whitespace.
+ * re-emits each original line with corrected leading whitespace. Optional
+ * passes move block braces to the configured style and wrap long call
+ * argument lists before indentation is applied.
  *
  * Author:  Marshall A Burns (@SchoolyB)
  * Copyright (c) 2025-Present Marshall A Burns
@@ -12,15 +14,40 @@
 #include "../lexer/lexer.h"
 #include "../lexer/token.h"
 #include "../util/arena.h"
+#include "../util/buf.h"
 
 #include <stdio.h>
 #include <stdlib.h>
 #include <string.h>
 #include <ctype.h>
 
-#define FMT_INDENT_WIDTH 4
+#define FMT_DEFAULT_INDENT_WIDTH    4
+#define FMT_DEFAULT_MAX_BLANK_LINES 2
 #define FMT_ARENA_SIZE   (1024 * 1024)  /* 1 MB — enough for any source file */
 
+/* A lexed token with its byte span in the source. */
+typedef struct {
+    TokenType type;
+    int line;
+    int column;
+    int start;  /* offset of the first byte */
+    int end;    /* offset just past the last byte */
+} FmtToken;
+
+/* Token and line tables for one source buffer. Line arrays are 1-indexed. */
+typedef struct {
+    const char *src;
+    FmtToken *toks;
+    int tok_count;
+    int max_line;
+    int *line_start;  /* offset of the first byte of each line */
+    int *line_end;    /* offset of the line's '\n' (or end of source) */
+    int *first_tok;   /* index of the first token starting on the line, or -1 */
+    int *last_tok;    /* index of the last token starting on the line, or -1 */
+    bool *verbatim;   /* line is a continuation of a multi-line raw string */
+    int *depth;       /* indentation depth at the start of the line */
+} FmtSource;
+
 /* Count the number of newlines in src to find the maximum line number. */
 static int count_lines(const char *src) {
     int n = 1;
@@ -30,107 +57,474 @@ static int count_lines(const char *src) {
     return n;
 }
 
+static bool is_open_bracket(TokenType t) {
+    return t == TOK_LBRACE || t == TOK_LPAREN || t == TOK_LBRACKET;
+}
+
+static bool is_close_bracket(TokenType t) {
+    return t == TOK_RBRACE || t == TOK_RPAREN || t == TOK_RBRACKET;
+}
+
+static void fmt_source_free(FmtSource *fs) {
+    free(fs->toks);
+    free(fs->line_start);
+    free(fs->line_end);
+    free(fs->first_tok);
+    free(fs->last_tok);
+    free(fs->verbatim);
+    free(fs->depth);
+    memset(fs, 0, sizeof(*fs));
+}
+
 /*
- * Build a depth table: depth_at[line] = indentation depth at the START of
- * that line (1-indexed).  Lines that contain no tokens (blank lines or
- * comment-only lines) inherit the depth of the nearest preceding token line.
+ * Fill fs->depth for build_depth_table with ( [ and { all nesting. Returns
+ * false after printing a diagnostic if the brackets are unbalanced.
+ */
+static bool build_bracket_depths(FmtSource *fs, const char *filename) {
+    int *stack = malloc(sizeof(int) * (fs->tok_count + 1));  /* opener lines */
+    if (!stack) return false;
+    int top = 0;
+    int levels = 0;  /* distinct opener lines on the stack */
+
+    int cur_line = 0;
+    bool leading = false;  /* only closers seen so far on cur_line */
+    for (int i = 0; i < fs->tok_count; i++) {
+        FmtToken *t = &fs->toks[i];
+        if (t->line != cur_line) {
+            cur_line = t->line;
+            leading = true;
+        }
+
+        if (is_close_bracket(t->type)) {
+            if (top == 0) {
+                fprintf(stderr, "%s:%d: error: unmatched '%.*s'\n", filename, t->line,
+                        t->end - t->start, fs->src + t->start);
+                free(stack);
+                return false;
+            }
+            top--;
+            if (top == 0 || stack[top - 1] != stack[top]) levels--;
+            if (leading) fs->depth[t->line] = levels;
+            continue;
+        }
+
+        if (leading && fs->depth[t->line] < 0) fs->depth[t->line] = levels;
+        leading = false;
+
+        if (is_open_bracket(t->type)) {
+            if (top == 0 || stack[top - 1] != t->line) levels++;
+            stack[top++] = t->line;
+        }
+    }
+
+    if (top > 0) {
+        fprintf(stderr, "%s:%d: error: unclosed bracket opened here\n", filename, stack[top - 1]);
+        free(stack);
+        return false;
+    }
+    free(stack);
+    return true;
+}
+
+/*
+ * Build the depth table: depth[line] = indentation depth at the START of
+ * that line (1-indexed). Lines that contain no tokens (blank lines or
+ * comment-only lines) inherit the depth of the nearest preceding token
+ * line.
+ *
+ * By default only braces nest. TOK_RBRACE decrements the depth BEFORE the
+ * line is recorded, so a closing brace line gets the outer depth.
+ * TOK_LBRACE increments the depth AFTER the line is recorded, so the
+ * opening-brace line itself stays at the current depth and the body lines
+ * inside get depth+1.
+ *
+ * With brackets set (call wrapping is on, and the wrapped arguments need
+ * it) ( and [ nest as well. All brackets opened on the same line then
+ * count as one level, so `spawn(do() {` indents its body once, and
+ * closing brackets at the start of a line dedent that line itself.
  *
- * TOK_RBRACE decrements the depth BEFORE the line is recorded, so a closing
- * brace line gets the outer depth.  TOK_LBRACE increments the depth AFTER
- * the line is recorded, so the opening-brace line itself stays at the current
- * depth and the body lines inside get depth+1.
+ * Returns false after printing a diagnostic if the brackets are unbalanced,
+ * so the caller never re-indents a file it could not understand.
  */
-static int *build_depth_table(const char *src, const char *filename, int max_line) {
-    int *table = calloc(max_line + 2, sizeof(int));
-    if (!table) return NULL;
+static bool build_depth_table(FmtSource *fs, const char *filename, bool brackets) {
+    for (int i = 0; i <= fs->max_line; i++) fs->depth[i] = -1;
 
-    /* -1 = not yet assigned by a token */
-    for (int i = 0; i <= max_line + 1; i++) table[i] = -1;
+    if (!brackets) {
+        int depth = 0;
+        FmtToken *open = NULL;  /* outermost unclosed '{' */
+        for (int i = 0; i < fs->tok_count; i++) {
+            FmtToken *t = &fs->toks[i];
 
-    Arena *arena = arena_create(FMT_ARENA_SIZE);
-    if (!arena) { free(table); return NULL; }
+            /* Closing brace: dedent first, then record */
+            if (t->type == TOK_RBRACE) {
+                if (depth == 0) {
+                    fprintf(stderr, "%s:%d:%d: error: unmatched '}'\n", filename, t->line, t->column);
+                    return false;
+                }
+                depth--;
+            }
+
+            /* Record depth for this line if not yet set */
+            if (fs->depth[t->line] < 0) fs->depth[t->line] = depth;
+
+            /* Opening brace: indent for subsequent lines */
+            if (t->type == TOK_LBRACE) {
+                if (depth == 0) open = t;
+                depth++;
+            }
+        }
+        if (depth > 0) {
+            fprintf(stderr, "%s:%d:%d: error: unclosed '{'\n", filename, open->line, open->column);
+            return false;
+        }
+    } else if (!build_bracket_depths(fs, filename)) {
+        return false;
+    }
+
+    /* Forward-fill gaps: blank lines and comment-only lines inherit the
+     * depth of the most recent token-bearing line. */
+    int current = 0;
+    for (int i = 1; i <= fs->max_line; i++) {
+        if (fs->depth[i] >= 0) {
+            current = fs->depth[i];
+        } else {
+            fs->depth[i] = current;
+        }
+    }
+    return true;
+}
 
+/*
+ * Lex src into fs and build its depth table. Returns false after printing
+ * a diagnostic if the source does not lex or its brackets are unbalanced.
+ */
+static bool fmt_source_load(FmtSource *fs, const char *src, const char *filename, bool brackets) {
+    memset(fs, 0, sizeof(*fs));
+    fs->src = src;
+    fs->max_line = count_lines(src);
+    int n = fs->max_line + 2;
+    fs->line_start = calloc(n, sizeof(int));
+    fs->line_end = calloc(n, sizeof(int));
+    fs->first_tok = malloc(n * sizeof(int));
+    fs->last_tok = malloc(n * sizeof(int));
+    fs->verbatim = calloc(n, sizeof(bool));
+    fs->depth = calloc(n, sizeof(int));
+    if (!fs->line_start || !fs->line_end || !fs->first_tok || !fs->last_tok ||
+        !fs->verbatim || !fs->depth) {
+        fmt_source_free(fs);
+        return false;
+    }
+
+    int line = 1;
+    for (int i = 0;; i++) {
+        if (src[i] == '\n' || src[i] == '\0') {
+            fs->line_end[line] = i;
+            if (src[i] == '\0') break;
+            fs->line_start[++line] = i + 1;
+        }
+    }
+    for (int i = 0; i < n; i++) fs->first_tok[i] = fs->last_tok[i] = -1;
+
+    Arena *arena = arena_create(FMT_ARENA_SIZE);
+    if (!arena) { fmt_source_free(fs); return false; }
     Lexer *lexer = lexer_create(arena, src, filename);
-    if (!lexer) { arena_destroy(arena); free(table); return NULL; }
+    if (!lexer) { arena_destroy(arena); fmt_source_free(fs); return false; }
 
-    int depth = 0;
+    int cap = 256;
+    fs->toks = malloc(sizeof(FmtToken) * cap);
     Token token;
-    while ((token = lexer_next_token(lexer)).type != TOK_EOF) {
-        if (token.type == TOK_ILLEGAL) break;
+    while (fs->toks && (token = lexer_next_token(lexer)).type != TOK_EOF) {
+        if (token.type == TOK_ILLEGAL) {
+            fprintf(stderr, "%s:%d:%d: error[%s]: %s\n", filename, token.line, token.column,
+                    lexer->error_code ? lexer->error_code : "E1022",
+                    lexer->error_msg ? lexer->error_msg : "invalid token");
+            arena_destroy(arena);
+            fmt_source_free(fs);
+            return false;
+        }
         if (token.type == TOK_NEWLINE) continue;
+        if (token.line < 1 || token.line > fs->max_line) continue;
 
-        int line = token.line;
-        if (line < 1 || line > max_line) continue;
-
-        /* Closing brace: dedent first, then record */
-        if (token.type == TOK_RBRACE) {
-            if (depth > 0) depth--;
+        if (fs->tok_count == cap) {
+            cap *= 2;
+            FmtToken *grown = realloc(fs->toks, sizeof(FmtToken) * cap);
+            if (!grown) { free(fs->toks); fs->toks = NULL; break; }
+            fs->toks = grown;
         }
+        FmtToken *t = &fs->toks[fs->tok_count];
+        t->type = token.type;
+        t->line = token.line;
+        t->column = token.column;
+        t->start = fs->line_start[token.line] + token.column - 1;
+        t->end = lexer->position;
+        if (fs->first_tok[t->line] < 0) fs->first_tok[t->line] = fs->tok_count;
+        fs->last_tok[t->line] = fs->tok_count;
 
-        /* Record depth for this line if not yet set */
-        if (table[line] < 0) {
-            table[line] = depth;
+        /* Lines after the first of a multi-line raw string are content. */
+        if (t->type == TOK_RAW_STRING) {
+            for (int p = t->start, l = t->line; p < t->end; p++) {
+                if (src[p] == '\n' && ++l <= fs->max_line) fs->verbatim[l] = true;
+            }
         }
+        fs->tok_count++;
+    }
+    arena_destroy(arena);
+    if (!fs->toks) { fmt_source_free(fs); return false; }
+
+    if (!build_depth_table(fs, filename, brackets)) {
+        fmt_source_free(fs);
+        return false;
+    }
+    return true;
+}
 
-        /* Opening brace: indent for subsequent lines */
-        if (token.type == TOK_LBRACE) {
-            depth++;
+/* Returns true if the text in [from, to) is only spaces and tabs. */
+static bool is_blank_span(const char *src, int from, int to) {
+    for (int i = from; i < to; i++) {
+        if (src[i] != ' ' && src[i] != '\t' && src[i] != '\r') return false;
+    }
+    return true;
+}
+
+/* Returns true if nothing but whitespace follows the line's last token,
+ * i.e. the line carries no trailing comment. */
+static bool ends_clean(const FmtSource *fs, int line) {
+    int last = fs->last_tok[line];
+    return last >= 0 && is_blank_span(fs->src, fs->toks[last].end, fs->line_end[line]);
+}
+
+static int line_token_count(const FmtSource *fs, int line) {
+    if (fs->first_tok[line] < 0) return 0;
+    return fs->last_tok[line] - fs->first_tok[line] + 1;
+}
+
+/*
+ * Returns true if the tokens [first, last] form the header of a block
+ * statement or declaration (`do f()`, `if x`, `} otherwise`, `const T
+ * struct`, ...), i.e. a line whose trailing '{' opens a block rather than
+ * a literal.
+ */
+static bool is_block_header(const FmtSource *fs, int first, int last) {
+    if (first < 0 || first > last) return false;
+    TokenType t = fs->toks[first].type;
+    if (t == TOK_RBRACE && first < last) t = fs->toks[++first].type;
+    if (t == TOK_PRIVATE && first < last) t = fs->toks[++first].type;
+    switch (t) {
+    case TOK_DO: case TOK_IF: case TOK_OR_KW: case TOK_OTHERWISE:
+    case TOK_FOR: case TOK_FOR_EACH: case TOK_AS_LONG_AS: case TOK_LOOP:
+    case TOK_WHEN: case TOK_IS: case TOK_DEFAULT:
+        return true;
+    case TOK_CONST:
+        for (int i = first; i <= last; i++) {
+            if (fs->toks[i].type == TOK_STRUCT || fs->toks[i].type == TOK_ENUM) return true;
         }
+        return false;
+    default:
+        return false;
     }
+}
 
-    arena_destroy(arena);
+/* Append src[from, to) to b with trailing whitespace removed. */
+static void append_trimmed(Buf *b, const char *src, int from, int to) {
+    while (to > from && (src[to - 1] == ' ' || src[to - 1] == '\t' || src[to - 1] == '\r')) to--;
+    append_bytes_to_buffer(b, src + from, to - from);
+}
 
-    /* Forward-fill gaps: blank lines and comment-only lines inherit the
-     * depth of the most recent token-bearing line. */
-    int current = 0;
-    for (int i = 1; i <= max_line; i++) {
-        if (table[i] >= 0) {
-            current = table[i];
-        } else {
-            table[i] = current;
+/* Drop the newline most recently written to b. */
+static void unterminate(Buf *b) {
+    if (b->len > 0 && b->data[b->len - 1] == '\n') b->data[--b->len] = '\0';
+}
+
+/*
+ * Split the call argument list on `line` one argument per line. Picks the
+ * leftmost call (an identifier followed by '(') whose closing paren is on
+ * the same line and which has at least two arguments. Returns true and
+ * appends the wrapped text to b on success.
+ */
+static bool wrap_call(const FmtSource *fs, int line, Buf *b) {
+    int first = fs->first_tok[line], last = fs->last_tok[line];
+    TokenType lead = fs->toks[first].type;
+    if (lead == TOK_DO || lead == TOK_PRIVATE || lead == TOK_IMPORT) return false;
+
+    for (int open = first + 1; open <= last; open++) {
+        if (fs->toks[open].type != TOK_LPAREN || fs->toks[open - 1].type != TOK_IDENT) continue;
+
+        /* Find the matching ')' and count the top-level commas. */
+        int depth = 0, close = -1, commas = 0;
+        for (int i = open; i <= last && close < 0; i++) {
+            TokenType t = fs->toks[i].type;
+            if (is_open_bracket(t)) depth++;
+            else if (is_close_bracket(t) && --depth == 0) close = i;
+            else if (t == TOK_COMMA && depth == 1) commas++;
+        }
+        if (close < 0 || commas == 0) continue;
+
+        /* Comments between the parens would be lost; leave such calls alone. */
+        bool clean = true;
+        for (int i = open; i < close && clean; i++) {
+            clean = is_blank_span(fs->src, fs->toks[i].end, fs->toks[i + 1].start);
         }
+        if (!clean) continue;
+
+        const char *src = fs->src;
+        append_bytes_to_buffer(b, src + fs->toks[first].start, fs->toks[open].end - fs->toks[first].start);
+        append_char_to_buffer(b, '\n');
+        int arg_start = open + 1;
+        depth = 0;
+        for (int i = open + 1; i <= close; i++) {
+            TokenType t = fs->toks[i].type;
+            if (i != close && is_open_bracket(t)) { depth++; continue; }
+            if (i != close && is_close_bracket(t)) { depth--; continue; }
+            if ((t == TOK_COMMA && depth == 0) || i == close) {
+                append_bytes_to_buffer(b, src + fs->toks[arg_start].start,
+                    fs->toks[i - 1].end - fs->toks[arg_start].start);
+                if (i != close) append_char_to_buffer(b, ',');
+                append_char_to_buffer(b, '\n');
+                arg_start = i + 1;
+            }
+        }
+        append_trimmed(b, src, fs->toks[close].start, fs->line_end[line]);
+        append_char_to_buffer(b, '\n');
+        return true;
     }
+    return false;
+}
 
-    return table;
+/* Returns the printed width of `line` once re-indented (tabs count as 4). */
+static int indented_width(const FmtSource *fs, int line, const GrayFmtOptions *opts) {
+    int content = fs->line_end[line] - fs->toks[fs->first_tok[line]].start;
+    int width = opts->use_tabs ? 4 : opts->indent_width;
+    return fs->depth[line] * width + content;
 }
 
 /*
- * Returns true if `line` (NUL-terminated, with leading whitespace already
- * stripped) is inside a raw string that started on an earlier line.
- * We track this by counting unpaired backticks across the whole source up to
- * the given line number.  An odd count means we are inside a raw string.
+ * Restructure pass: apply the brace style and wrap long calls, producing a
+ * new source buffer for the indentation pass. Every other line is copied
+ * through unchanged.
  */
-static bool line_is_in_raw_string(const char *src, int target_line) {
-    int line = 1;
-    bool in_raw = false;
-    bool in_str = false;  /* regular double-quoted string */
-    const char *p = src;
-    while (*p && line < target_line) {
-        if (*p == '\n') { line++; p++; continue; }
-        if (in_raw) {
-            if (*p == '`') in_raw = false;
-            p++; continue;
+static char *restructure(const FmtSource *fs, const GrayFmtOptions *opts) {
+    Buf b = buffer_create(4096);
+    const char *src = fs->src;
+
+    /* What the last emitted line can be joined with (same_line style). */
+    enum { PREV_OTHER, PREV_HEADER, PREV_CLOSE } prev = PREV_OTHER;
+
+    for (int line = 1; line <= fs->max_line; line++) {
+        int ls = fs->line_start[line], le = fs->line_end[line];
+        bool last_line = line == fs->max_line;
+        int first = fs->first_tok[line], last = fs->last_tok[line];
+        int ntok = line_token_count(fs, line);
+
+        if (fs->verbatim[line] || ntok == 0 || fs->toks[first].start < ls) {
+            append_bytes_to_buffer(&b, src + ls, le - ls);
+            if (!last_line) append_char_to_buffer(&b, '\n');
+            prev = PREV_OTHER;
+            continue;
         }
-        if (in_str) {
-            if (*p == '\\' && *(p + 1)) { p += 2; continue; } /* skip escape */
-            if (*p == '"')  { in_str = false; }
-            p++; continue;
+
+        TokenType lead = fs->toks[first].type;
+        bool clean = ends_clean(fs, line);
+
+        if (opts->brace_style == GRAY_FMT_BRACE_SAME_LINE) {
+            /* A lone '{' joins the block header above it. */
+            if (ntok == 1 && lead == TOK_LBRACE && clean && prev == PREV_HEADER) {
+                unterminate(&b);
+                append_string_to_buffer(&b, " {\n");
+                prev = PREV_OTHER;
+                continue;
+            }
+            /* `otherwise` / `or` joins the lone '}' above it. */
+            if ((lead == TOK_OTHERWISE || lead == TOK_OR_KW) && prev == PREV_CLOSE) {
+                unterminate(&b);
+                append_char_to_buffer(&b, ' ');
+                append_trimmed(&b, src, fs->toks[first].start, le);
+                append_char_to_buffer(&b, '\n');
+                prev = (clean && fs->toks[last].type != TOK_LBRACE && is_block_header(fs, first, last))
+                    ? PREV_HEADER : PREV_OTHER;
+                continue;
+            }
+        }
+
+        if (opts->brace_style == GRAY_FMT_BRACE_NEXT_LINE && ntok > 1 && clean &&
+            fs->toks[last].type == TOK_LBRACE && is_block_header(fs, first, last)) {
+            int from = fs->toks[first].start;
+            if (lead == TOK_RBRACE) {
+                /* `} otherwise {` becomes `}` / `otherwise` / `{` */
+                append_string_to_buffer(&b, "}\n");
+                from = fs->toks[first + 1].start;
+            }
+            append_trimmed(&b, src, from, fs->toks[last].start);
+            append_string_to_buffer(&b, "\n{\n");
+            prev = PREV_OTHER;
+            continue;
+        }
+
+        if (opts->max_line_length > 0 && indented_width(fs, line, opts) > opts->max_line_length &&
+            wrap_call(fs, line, &b)) {
+            prev = PREV_OTHER;
+            continue;
+        }
+
+        append_bytes_to_buffer(&b, src + ls, le - ls);
+        if (!last_line) append_char_to_buffer(&b, '\n');
+
+        if (ntok == 1 && lead == TOK_RBRACE && clean) {
+            prev = PREV_CLOSE;
+        } else if (clean && fs->toks[last].type != TOK_LBRACE && is_block_header(fs, first, last)) {
+            prev = PREV_HEADER;
+        } else {
+            prev = PREV_OTHER;
         }
-        if (*p == '`') { in_raw = true; p++; continue; }
-        if (*p == '"') { in_str = true; p++; continue; }
-        p++;
     }
-    return in_raw;
+
+    char *out = strdup(b.data ? b.data : "");
+    buffer_destroy(&b);
+    return out;
 }
 
-int gray_fmt_source(const char *src, const char *filename, FILE *out) {
-    int max_line = count_lines(src);
-    int *depth_table = build_depth_table(src, filename, max_line);
-    if (!depth_table) return 1;
+/* Returns true if line_num falls inside the requested formatting range. */
+static bool line_in_range(const GrayFmtOptions *opts, int line_num) {
+    if (opts->range_start > 0 && line_num < opts->range_start) return false;
+    if (opts->range_end > 0 && line_num > opts->range_end) return false;
+    return true;
+}
+
+/* Fill unset (zero) options with their defaults. */
+static GrayFmtOptions resolve_options(const GrayFmtOptions *opts) {
+    GrayFmtOptions o = {0};
+    if (opts) o = *opts;
+    if (o.indent_width <= 0) o.indent_width = FMT_DEFAULT_INDENT_WIDTH;
+    if (!o.max_blank_lines_set) o.max_blank_lines = FMT_DEFAULT_MAX_BLANK_LINES;
+    /* Restructuring moves lines around, so --range only re-indents. */
+    if (o.range_start > 0 || o.range_end > 0) {
+        o.brace_style = GRAY_FMT_BRACE_PRESERVE;
+        o.max_line_length = 0;
+    }
+    return o;
+}
+
+int gray_fmt_source(const char *src, const char *filename, FILE *out, const GrayFmtOptions *user_opts) {
+    GrayFmtOptions opts = resolve_options(user_opts);
+
+    FmtSource fs;
+    bool brackets = opts.max_line_length > 0;
+    if (!fmt_source_load(&fs, src, filename, brackets)) return 1;
+
+    char *restructured = NULL;
+    if (opts.brace_style != GRAY_FMT_BRACE_PRESERVE || opts.max_line_length > 0) {
+        restructured = restructure(&fs, &opts);
+        fmt_source_free(&fs);
+        if (!restructured || !fmt_source_load(&fs, restructured, filename, brackets)) {
+            free(restructured);
+            return 1;
+        }
+        src = restructured;
+    }
 
     /* Walk the source line by line, re-indenting each one. */
     const char *p = src;
     int line_num = 1;
+    int blank_run = 0;
 
     while (*p) {
         /* Find end of this line */
@@ -147,20 +541,26 @@ int gray_fmt_source(const char *src, const char *filename, FILE *out) {
             content++;
         int content_len = (int)((line_start + line_len) - content);
 
-        if (content_len == 0) {
-            /* Blank line: emit as-is (no indentation) */
-            fputc('\n', out);
-        } else if (line_is_in_raw_string(src, line_num)) {
-            /* Inside a raw string: preserve the original line verbatim */
+        if (!line_in_range(&opts, line_num) || fs.verbatim[line_num]) {
+            /* Outside --range or inside a raw string: preserve verbatim */
             fwrite(line_start, 1, line_len, out);
             fputc('\n', out);
+            blank_run = 0;
+        } else if (content_len == 0) {
+            /* Blank line: emit as-is (no indentation), collapsing long runs */
+            if (++blank_run <= opts.max_blank_lines) fputc('\n', out);
         } else {
             /* Emit corrected indentation + original content */
-            int depth = (line_num <= max_line) ? depth_table[line_num] : 0;
+            int depth = (line_num <= fs.max_line) ? fs.depth[line_num] : 0;
             if (depth < 0) depth = 0;
-            for (int i = 0; i < depth * FMT_INDENT_WIDTH; i++) fputc(' ', out);
+            if (opts.use_tabs) {
+                for (int i = 0; i < depth; i++) fputc('\t', out);
+            } else {
+                for (int i = 0; i < depth * opts.indent_width; i++) fputc(' ', out);
+            }
             fwrite(content, 1, content_len, out);
             fputc('\n', out);
+            blank_run = 0;
         }
 
         line_num++;
@@ -169,6 +569,7 @@ int gray_fmt_source(const char *src, const char *filename, FILE *out) {
     /* Ensure file ends with exactly one newline (already handled above if
      * the source ended with \n; if it didn't, the last fputc above adds one). */
 
-    free(depth_table);
+    fmt_source_free(&fs);
+    free(restructured);
     return 0;
 }
@@ -1,7 +1,7 @@
 /*
  * fmt.h — Public interface for the Grayscale source formatter. Declares the
- * gray_fmt_source function that re-indents Grayscale source files while
- * preserving all content verbatim.
+ * gray_fmt_source function that re-indents Grayscale source files and its
+ * configuration options.
  *
  * Author:  Marshall A Burns (@SchoolyB)
  * Copyright (c) 2025-Present Marshall A Burns
@@ -11,18 +11,53 @@
 #ifndef GRAYC_FMT_H
 #define GRAYC_FMT_H
 
+#include <stdbool.h>
 #include <stdio.h>
 
+/* Where the '{' that opens a block goes. PRESERVE leaves it as written. */
+typedef enum {
+    GRAY_FMT_BRACE_PRESERVE = 0,
+    GRAY_FMT_BRACE_SAME_LINE,   /* do main() {                       */
+    GRAY_FMT_BRACE_NEXT_LINE,   /* do main()  then { on its own line */
+} GrayFmtBraceStyle;
+
+/*
+ * GrayFmtOptions:
+ *   range_start / range_end restrict re-indentation to an inclusive, 1-based
+ *   line range; lines outside it are emitted verbatim. 0 leaves that end of
+ *   the range unbounded. A range disables brace_style and max_line_length.
+ *
+ *   indent_width     spaces per level (0 = 4); ignored when use_tabs is set
+ *   max_blank_lines  longest run of blank lines kept, used only when
+ *                    max_blank_lines_set is true (otherwise 2); 0 removes
+ *                    every blank line
+ *   max_line_length  wrap call argument lists one per line when a line is
+ *                    longer than this (0 = never wrap). Wrapping also
+ *                    indents inside ( and [, not only inside braces.
+ */
+typedef struct {
+    int range_start;
+    int range_end;
+    int indent_width;
+    bool use_tabs;
+    int max_blank_lines;
+    bool max_blank_lines_set;
+    int max_line_length;
+    GrayFmtBraceStyle brace_style;
+} GrayFmtOptions;
+
 /*
  * gray_fmt_source:
  *   Format the Grayscale source in `src` (NUL-terminated) and write the result to
  *   `out`. Returns 0 on success, non-zero if the source could not be lexed.
+ *   `opts` may be NULL for default formatting of the whole file.
  *
  *   Strategy: lex the source to build a per-line indentation depth table,
  *   then re-emit each original source line with corrected leading whitespace.
- *   All content (comments, string literals, operators) is preserved verbatim —
- *   only the leading indentation of each line is touched.
+ *   All content (comments, string literals, operators) is preserved verbatim.
+ *   Beyond indentation, only blank-line runs, block brace placement, and
+ *   (when max_line_length is set) over-long call argument lists are changed.
  */
-int gray_fmt_source(const char *src, const char *filename, FILE *out);
+int gray_fmt_source(const char *src, const char *filename, FILE *out, const GrayFmtOptions *opts);
 
 #endif
@@ -12,6 +12,7 @@
 #include <stdlib.h>
 #include <string.h>
 #include <stdint.h>
+#include <limits.h>
 #include <fcntl.h>
 #include <unistd.h>
 #include <sys/stat.h>
@@ -31,6 +32,7 @@
 #include "typechecker/typechecker.h"
 #include "codegen/codegen.h"
 #include "fmt/fmt.h"
+#include "doc/decls.h"
 
 #ifndef GRAY_VERSION
 #define GRAY_VERSION "unknown"
@@ -48,6 +50,7 @@ static void print_usage(void) {
     fprintf(stderr, "  gray <file.gray> [options]         Compile and run\n");
     fprintf(stderr, "  gray build <file.gray> [options]   Compile to binary\n");
     fprintf(stderr, "  gray check <file.gray>             Type check only\n");
+    fprintf(stderr, "  gray check --module <file.gray>    Type check a module (no main() needed)\n");
     fprintf(stderr, "  gray version                       Show version\n");
     fprintf(stderr, "\nOptions:\n");
     fprintf(stderr, "  -o <file>       Output binary name (default: based on input filename)\n");
@@ -627,8 +630,13 @@ int main(int argc, char **argv) {
     const char *output_file = NULL;
     bool emit_c_only = false;
     bool check_only = false;
+    bool check_module = false;
     bool run_mode = false;
     bool fmt_mode = false;
+    bool dump_decls = false;
+    bool fmt_stdout = false;
+    GrayFmtOptions fmt_opts = {0};
+    const char *stdin_filename = "<stdin>";
     bool verbose = false;
     bool show_time = false;
     bool no_color = false;
@@ -692,6 +700,10 @@ int main(int argc, char **argv) {
             check_only = true;
             continue;
         }
+        if (strcmp(argv[i], "--module") == 0) {
+            check_module = true;
+            continue;
+        }
         if (strcmp(argv[i], "build") == 0 && !input_file) {
             /* build is the default — just skip the keyword */
             continue;
@@ -704,6 +716,76 @@ int main(int argc, char **argv) {
             fmt_mode = true;
             continue;
         }
+        if (strcmp(argv[i], "--dump-decls") == 0) {
+            dump_decls = true;
+            continue;
+        }
+        if (strcmp(argv[i], "--stdout") == 0) {
+            fmt_stdout = true;
+            continue;
+        }
+        if (strcmp(argv[i], "--range") == 0 && i + 1 < argc) {
+            if (sscanf(argv[++i], "%d:%d", &fmt_opts.range_start, &fmt_opts.range_end) != 2 ||
+                fmt_opts.range_start < 1 || fmt_opts.range_end < fmt_opts.range_start) {
+                fprintf(stderr, "gray: invalid --range '%s' (expected start:end)\n", argv[i]);
+                return 1;
+            }
+            continue;
+        }
+        if (strcmp(argv[i], "--indent-width") == 0 && i + 1 < argc) {
+            fmt_opts.indent_width = atoi(argv[++i]);
+            if (fmt_opts.indent_width < 1 || fmt_opts.indent_width > 16) {
+                fprintf(stderr, "gray: invalid --indent-width '%s' (expected 1-16)\n", argv[i]);
+                return 1;
+            }
+            continue;
+        }
+        if (strcmp(argv[i], "--use-tabs") == 0) {
+            fmt_opts.use_tabs = true;
+            continue;
+        }
+        if (strcmp(argv[i], "--max-blank-lines") == 0 && i + 1 < argc) {
+            char *end;
+            long n = strtol(argv[++i], &end, 10);
+            if (*argv[i] == '\0' || *end != '\0' || n < 0 || n > INT_MAX) {
+                fprintf(stderr, "gray: invalid --max-blank-lines '%s' (expected 0 or more)\n", argv[i]);
+                return 1;
+            }
+            fmt_opts.max_blank_lines = (int)n;
+            fmt_opts.max_blank_lines_set = true;
+            continue;
+        }
+        if (strcmp(argv[i], "--max-line-length") == 0 && i + 1 < argc) {
+            fmt_opts.max_line_length = atoi(argv[++i]);
+            if (fmt_opts.max_line_length < 0) {
+                fprintf(stderr, "gray: invalid --max-line-length '%s'\n", argv[i]);
+                return 1;
+            }
+            continue;
+        }
+        if (strcmp(argv[i], "--brace-style") == 0 && i + 1 < argc) {
+            const char *style = argv[++i];
+            if (strcmp(style, "same_line") == 0) {
+                fmt_opts.brace_style = GRAY_FMT_BRACE_SAME_LINE;
+            } else if (strcmp(style, "next_line") == 0) {
+                fmt_opts.brace_style = GRAY_FMT_BRACE_NEXT_LINE;
+            } else if (strcmp(style, "preserve") == 0) {
+                fmt_opts.brace_style = GRAY_FMT_BRACE_PRESERVE;
+            } else {
+                fprintf(stderr, "gray: invalid --brace-style '%s' (expected same_line, next_line, or preserve)\n", style);
+                return 1;
+            }
+            continue;
+        }
+        if (strcmp(argv[i], "--stdin-filename") == 0 && i + 1 < argc) {
+            stdin_filename = argv[++i];
+            continue;
+        }
+        if (strcmp(argv[i], "-") == 0) {
+            /* "-" reads source from stdin (fmt only) */
+            input_file = argv[i];
+            continue;
+        }
         if (argv[i][0] == '-') {
             fprintf(stderr, "gray: unknown option '%s'\n", argv[i]);
             return 1;
@@ -715,20 +797,34 @@ int main(int argc, char **argv) {
         fprintf(stderr, "gray: no input file\n");
         return 1;
     }
+    if (check_module && !check_only) {
+        fprintf(stderr, "gray: --module only applies to check\n");
+        return 1;
+    }
+
+    bool from_stdin = strcmp(input_file, "-") == 0;
+    if (from_stdin && !fmt_mode) {
+        fprintf(stderr, "gray: reading source from stdin is only supported with --fmt\n");
+        return 1;
+    }
 
     /* Read source file */
-    char *source = read_file(input_file);
+    char *source = read_file(from_stdin ? "/dev/stdin" : input_file);
     if (!source) return 1;
 
-    /* fmt mode: reformat and write back, then exit */
+    /* fmt mode: reformat and write back (or to stdout), then exit */
     if (fmt_mode) {
+        if (from_stdin) {
+            fmt_stdout = true;
+            input_file = stdin_filename;
+        }
         FILE *tmp = tmpfile();
         if (!tmp) {
             fprintf(stderr, "gray: fmt: could not create temp file\n");
             free(source);
             return 1;
         }
-        int rc = gray_fmt_source(source, input_file, tmp);
+        int rc = gray_fmt_source(source, input_file, tmp, &fmt_opts);
         if (rc != 0) {
             fprintf(stderr, "gray: fmt: failed to format '%s'\n", input_file);
             fclose(tmp);
@@ -747,6 +843,12 @@ int main(int argc, char **argv) {
         }
         fmt_buf[fmt_len] = '\0';
         fclose(tmp);
+        if (fmt_stdout) {
+            fwrite(fmt_buf, 1, fmt_len, stdout);
+            free(fmt_buf);
+            free(source);
+            return 0;
+        }
         /* Write back to the original file with explicit 0644 permissions */
         int wfd = open(input_file, O_WRONLY | O_CREAT | O_TRUNC, 0644);
         if (wfd < 0) {
@@ -832,6 +934,16 @@ int main(int argc, char **argv) {
         return 1;
     }
 
+    /* --dump-decls: print this file's imports and declarations for gray
+     * doc, then exit (imports are not resolved and nothing is type-checked) */
+    if (dump_decls) {
+        gray_dump_decls(stdout, program, input_file, source, arena);
+        diagnostic_destroy(diag);
+        arena_destroy(arena);
+        free(source);
+        return 0;
+    }
+
     /* Resolve local imports: parse imported .gray files and merge declarations */
     {
         /* Mark the main file as already imported (prevents circular import loops).
@@ -1503,6 +1615,7 @@ int main(int argc, char **argv) {
 
     /* Type check */
     TypeChecker *checker =typechecker_create(diag, input_file);
+    checker->is_module = check_module;
     typechecker_check(checker, program);
 
     if (diagnostic_has_errors(diag)) {
@@ -70,6 +70,16 @@ typedef enum {
 /* Forward declaration */
 typedef struct AstNode AstNode;
 
+/* Source extent of an expression or type: positions of its first and last
+ * tokens. Recorded for declarations so tools can quote them verbatim
+ * (type names are canonicalised, e.g. mod.Type becomes mod_Type). */
+typedef struct {
+    int line;
+    int column;
+    int end_line;
+    int end_column;
+} SrcSpan;
+
 /* Parameter for function declarations */
 typedef struct {
     const char *name;
@@ -77,6 +87,8 @@ typedef struct {
     bool mutable;
     bool is_type_param;    /* true when declared with <?> syntax */
     AstNode *default_value;
+    SrcSpan type_span;
+    SrcSpan default_span;
 } Param;
 
 /* Field in struct declaration */
@@ -84,6 +96,8 @@ typedef struct {
     const char *name;
     const char *type_name;
     AstNode *default_value;
+    SrcSpan type_span;
+    SrcSpan default_span;
 } StructField;
 
 /* Function in struct declaration (namespaced free function) */
@@ -96,6 +110,7 @@ typedef struct {
 typedef struct {
     const char *name;
     AstNode *value;              /* optional explicit value (plain enums only) */
+    SrcSpan value_span;
     const char **payload_types;  /* NULL if no payload */
     int payload_count;           /* 0 for plain variants */
 } EnumVal;
@@ -122,6 +137,7 @@ typedef struct {
 struct AstNode {
     NodeKind kind;
     Token token;
+    const char *doc; /* #doc text on declarations, NULL if none */
 
     union {
         /* NODE_LABEL */
@@ -227,6 +243,8 @@ struct AstNode {
             const char *original_name; /* pre-prefix name for error messages */
             const char *type_name;
             AstNode *value;
+            SrcSpan type_span;
+            SrcSpan value_span;
             bool mutable;
             bool is_private;
         } var_decl;
@@ -297,6 +315,7 @@ struct AstNode {
             int param_count;
             const char **return_types;
             const char **return_names; /* Named return params (NULL if unnamed) */
+            SrcSpan *return_spans;     /* Source extent of each return type */
             int return_type_count;
             AstNode *body;
             bool is_private;
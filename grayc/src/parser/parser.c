@@ -152,6 +152,69 @@ static bool expect_peek_token(Parser *parser, TokenType type) {
     return false;
 }
 
+/* Parse an expression and record where its first and last tokens sit.
+ * Pratt parsing leaves cur_token on the last token of the expression. */
+static AstNode *parse_expression_span(Parser *parser, SrcSpan *span) {
+    span->line = parser->cur_token.line;
+    span->column = parser->cur_token.column;
+    AstNode *expr = parse_expression(parser, PREC_LOWEST);
+    span->end_line = parser->cur_token.line;
+    span->end_column = parser->cur_token.column;
+    return expr;
+}
+
+/* Decode the escapes of a "..." literal (the lexer keeps them verbatim).
+ * Only what can appear in documentation text is handled. */
+static const char *unescape_doc_string(Parser *parser, const char *lit) {
+    size_t len = strlen(lit);
+    char *out = arena_alloc(parser->arena, len + 1);
+    size_t n = 0;
+    for (size_t i = 0; i < len; i++) {
+        if (lit[i] != '\\' || i + 1 >= len) {
+            out[n++] = lit[i];
+            continue;
+        }
+        char c = lit[++i];
+        switch (c) {
+        case 'n': out[n++] = '\n'; break;
+        case 't': out[n++] = '\t'; break;
+        case 'r': out[n++] = '\r'; break;
+        case '0': out[n++] = '\0'; break;
+        default:  out[n++] = c; break; /* \\ \" \' \$ */
+        }
+    }
+    out[n] = '\0';
+    return out;
+}
+
+/* Consume a #doc attribute (cur_token is #doc) and return its text, or
+ * NULL when it has no string argument. Leaves cur_token on the ')'. */
+static const char *parse_doc_attribute(Parser *parser) {
+    const char *text = NULL;
+    if (!peek_token_is(parser, TOK_LPAREN)) return NULL;
+    next_token(parser);
+    while (!current_token_is(parser, TOK_RPAREN) && !current_token_is(parser, TOK_EOF)) {
+        if (!text && current_token_is(parser, TOK_STRING))
+            text = unescape_doc_string(parser, parser->cur_token.literal);
+        else if (!text && current_token_is(parser, TOK_RAW_STRING))
+            text = parser->cur_token.literal;
+        next_token(parser);
+    }
+    return text;
+}
+
+/* Join two doc texts with a newline; either may be NULL. */
+static const char *join_doc(Parser *parser, const char *first, const char *second) {
+    if (!first) return second;
+    if (!second) return first;
+    size_t a = strlen(first), b = strlen(second);
+    char *joined = arena_alloc(parser->arena, a + b + 2);
+    memcpy(joined, first, a);
+    joined[a] = '\n';
+    memcpy(joined + a + 1, second, b + 1);
+    return joined;
+}
+
 /* Check if a token type is a keyword (reserved word) */
 static bool is_keyword_token(TokenType type) {
     switch (type) {
@@ -499,6 +562,16 @@ static const char *parse_complex_type(Parser *parser) {
     }
 }
 
+/* parse_complex_type, also recording the extent of the type as written */
+static const char *parse_type_span(Parser *parser, SrcSpan *span) {
+    span->line = parser->cur_token.line;
+    span->column = parser->cur_token.column;
+    const char *type_name = parse_complex_type(parser);
+    span->end_line = parser->cur_token.line;
+    span->end_column = parser->cur_token.column;
+    return type_name;
+}
+
 static AstNode *parse_identifier(Parser *parser) {
     AstNode *node = ast_alloc(parser->arena, NODE_LABEL, parser->cur_token);
     node->data.label.value = parser->cur_token.literal;
@@ -1450,7 +1523,7 @@ static AstNode *parse_var_declaration(Parser *parser) {
         peek_token_is(parser, TOK_STRUCT) || peek_token_is(parser, TOK_ENUM) ||
         peek_token_is(parser, TOK_QUESTION)) {
         next_token(parser);
-        node->data.var_decl.type_name = parse_complex_type(parser);
+        node->data.var_decl.type_name = parse_type_span(parser, &node->data.var_decl.type_span);
         if (!node->data.var_decl.type_name) return NULL;
         /* E2070: wildcard `?` only allowed in function signatures */
         if (type_string_has_wildcard(node->data.var_decl.type_name)) {
@@ -1563,7 +1636,7 @@ static AstNode *parse_var_declaration(Parser *parser) {
     if (peek_token_is(parser, TOK_ASSIGN)) {
         next_token(parser); /* skip = */
         next_token(parser);
-        node->data.var_decl.value = parse_expression(parser, PREC_LOWEST);
+        node->data.var_decl.value = parse_expression_span(parser, &node->data.var_decl.value_span);
 
         if (node->data.var_decl.value &&
             strcmp(node->data.var_decl.name, "_") == 0) {
@@ -1735,7 +1808,7 @@ static AstNode *parse_func_declaration(Parser *parser) {
                     param->type_name = "?";
                     param->is_type_param = true;
                 } else {
-                    param->type_name = parse_complex_type(parser);
+                    param->type_name = parse_type_span(parser, &param->type_span);
                     if (!param->type_name) return NULL;
                 }
             } else if (peek_token_is(parser, TOK_AMPERSAND)) {
@@ -1750,7 +1823,7 @@ static AstNode *parse_func_declaration(Parser *parser) {
             if (peek_token_is(parser, TOK_ASSIGN)) {
                 next_token(parser); /* skip = */
                 next_token(parser);
-                param->default_value = parse_expression(parser, PREC_LOWEST);
+                param->default_value = parse_expression_span(parser, &param->default_span);
             }
 
             node->data.func_decl.param_count++;
@@ -1781,8 +1854,10 @@ static AstNode *parse_func_declaration(Parser *parser) {
         Param *p_i = &node->data.func_decl.params[i];
         if (!p_i->type_name && i + 1 < node->data.func_decl.param_count) {
             p_i->type_name = node->data.func_decl.params[i + 1].type_name;
+            p_i->type_span = node->data.func_decl.params[i + 1].type_span;
             if (!p_i->default_value && node->data.func_decl.params[i + 1].default_value) {
                 p_i->default_value = node->data.func_decl.params[i + 1].default_value;
+                p_i->default_span = node->data.func_decl.params[i + 1].default_span;
             }
         }
         if (!p_i->type_name && !p_i->default_value) {
@@ -1849,6 +1924,7 @@ static AstNode *parse_func_declaration(Parser *parser) {
         node->data.func_decl.return_types = arena_alloc(parser->arena, sizeof(const char *) * ret_cap);
         node->data.func_decl.return_names = arena_alloc(parser->arena, sizeof(const char *) * ret_cap);
         memset(node->data.func_decl.return_names, 0, sizeof(const char *) * ret_cap);
+        node->data.func_decl.return_spans = arena_alloc(parser->arena, sizeof(SrcSpan) * ret_cap);
 
         if (current_token_is(parser, TOK_LPAREN)) {
             /* Multiple/named return types:
@@ -1904,7 +1980,7 @@ static AstNode *parse_func_declaration(Parser *parser) {
                         return NULL;
                     }
                     node->data.func_decl.return_names[idx] = ret_name;
-                    node->data.func_decl.return_types[idx] = parse_complex_type(parser);
+                    node->data.func_decl.return_types[idx] = parse_type_span(parser, &node->data.func_decl.return_spans[idx]);
                     node->data.func_decl.return_type_count++;
                 } else if (current_token_is(parser, TOK_IDENT) && peek_token_is(parser, TOK_COMMA) && !is_type) {
                     /* Shared type: (x, y int); collect names, assign same type */
@@ -1921,6 +1997,7 @@ static AstNode *parse_func_declaration(Parser *parser) {
                     /* cur is last name, peek should be the shared type */
                     if (peek_token_is(parser, TOK_IDENT)) {
                         next_token(parser);
+                        SrcSpan shared_span = {parser->cur_token.line, parser->cur_token.column, 0, 0};
                         for (int s = 0; s < shared; s++) {
                             int idx = node->data.func_decl.return_type_count;
                             if (idx >= ret_cap) {
@@ -1929,6 +2006,9 @@ static AstNode *parse_func_declaration(Parser *parser) {
                             }
                             node->data.func_decl.return_names[idx] = names[s];
                             node->data.func_decl.return_types[idx] = read_type_name(parser);
+                            shared_span.end_line = parser->cur_token.line;
+                            shared_span.end_column = parser->cur_token.column;
+                            node->data.func_decl.return_spans[idx] = shared_span;
                             node->data.func_decl.return_type_count++;
                         }
                     }
@@ -1942,7 +2022,7 @@ static AstNode *parse_func_declaration(Parser *parser) {
                         return NULL;
                     }
                     node->data.func_decl.return_names[idx] = NULL;
-                    node->data.func_decl.return_types[idx] = parse_complex_type(parser);
+                    node->data.func_decl.return_types[idx] = parse_type_span(parser, &node->data.func_decl.return_spans[idx]);
                     node->data.func_decl.return_type_count++;
                 }
                 if (peek_token_is(parser, TOK_COMMA)) {
@@ -1952,7 +2032,7 @@ static AstNode *parse_func_declaration(Parser *parser) {
             }
         } else {
             /* Single return type (array, pointer, map, or plain) */
-            node->data.func_decl.return_types[0] = parse_complex_type(parser);
+            node->data.func_decl.return_types[0] = parse_type_span(parser, &node->data.func_decl.return_spans[0]);
             if (!node->data.func_decl.return_types[0]) return NULL;
             node->data.func_decl.return_type_count = 1;
 
@@ -2165,21 +2245,18 @@ static AstNode *parse_struct_declaration(Parser *parser) {
     int prev_field_line = -1;
     int field_cap = 8;
     int func_cap = 4;
+    const char *pending_doc = NULL; /* #doc text waiting for its struct function */
     node->data.struct_decl.field_count = 0;
     node->data.struct_decl.fields = arena_alloc(parser->arena, sizeof(StructField) * field_cap);
     node->data.struct_decl.func_count = 0;
     node->data.struct_decl.funcs = arena_alloc(parser->arena, sizeof(StructFunc) * func_cap);
 
     while (!current_token_is(parser, TOK_RBRACE) && !current_token_is(parser, TOK_EOF)) {
-        /* : skip #doc attributes on struct functions. Consume
-         * the attribute + any parenthesised args, then continue so
-         * the next token (do/private do) is handled normally. */
+        /* #doc attributes on struct functions. Consume the attribute +
+         * any parenthesised args and hold the text for the next function;
+         * continue so the next token (do/private do) is handled normally. */
         if (current_token_is(parser, TOK_DOC)) {
-            if (peek_token_is(parser, TOK_LPAREN)) {
-                next_token(parser);
-                while (!current_token_is(parser, TOK_RPAREN) && !current_token_is(parser, TOK_EOF))
-                    next_token(parser);
-            }
+            pending_doc = join_doc(parser, pending_doc, parse_doc_attribute(parser));
             next_token(parser);
             continue;
         }
@@ -2187,6 +2264,7 @@ static AstNode *parse_struct_declaration(Parser *parser) {
         if (current_token_is(parser, TOK_DO)) {
             AstNode *fn = parse_func_declaration(parser);
             if (fn) {
+                fn->doc = pending_doc;
                 if (node->data.struct_decl.func_count >= func_cap) {
                     func_cap *= 2;
                     StructFunc *new_funcs = arena_alloc(parser->arena, sizeof(StructFunc) * func_cap);
@@ -2196,6 +2274,7 @@ static AstNode *parse_struct_declaration(Parser *parser) {
                 }
                 node->data.struct_decl.funcs[node->data.struct_decl.func_count++].func_decl = fn;
             }
+            pending_doc = NULL;
             next_token(parser);
             continue;
         }
@@ -2204,6 +2283,7 @@ static AstNode *parse_struct_declaration(Parser *parser) {
             AstNode *fn = parse_func_declaration(parser);
             if (fn) {
                 fn->data.func_decl.is_private = true;
+                fn->doc = pending_doc;
                 if (node->data.struct_decl.func_count >= func_cap) {
                     func_cap *= 2;
                     StructFunc *new_funcs = arena_alloc(parser->arena, sizeof(StructFunc) * func_cap);
@@ -2215,6 +2295,7 @@ static AstNode *parse_struct_declaration(Parser *parser) {
                 node->data.struct_decl.funcs[idx].func_decl = fn;
                 node->data.struct_decl.funcs[idx].is_private = true;
             }
+            pending_doc = NULL;
             next_token(parser);
             continue;
         }
@@ -2337,7 +2418,8 @@ static AstNode *parse_struct_declaration(Parser *parser) {
             }
         }
         /* Current token is now the type; parse it and backfill all names in this group */
-        const char *type_name = parse_complex_type(parser);
+        SrcSpan type_span;
+        const char *type_name = parse_type_span(parser, &type_span);
         if (!type_name) return NULL;
         /* E2070: wildcard `?` is not allowed as a struct field type */
         if (type_string_has_wildcard(type_name)) {
@@ -2349,6 +2431,7 @@ static AstNode *parse_struct_declaration(Parser *parser) {
         }
         for (int i = group_start; i < node->data.struct_decl.field_count; i++) {
             node->data.struct_decl.fields[i].type_name = type_name;
+            node->data.struct_decl.fields[i].type_span = type_span;
             node->data.struct_decl.fields[i].default_value = NULL;
         }
         next_token(parser);
@@ -2356,9 +2439,11 @@ static AstNode *parse_struct_declaration(Parser *parser) {
         /* Parse optional default value: `= expr` */
         if (current_token_is(parser, TOK_ASSIGN)) {
             next_token(parser); /* skip '=' */
-            AstNode *def = parse_expression(parser, PREC_LOWEST);
+            SrcSpan def_span;
+            AstNode *def = parse_expression_span(parser, &def_span);
             for (int i = group_start; i < node->data.struct_decl.field_count; i++) {
                 node->data.struct_decl.fields[i].default_value = def;
+                node->data.struct_decl.fields[i].default_span = def_span;
             }
             next_token(parser);
         }
@@ -2531,7 +2616,7 @@ static AstNode *parse_enum_declaration(Parser *parser) {
             }
             next_token(parser); /* skip = */
             next_token(parser);
-            ev->value = parse_expression(parser, PREC_LOWEST);
+            ev->value = parse_expression_span(parser, &ev->value_span);
         }
 
         node->data.enum_decl.value_count++;
@@ -3118,16 +3203,15 @@ static AstNode *parse_statement(Parser *parser) {
         }
         next_token(parser);
         return parse_statement(parser);
-    case TOK_DOC:
-        /* Skip #doc attribute tokens; consume args if present */
-        if (peek_token_is(parser, TOK_LPAREN)) {
-            next_token(parser);
-            while (!current_token_is(parser, TOK_RPAREN) && !current_token_is(parser, TOK_EOF)) {
-                next_token(parser);
-            }
-        }
+    case TOK_DOC: {
+        /* Record the #doc text on the declaration that follows */
+        const char *doc = parse_doc_attribute(parser);
         next_token(parser);
-        return parse_statement(parser);
+        /* Stacked attributes recurse; the outer (earlier) #doc comes first */
+        AstNode *decl = parse_statement(parser);
+        if (decl) decl->doc = join_doc(parser, doc, decl->doc);
+        return decl;
+    }
     case TOK_ENSURE:
         return parse_ensure_statement(parser);
     case TOK_BLANK:
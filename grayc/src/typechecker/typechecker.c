@@ -11753,7 +11753,7 @@ void typechecker_check(TypeChecker *checker, AstNode *program) {
     }
 
     /* Verify main() exists */
-    if (!find_func(checker, "main")) {
+    if (!checker->is_module && !find_func(checker, "main")) {
         /* Point at the last statement or line 1 if empty */
         int err_line = 1;
         if (program->data.program.stmt_count > 0) {
@@ -11781,7 +11781,7 @@ void typechecker_check(TypeChecker *checker, AstNode *program) {
     }
 
     /* Warn about unused functions (skip main and struct-namespaced) */
-    for (int i = 0; i < checker->func_count; i++) {
+    for (int i = 0; !checker->is_module && i < checker->func_count; i++) {
         FuncSig *fs = &checker->funcs[i];
         if (!fs->used && fs->def_line > 0 &&
             strcmp(fs->name, "main") != 0 &&
@@ -68,6 +68,10 @@ typedef struct {
     TypeTable *type_table;
     const char *file;
 
+    /* Checking a module on its own (grayc check --module): it needs no
+     * main(), and its functions are called by the files importing it. */
+    bool is_module;
+
     /* Registered struct types */
     StructInfo *structs;
     int struct_count;
@@ -371,6 +371,16 @@ void diagnostic_print_summary(DiagnosticList *diagnostics) {
         fprintf(stderr, "\n");
     }
 
+    /* Point at the long-form explanation of the first error */
+    for (int i = 0; errors > 0 && i < diagnostics->count; i++) {
+        const char *code = diagnostics->items[i].code;
+        if (diagnostics->items[i].severity == SEV_ERROR && code && code[0]) {
+            fprintf(stderr, "%shint:%s run `gray explain %s` for more about this error\n",
+                col(diagnostics, COL_BOLD), col(diagnostics, COL_RESET), code);
+            break;
+        }
+    }
+
     if (warnings > 0 && !diagnostics->suppress_all_warnings && diagnostics->suppressed_count == 0) {
         fprintf(stderr, "%shint:%s suppress warnings with -q <W1001,W1002,...> or -q 'all'\n",
             col(diagnostics, COL_BOLD), col(diagnostics, COL_RESET));
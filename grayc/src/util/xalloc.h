@@ -42,14 +42,14 @@ static inline void *xcalloc(size_t nmemb, size_t size) {
 }
 
 /* Read an entire seekable file into a malloc'd NUL-terminated string.
- * Returns NULL on open failure or OOM. */
+ * Returns NULL on open failure, OOM, or a non-seekable input (pipe). */
 static inline char *read_file_to_string(const char *path) {
     FILE *f = fopen(path, "rb");
     if (!f) return NULL;
 
-    fseek(f, 0, SEEK_END);
+    if (fseek(f, 0, SEEK_END) != 0) { fclose(f); return NULL; }
     long size = ftell(f);
-    fseek(f, 0, SEEK_SET);
+    if (size < 0 || fseek(f, 0, SEEK_SET) != 0) { fclose(f); return NULL; }
 
     char *buf = malloc((size_t)size + 1);
     if (!buf) { fclose(f); return NULL; }
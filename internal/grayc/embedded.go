@@ -17,6 +17,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 )
 
@@ -143,3 +144,35 @@ func writeIfMissing(path string, data []byte, mode fs.FileMode) error {
 	}
 	return nil
 }
+
+// StdlibHeader is one embedded stdlib header.
+type StdlibHeader struct {
+	Name string // file name, e.g. "strings.h"
+	Data []byte
+}
+
+// StdlibHeaders returns the stdlib headers embedded alongside grayc, in
+// name order, so callers can read the @man blocks of the stdlib that
+// ships in this binary. It returns ErrNoEmbed for a dev build whose
+// runtime assets were not staged.
+func StdlibHeaders() ([]StdlibHeader, error) {
+	entries, err := fs.ReadDir(embeddedSrc, "runtime/src/stdlib")
+	if err != nil {
+		return nil, ErrNoEmbed
+	}
+	var headers []StdlibHeader
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".h") {
+			continue
+		}
+		data, err := embeddedSrc.ReadFile("runtime/src/stdlib/" + e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read embedded %s: %w", e.Name(), err)
+		}
+		headers = append(headers, StdlibHeader{Name: e.Name(), Data: data})
+	}
+	if len(headers) == 0 {
+		return nil, ErrNoEmbed
+	}
+	return headers, nil
+}
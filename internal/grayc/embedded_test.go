@@ -96,3 +96,21 @@ func TestWriteIfMissing_SetsMode(t *testing.T) {
 		t.Errorf("expected execute bit set, got mode %v", info.Mode())
 	}
 }
+
+func TestStdlibHeaders(t *testing.T) {
+	headers, err := StdlibHeaders()
+	if err == ErrNoEmbed {
+		t.Skip("runtime assets not staged")
+	}
+	if err != nil {
+		t.Fatalf("StdlibHeaders: %v", err)
+	}
+	for i, h := range headers {
+		if filepath.Ext(h.Name) != ".h" || len(h.Data) == 0 {
+			t.Errorf("header %q (%d bytes)", h.Name, len(h.Data))
+		}
+		if i > 0 && headers[i-1].Name >= h.Name {
+			t.Errorf("headers out of order: %s before %s", headers[i-1].Name, h.Name)
+		}
+	}
+}
@@ -1,5 +1,6 @@
 // grayc.go — Go wrapper for locating and invoking the grayc compiler binary.
-// Provides Find, Build, Run, Check, Fmt, and Version entry points used by the gray CLI.
+// Provides Find, Build, Run, RunOutput, Check, CheckOutput, Fmt, FmtSource,
+// DumpDecls, and Version entry points used by the gray CLI.
 //
 // Author:  Marshall A Burns (@SchoolyB)
 // Copyright (c) 2025-Present Marshall A Burns
@@ -8,10 +9,12 @@
 package grayc
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -95,6 +98,29 @@ func Run(file string, extraArgs []string) (int, error) {
 	return execute(graycPath, args)
 }
 
+// RunOutput compiles and executes a source file like Run but captures the
+// program's stdout and grayc's stderr instead of streaming them.
+func RunOutput(file string, extraArgs []string) (stdout, stderr []byte, code int, err error) {
+	graycPath, err := Find()
+	if err != nil {
+		return nil, nil, 1, err
+	}
+
+	args := []string{"run", file}
+	args = append(args, extraArgs...)
+	var outBuf, errBuf bytes.Buffer
+	cmd := exec.Command(graycPath, args...)
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return outBuf.Bytes(), errBuf.Bytes(), exitErr.ExitCode(), nil
+		}
+		return outBuf.Bytes(), errBuf.Bytes(), 1, err
+	}
+	return outBuf.Bytes(), errBuf.Bytes(), 0, nil
+}
+
 // Build compiles a Grayscale source file to a native binary via grayc build.
 func Build(file string, opts BuildOpts) (int, error) {
 	graycPath, err := Find()
@@ -145,6 +171,50 @@ func Check(file string, extraArgs []string) (int, error) {
 	return execute(graycPath, args)
 }
 
+// CheckOutput type-checks a file like Check but captures grayc's combined
+// stdout and stderr instead of streaming it, so several checks can run
+// concurrently and be printed in a stable order.
+func CheckOutput(file string, extraArgs []string) ([]byte, int, error) {
+	graycPath, err := Find()
+	if err != nil {
+		return nil, 1, err
+	}
+
+	args := []string{"check", file}
+	args = append(args, extraArgs...)
+	cmd := exec.Command(graycPath, args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return out, exitErr.ExitCode(), nil
+		}
+		return out, 1, err
+	}
+	return out, 0, nil
+}
+
+// DumpDecls parses file and returns grayc's JSON description of its
+// top-level declarations (grayc --dump-decls). Nothing is type-checked.
+// Parse errors are returned with the compiler's diagnostics as the message.
+func DumpDecls(file string) ([]byte, error) {
+	graycPath, err := Find()
+	if err != nil {
+		return nil, err
+	}
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := exec.Command(graycPath, "--dump-decls", "--no-color", file)
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(errBuf.String()); msg != "" {
+			return nil, fmt.Errorf("%s", msg)
+		}
+		return nil, err
+	}
+	return outBuf.Bytes(), nil
+}
+
 // Version returns the grayc compiler version string.
 func Version() (string, error) {
 	graycPath, err := Find()
@@ -161,14 +231,90 @@ func Version() (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
+// FmtOpts configures a format invocation.
+type FmtOpts struct {
+	RangeStart int    // 1-based first line to re-indent; 0 formats from the top
+	RangeEnd   int    // 1-based last line (inclusive); 0 formats to EOF
+	Filename   string // name reported in diagnostics for in-memory source
+
+	IndentWidth   int    // spaces per indentation level; 0 uses grayc's default
+	UseTabs       bool   // indent with tabs instead of spaces
+	MaxBlankLines *int   // longest blank-line run kept; nil uses grayc's default
+	MaxLineLength int    // wrap long call argument lists; 0 never wraps
+	BraceStyle    string // "same_line", "next_line", or "" / "preserve"
+}
+
+// args returns the grayc flags for opts.
+func (o FmtOpts) args() []string {
+	var args []string
+	if o.RangeStart > 0 && o.RangeEnd > 0 {
+		args = append(args, "--range", fmt.Sprintf("%d:%d", o.RangeStart, o.RangeEnd))
+	}
+	if o.Filename != "" {
+		args = append(args, "--stdin-filename", o.Filename)
+	}
+	if o.IndentWidth > 0 {
+		args = append(args, "--indent-width", strconv.Itoa(o.IndentWidth))
+	}
+	if o.UseTabs {
+		args = append(args, "--use-tabs")
+	}
+	if o.MaxBlankLines != nil {
+		args = append(args, "--max-blank-lines", strconv.Itoa(*o.MaxBlankLines))
+	}
+	if o.MaxLineLength > 0 {
+		args = append(args, "--max-line-length", strconv.Itoa(o.MaxLineLength))
+	}
+	if o.BraceStyle != "" && o.BraceStyle != "preserve" {
+		args = append(args, "--brace-style", o.BraceStyle)
+	}
+	return args
+}
+
+// FmtResult is the outcome of formatting in-memory source.
+type FmtResult struct {
+	Output      []byte // formatted source; nil when Code != 0
+	Diagnostics string // grayc's stderr (parse errors and the like)
+	Code        int    // grayc exit code
+}
+
 // Fmt formats a single .gray file in place using the grayc --fmt flag.
 // Returns 0 on success, non-zero on failure.
-func Fmt(file string) (int, error) {
+func Fmt(file string, opts FmtOpts) (int, error) {
 	graycPath, err := Find()
 	if err != nil {
 		return 1, err
 	}
-	return executeSilent(graycPath, []string{"--fmt", file})
+	args := append([]string{"--fmt"}, opts.args()...)
+	return executeSilent(graycPath, append(args, file))
+}
+
+// FmtSource formats Grayscale source held in memory. The source is piped
+// to `grayc --fmt -` and the formatted result is returned without
+// touching disk. Diagnostics are captured rather than printed so callers
+// can report them per file.
+func FmtSource(src []byte, opts FmtOpts) (FmtResult, error) {
+	graycPath, err := Find()
+	if err != nil {
+		return FmtResult{Code: 1}, err
+	}
+	args := append([]string{"--fmt"}, opts.args()...)
+	cmd := exec.Command(graycPath, append(args, "-")...)
+	cmd.Stdin = bytes.NewReader(src)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	res := FmtResult{Diagnostics: stderr.String()}
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			res.Code = exitErr.ExitCode()
+			return res, nil
+		}
+		res.Code = 1
+		return res, err
+	}
+	res.Output = out
+	return res, nil
 }
 
 // executeSilent runs grayc without streaming I/O, for use by fmt/check internals.
@@ -10,6 +10,7 @@ package grayc
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -93,3 +94,69 @@ func TestFindCompilerPathOverride(t *testing.T) {
 		}
 	})
 }
+
+func TestFmtOptsArgs(t *testing.T) {
+	if got := (FmtOpts{}).args(); len(got) != 0 {
+		t.Errorf("zero FmtOpts args = %v, want none", got)
+	}
+	got := FmtOpts{RangeStart: 3, RangeEnd: 9}.args()
+	if len(got) != 2 || got[0] != "--range" || got[1] != "3:9" {
+		t.Errorf("range args = %v", got)
+	}
+	got = FmtOpts{Filename: "app.gray"}.args()
+	if len(got) != 2 || got[0] != "--stdin-filename" || got[1] != "app.gray" {
+		t.Errorf("filename args = %v", got)
+	}
+	one, zero := 1, 0
+	got = FmtOpts{IndentWidth: 2, UseTabs: true, MaxBlankLines: &one, MaxLineLength: 80, BraceStyle: "next_line"}.args()
+	want := "--indent-width 2 --use-tabs --max-blank-lines 1 --max-line-length 80 --brace-style next_line"
+	if strings.Join(got, " ") != want {
+		t.Errorf("style args = %v, want %s", got, want)
+	}
+	if got := (FmtOpts{BraceStyle: "preserve"}).args(); len(got) != 0 {
+		t.Errorf("preserve brace style args = %v, want none", got)
+	}
+	if got := strings.Join(FmtOpts{MaxBlankLines: &zero}.args(), " "); got != "--max-blank-lines 0" {
+		t.Errorf("zero blank lines args = %q", got)
+	}
+}
+
+func TestFmtSourceIndent(t *testing.T) {
+	if _, err := Find(); err != nil {
+		t.Skip("grayc not available")
+	}
+	src := "do main() {\nprintln(add(1,\n2))\nxs := [\n1,\n]\nif true {\nprintln(1)\n}}\n"
+	// Only braces nest by default; the second '}' on a line does not
+	// dedent it again.
+	want := "do main() {\n    println(add(1,\n    2))\n    xs := [\n    1,\n    ]\n    if true {\n        println(1)\n    }}\n"
+	res, err := FmtSource([]byte(src), FmtOpts{})
+	if err != nil || res.Code != 0 {
+		t.Fatalf("FmtSource = %+v, %v", res, err)
+	}
+	if string(res.Output) != want {
+		t.Errorf("default indent:\n%s\nwant:\n%s", res.Output, want)
+	}
+
+	// With wrapping on, ( and [ nest too.
+	res, err = FmtSource([]byte(src), FmtOpts{MaxLineLength: 100})
+	if err != nil || res.Code != 0 {
+		t.Fatalf("FmtSource = %+v, %v", res, err)
+	}
+	if !strings.Contains(string(res.Output), "    println(add(1,\n        2))\n") {
+		t.Errorf("wrapping indent:\n%s", res.Output)
+	}
+}
+
+func TestFmtSourceNoBlankLines(t *testing.T) {
+	if _, err := Find(); err != nil {
+		t.Skip("grayc not available")
+	}
+	zero := 0
+	res, err := FmtSource([]byte("a := 1\n\n\nb := 2\n"), FmtOpts{MaxBlankLines: &zero})
+	if err != nil || res.Code != 0 {
+		t.Fatalf("FmtSource = %+v, %v", res, err)
+	}
+	if string(res.Output) != "a := 1\nb := 2\n" {
+		t.Errorf("max_blank_lines = 0 kept blank lines: %q", res.Output)
+	}
+}
@@ -0,0 +1,86 @@
+#!/usr/bin/env bash
+# generate_explain.sh — extract error, warning, and panic codes for gray explain
+# Usage: ./scripts/generate_explain.sh
+# Reads grayc/src/util/error_codes.h and the smallest integration test that
+# triggers each code (integration-tests/fail/errors/<code>_*.gray, or
+# integration-tests/pass/warnings/<code>_*.gray for warnings), without the
+# test's header comment.
+# Generates cli/explain_codes_data.go (committed, do not edit by hand).
+set -e
+
+SCRIPT_DIR="$(cd "$(dirname "$0")" && pwd)"
+ROOT="$(dirname "$SCRIPT_DIR")"
+CODES_FILE="$ROOT/grayc/src/util/error_codes.h"
+OUT="$ROOT/cli/explain_codes_data.go"
+
+# strip_test_header FILE — print FILE without the comment the test harness
+# reads (/* Error Test: ... Expected: ... */, or // Test: lines) and the
+# blank lines after it, leaving just the failing program.
+strip_test_header() {
+  sed 's/\r$//' "$1" |
+  awk '
+    state == 0 && /^[ \t]*\/\*/   { state = 1 }
+    state == 0 && /^[ \t]*\/\/.*Test/ { state = 2 }
+    state == 0 && !/^[ \t]*$/ { state = 3 }
+    state == 1 { if (/\*\//) state = 4; next }
+    state == 2 { if (/^[ \t]*\/\//) next; state = 4 }
+    state == 4 && /^[ \t]*$/ { next }
+    state == 4 { state = 3 }
+    { print }
+  '
+}
+
+# go_string — print stdin as the body of a Go string literal.
+go_string() {
+  sed -e 's/\\/\\\\/g' -e 's/"/\\"/g' -e 's/\t/\\t/g' |
+  awk '
+    { lines[NR] = $0 }
+    END {
+      n = NR
+      while (n > 0 && lines[n] == "") n--
+      for (i = 1; i <= n; i++) printf "%s%s", lines[i], (i < n ? "\\n" : "")
+    }
+  '
+}
+
+{
+  echo "// Code generated by scripts/generate_explain.sh — do not edit."
+  echo "package main"
+  echo ""
+  echo "// ErrorCodeEntry holds the registry data for one error, warning, or panic code."
+  echo "type ErrorCodeEntry struct {"
+  echo "	Kind        string // \"error\", \"warning\", or \"panic\""
+  echo "	Category    string"
+  echo "	Message     string // message template from error_codes.h"
+  echo "	Example     string // smallest integration test that triggers the code"
+  echo "	ExampleFile string"
+  echo "}"
+  echo ""
+  echo "// errorCodeDocs is the lookup table used by gray explain <code>."
+  echo "var errorCodeDocs = map[string]ErrorCodeEntry{"
+  # The message is copied as written: the C escapes used in error_codes.h
+  # (\\ and \") mean the same in a Go string literal.
+  sed -n 's/^ *GRAY_\(ERROR\|WARNING\|PANIC\)("\([^"]*\)", *"\([^"]*\)", *"\(.*\)") *\\\{0,1\}$/\1\t\2\t\3\t\4/p' "$CODES_FILE" |
+  while IFS=$'\t' read -r kind code category message; do
+    kind=$(echo "$kind" | tr '[:upper:]' '[:lower:]')
+    example_file=""
+    for f in "$ROOT"/integration-tests/fail/errors/"$code"_*.gray "$ROOT"/integration-tests/pass/warnings/"$code"_*.gray; do
+      [ -f "$f" ] || continue
+      if [ -z "$example_file" ] || [ "$(wc -c < "$f")" -lt "$(wc -c < "$example_file")" ]; then
+        example_file="$f"
+      fi
+    done
+    example=""
+    rel=""
+    if [ -n "$example_file" ]; then
+      example=$(strip_test_header "$example_file" | go_string)
+      rel="${example_file#"$ROOT"/}"
+    fi
+    printf '\t"%s": {Kind: "%s", Category: "%s", Message: "%s", Example: "%s", ExampleFile: "%s"},\n' \
+      "$code" "$kind" "$category" "$message" "$example" "$rel"
+  done
+  echo "}"
+} > "$OUT"
+
+gofmt -w "$OUT" 2>/dev/null || true
+echo "generate_explain.sh: wrote $OUT ($(grep -c '^	"' "$OUT") codes)"
@@ -3,6 +3,8 @@
 # Usage: ./scripts/generate_stdlib_man.sh
 # Reads all *.h files under grayc/src/stdlib/ that contain @module tags.
 # Generates cli/stdlib_man_data.go (committed, do not edit by hand).
+# Release builds parse the embedded headers at runtime instead (see
+# cli/stdlibman.go); this output is the fallback for dev builds.
 set -e
 
 SCRIPT_DIR="$(cd "$(dirname "$0")" && pwd)"